@@ -4,14 +4,34 @@ import (
 	"context"
 	"time"
 
+	"e-commerce/internal/admin"
 	"e-commerce/internal/brand"
 	"e-commerce/internal/cache"
+	"e-commerce/internal/cart"
 	"e-commerce/internal/category"
 	"e-commerce/internal/config"
+	"e-commerce/internal/currency"
 	"e-commerce/internal/database"
+	"e-commerce/internal/health"
 	"e-commerce/internal/imagestorage"
+	"e-commerce/internal/ingredient"
+	"e-commerce/internal/inventory"
+	"e-commerce/internal/order"
+	"e-commerce/internal/payment"
 	"e-commerce/internal/product"
+	"e-commerce/internal/promotion"
+	"e-commerce/internal/question"
+	"e-commerce/internal/recommendation"
+	"e-commerce/internal/reservation"
+	"e-commerce/internal/returns"
+	"e-commerce/internal/review"
+	"e-commerce/internal/routine"
+	"e-commerce/internal/shipping"
 	"e-commerce/internal/skintype"
+	"e-commerce/internal/tax"
+	"e-commerce/internal/variant"
+	"e-commerce/internal/warehouse"
+	"e-commerce/internal/wishlist"
 
 	_ "e-commerce/docs/swagger"
 
@@ -61,7 +81,7 @@ func main() {
 
 	cacheClient, err := cache.New(ctx, &cfg.Cache)
 	if err != nil {
-		logrus.WithError(err).Fatal("Failed to connect to cache")
+		logrus.WithError(err).Fatal("Failed to initialize cache")
 	}
 	defer cacheClient.Close()
 
@@ -71,27 +91,135 @@ func main() {
 	}
 	defer cacheClient.Close()
 
-	productRepo := product.NewProductRepository(db.Pool, cacheClient.Client, minioClient.Client)
-	brandRepo := brand.NewBrandRepository(db.Pool, cacheClient.Client)
-	categoryRepo := category.NewCategoryRepository(db.Pool, cacheClient.Client)
-	skinTypeRepo := skintype.NewSkinTypeRepository(db.Pool, cacheClient.Client)
+	exchangeRateRepo := currency.NewExchangeRateRepository(db.Pool, cacheClient)
+	productRepo := product.NewProductRepository(db.Pool, cacheClient, minioClient.Client)
+	brandRepo := brand.NewBrandRepository(db.Pool, cacheClient)
+	categoryRepo := category.NewCategoryRepository(db.Pool, cacheClient)
+	skinTypeRepo := skintype.NewSkinTypeRepository(db.Pool, cacheClient)
+	ingredientRepo := ingredient.NewIngredientRepository(db.Pool, cacheClient)
+	variantRepo := variant.NewVariantRepository(db.Pool, cacheClient)
+	inventoryRepo := inventory.NewInventoryRepository(db.Pool, cacheClient)
+	warehouseRepo := warehouse.NewWarehouseRepository(db.Pool, cacheClient)
+	reservationRepo := reservation.NewReservationRepository(db.Pool)
+	cartRepo := cart.NewCartRepository(db.Pool, cacheClient, cfg.Carts.GuestTTL)
+	promotionRepo := promotion.NewPromotionRepository(db.Pool)
+	orderRepo := order.NewOrderRepository(db.Pool, inventoryRepo, reservationRepo)
+	paymentRepo := payment.NewPaymentRepository(db.Pool)
+	returnRepo := returns.NewReturnRepository(db.Pool, inventoryRepo)
+	taxRepo := tax.NewTaxRepository(db.Pool)
+	shippingRepo := shipping.NewShippingRepository(db.Pool)
+	wishlistRepo := wishlist.NewWishlistRepository(db.Pool)
+	reviewRepo := review.NewReviewRepository(db.Pool, cacheClient)
+	questionRepo := question.NewQuestionRepository(db.Pool)
+	routineRepo := routine.NewRoutineRepository(db.Pool)
+	recommendationRepo := recommendation.NewRecommendationRepository(db.Pool, cacheClient)
 
-	productService := product.NewProductService(productRepo)
+	currencyService := currency.NewCurrencyService(exchangeRateRepo, &cfg.Currency)
+	productService := product.NewProductService(productRepo, currencyService)
 	brandService := brand.NewBrandService(brandRepo)
 	categoryService := category.NewCategoryService(categoryRepo)
 	skinTypeService := skintype.NewSkinTypeService(skinTypeRepo)
+	ingredientService := ingredient.NewIngredientService(ingredientRepo)
+	variantService := variant.NewVariantService(variantRepo, currencyService.BaseCurrency())
+	inventoryService := inventory.NewInventoryService(inventoryRepo)
+	warehouseService := warehouse.NewWarehouseService(warehouseRepo)
+	reservationService := reservation.NewReservationService(reservationRepo, &cfg.Reservations)
+	taxService := tax.NewTaxService(taxRepo, &cfg.Tax)
+	cartService := cart.NewCartService(cartRepo, taxService, currencyService.BaseCurrency())
+	shippingService := shipping.NewShippingService(shippingRepo, cartService)
+	wishlistService := wishlist.NewWishlistService(wishlistRepo, currencyService.BaseCurrency())
+	reviewService := review.NewReviewService(reviewRepo)
+	questionService := question.NewQuestionService(questionRepo)
+	routineService := routine.NewRoutineService(routineRepo)
+	recommendationService := recommendation.NewRecommendationService(recommendationRepo, &cfg.Recommendations, currencyService.BaseCurrency())
+	promotionService := promotion.NewPromotionService(promotionRepo, cartService)
+	orderService := order.NewOrderService(orderRepo, cartService, promotionService, taxService)
+
+	paymentProvider, err := payment.NewProvider(&cfg.Payments)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to initialize payment provider")
+	}
+	paymentService := payment.NewPaymentService(paymentRepo, paymentProvider, orderService)
+	returnService := returns.NewReturnService(returnRepo, orderService, paymentService)
+
+	sweeper := reservation.NewSweeper(reservationRepo, cfg.Reservations.SweepInterval)
+	sweeper.Start()
+	defer sweeper.Stop()
+
+	if cfg.Cache.Warmup.Enabled {
+		warmer := cache.NewWarmer(&cfg.Cache.Warmup)
+		defer warmer.Stop()
+
+		warmer.Register("brand", func(ctx context.Context) error {
+			_, err := brandService.GetAllBrands(ctx)
+			return err
+		})
+		warmer.Register("category", func(ctx context.Context) error {
+			_, err := categoryService.GetAllCategories(ctx)
+			return err
+		})
+		warmer.Register("skintype", func(ctx context.Context) error {
+			_, err := skinTypeService.GetAllSkinTypes(ctx)
+			return err
+		})
+		warmer.Register("ingredient", func(ctx context.Context) error {
+			_, err := ingredientService.GetAllIngredients(ctx)
+			return err
+		})
+		warmer.Register("product", func(ctx context.Context) error {
+			_, err := productService.GetAllProducts(ctx, "")
+			return err
+		})
+		warmer.RegisterSource("product", func(ctx context.Context) ([]cache.WarmupTask, error) {
+			filters, err := productService.GetPopularFilters(ctx, cfg.Cache.Warmup.TopFilters)
+			if err != nil {
+				return nil, err
+			}
+
+			tasks := make([]cache.WarmupTask, 0, len(filters))
+			for _, filter := range filters {
+				tasks = append(tasks, func(ctx context.Context) error {
+					return productService.WarmProductsByFilter(ctx, filter)
+				})
+			}
+			return tasks, nil
+		})
+
+		cacheClient.OnInvalidate(warmer.Schedule)
+		go warmer.WarmAll(ctx)
+	}
 
 	productHandler := product.NewProductHandler(productService)
+	currencyHandler := currency.NewCurrencyHandler(currencyService)
 	brandHandler := brand.NewBrandHandler(brandService)
 	categoryHandler := category.NewCategoryHandler(categoryService)
 	skinTypeHandler := skintype.NewSkinTypeHandler(skinTypeService)
+	ingredientHandler := ingredient.NewIngredientHandler(ingredientService)
+	variantHandler := variant.NewVariantHandler(variantService)
+	inventoryHandler := inventory.NewInventoryHandler(inventoryService)
+	warehouseHandler := warehouse.NewWarehouseHandler(warehouseService)
+	reservationHandler := reservation.NewReservationHandler(reservationService)
+	cartHandler := cart.NewCartHandler(cartService)
+	promotionHandler := promotion.NewPromotionHandler(promotionService)
+	orderHandler := order.NewOrderHandler(orderService)
+	paymentHandler := payment.NewPaymentHandler(paymentService)
+	returnHandler := returns.NewReturnHandler(returnService)
+	taxHandler := tax.NewTaxHandler(taxService)
+	shippingHandler := shipping.NewShippingHandler(shippingService)
+	wishlistHandler := wishlist.NewWishlistHandler(wishlistService)
+	reviewHandler := review.NewReviewHandler(reviewService)
+	questionHandler := question.NewQuestionHandler(questionService)
+	routineHandler := routine.NewRoutineHandler(routineService)
+	recommendationHandler := recommendation.NewRecommendationHandler(recommendationService)
+	healthHandler := health.NewHealthHandler(db.Pool, cacheClient)
+	adminHandler := admin.NewAdminHandler(admin.NewAdminService(cacheClient))
 
 	router := gin.Default()
 
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept-Currency"},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
@@ -104,6 +232,26 @@ func main() {
 	brandHandler.RegisterRoutes(router)
 	categoryHandler.RegisterRoutes(router)
 	skinTypeHandler.RegisterRoutes(router)
+	ingredientHandler.RegisterRoutes(router)
+	variantHandler.RegisterRoutes(router)
+	inventoryHandler.RegisterRoutes(router)
+	warehouseHandler.RegisterRoutes(router)
+	reservationHandler.RegisterRoutes(router)
+	cartHandler.RegisterRoutes(router)
+	promotionHandler.RegisterRoutes(router)
+	orderHandler.RegisterRoutes(router)
+	paymentHandler.RegisterRoutes(router)
+	returnHandler.RegisterRoutes(router)
+	taxHandler.RegisterRoutes(router)
+	shippingHandler.RegisterRoutes(router)
+	wishlistHandler.RegisterRoutes(router)
+	reviewHandler.RegisterRoutes(router)
+	questionHandler.RegisterRoutes(router)
+	routineHandler.RegisterRoutes(router)
+	recommendationHandler.RegisterRoutes(router)
+	healthHandler.RegisterRoutes(router)
+	adminHandler.RegisterRoutes(router)
+	currencyHandler.RegisterRoutes(router)
 
 	logrus.Info("Server is running on http://localhost:8080")
 	if err := router.Run(":8080"); err != nil {
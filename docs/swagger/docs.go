@@ -24,9 +24,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/brands": {
+        "/admin/cache/stats": {
             "get": {
-                "description": "Get a list of all brands",
+                "description": "Get hit/miss/error counters and key counts per cache prefix, plus memory usage",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,17 +34,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "brands"
+                    "admin"
                 ],
-                "summary": "Get all brands",
+                "summary": "Get cache statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domains.Brand"
-                            }
+                            "$ref": "#/definitions/domains.CacheStats"
                         }
                     },
                     "500": {
@@ -52,11 +49,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new brand with the provided details",
+            }
+        },
+        "/admin/cache/{prefix}": {
+            "delete": {
+                "description": "Delete every cache entry stored under a prefix",
                 "consumes": [
                     "application/json"
                 ],
@@ -64,29 +69,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "brands"
+                    "admin"
                 ],
-                "summary": "Create a new brand",
+                "summary": "Purge cache prefix",
                 "parameters": [
                     {
-                        "description": "Brand object",
-                        "name": "brand",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domains.Brand"
-                        }
+                        "type": "string",
+                        "description": "Cache prefix (e.g. brand, product)",
+                        "name": "prefix",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domains.Brand"
+                            "$ref": "#/definitions/domains.CachePurgeResult"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
@@ -96,13 +99,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
                     }
                 }
             }
         },
-        "/brands/{id}": {
-            "get": {
-                "description": "Get a brand by its ID",
+        "/admin/cache/{prefix}/{id}": {
+            "delete": {
+                "description": "Delete a single cache entry by prefix and ID (use \"all\" for the list entry)",
                 "consumes": [
                     "application/json"
                 ],
@@ -110,13 +119,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "brands"
+                    "admin"
                 ],
-                "summary": "Get brand by ID",
+                "summary": "Purge cache entry",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Brand ID",
+                        "type": "string",
+                        "description": "Cache prefix (e.g. brand, product)",
+                        "name": "prefix",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entry ID or key",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -126,25 +142,65 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domains.Brand"
+                            "$ref": "#/definitions/domains.CachePurgeResult"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/exchange-rates": {
+            "get": {
+                "description": "Get every exchange rate, as units of the currency per unit of the base currency",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get exchange rates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.ExchangeRate"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exchange-rates/{currency}": {
             "put": {
-                "description": "Update an existing brand",
+                "description": "Create or replace the exchange rate of a currency",
                 "consumes": [
                     "application/json"
                 ],
@@ -152,24 +208,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "brands"
+                    "admin"
                 ],
-                "summary": "Update brand",
+                "summary": "Set exchange rate",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Brand ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "ISO 4217 currency code",
+                        "name": "currency",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Brand object",
-                        "name": "brand",
+                        "description": "Units of the currency per unit of the base currency",
+                        "name": "rate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domains.Brand"
+                            "$ref": "#/definitions/domains.ExchangeRateRequest"
                         }
                     }
                 ],
@@ -177,7 +233,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domains.Brand"
+                            "$ref": "#/definitions/domains.ExchangeRate"
                         }
                     },
                     "400": {
@@ -186,8 +242,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/domains.Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
@@ -195,7 +251,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete a brand by its ID",
+                "description": "Delete the exchange rate of a currency; prices can no longer be shown in it",
                 "consumes": [
                     "application/json"
                 ],
@@ -203,14 +259,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "brands"
+                    "admin"
                 ],
-                "summary": "Delete brand",
+                "summary": "Delete exchange rate",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Brand ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "ISO 4217 currency code",
+                        "name": "currency",
                         "in": "path",
                         "required": true
                     }
@@ -230,32 +286,6 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
-                    }
-                }
-            }
-        },
-        "/categories": {
-            "get": {
-                "description": "Get a list of all categories",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "categories"
-                ],
-                "summary": "Get all categories",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domains.Category"
-                            }
-                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -264,9 +294,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new category with the provided details",
+            }
+        },
+        "/answers": {
+            "get": {
+                "description": "Get answers for moderation, newest first. Defaults to the pending queue.",
                 "consumes": [
                     "application/json"
                 ],
@@ -274,25 +306,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "questions"
                 ],
-                "summary": "Create a new category",
+                "summary": "Get answers",
                 "parameters": [
                     {
-                        "description": "Category object",
-                        "name": "category",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domains.Category"
-                        }
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "default": "pending",
+                        "description": "Answer status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domains.Category"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.Answer"
+                            }
                         }
                     },
                     "400": {
@@ -310,9 +366,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/{id}": {
-            "get": {
-                "description": "Get a category by its ID",
+        "/answers/{id}": {
+            "delete": {
+                "description": "Delete an answer and its votes",
                 "consumes": [
                     "application/json"
                 ],
@@ -320,24 +376,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "questions"
                 ],
-                "summary": "Get category by ID",
+                "summary": "Delete answer",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
+                        "description": "Answer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domains.Category"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -352,9 +405,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/answers/{id}/moderation": {
             "put": {
-                "description": "Update an existing category",
+                "description": "Approve or reject an answer",
                 "consumes": [
                     "application/json"
                 ],
@@ -362,24 +417,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "questions"
                 ],
-                "summary": "Update category",
+                "summary": "Moderate answer",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
+                        "description": "Answer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Category object",
-                        "name": "category",
+                        "description": "Moderation decision",
+                        "name": "moderation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domains.Category"
+                            "$ref": "#/definitions/domains.ModerationRequest"
                         }
                     }
                 ],
@@ -387,7 +442,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domains.Category"
+                            "$ref": "#/definitions/domains.Answer"
                         }
                     },
                     "400": {
@@ -401,11 +456,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a category by its ID",
+            }
+        },
+        "/answers/{id}/upvote": {
+            "post": {
+                "description": "Mark a published answer as helpful. Each customer counts once.",
                 "consumes": [
                     "application/json"
                 ],
@@ -413,21 +476,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "questions"
                 ],
-                "summary": "Delete category",
+                "summary": "Upvote answer",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
+                        "description": "Answer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Voting customer",
+                        "name": "vote",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.UpvoteRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Answer"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -440,13 +515,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
                     }
                 }
             }
         },
-        "/products": {
+        "/brands": {
             "get": {
-                "description": "Get a list of all products",
+                "description": "Get a list of all brands",
                 "consumes": [
                     "application/json"
                 ],
@@ -454,16 +535,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "brands"
                 ],
-                "summary": "Get all products",
+                "summary": "Get all brands",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domains.ProductResponse"
+                                "$ref": "#/definitions/domains.Brand"
                             }
                         }
                     },
@@ -476,7 +557,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new product with the provided details",
+                "description": "Create a new brand with the provided details",
                 "consumes": [
                     "application/json"
                 ],
@@ -484,17 +565,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "brands"
                 ],
-                "summary": "Create a new product",
+                "summary": "Create a new brand",
                 "parameters": [
                     {
-                        "description": "Product object",
-                        "name": "product",
+                        "description": "Brand object",
+                        "name": "brand",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domains.ProductRequest"
+                            "$ref": "#/definitions/domains.Brand"
                         }
                     }
                 ],
@@ -502,7 +583,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domains.ProductResponse"
+                            "$ref": "#/definitions/domains.Brand"
                         }
                     },
                     "400": {
@@ -520,9 +601,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/filter": {
+        "/brands/{id}": {
             "get": {
-                "description": "Get products filtered by various criteria",
+                "description": "Get a brand by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -530,63 +611,92 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "brands"
                 ],
-                "summary": "Filter products",
+                "summary": "Get brand by ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Comma-separated list of skin type IDs",
-                        "name": "skin-type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comma-separated list of brand IDs",
-                        "name": "brand",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Brand"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Comma-separated list of category IDs",
-                        "name": "category",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing brand",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "brands"
+                ],
+                "summary": "Update brand",
+                "parameters": [
                     {
-                        "type": "number",
-                        "description": "Minimum price",
-                        "name": "min_price",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "number",
-                        "description": "Maximum price",
-                        "name": "max_price",
-                        "in": "query"
+                        "description": "Brand object",
+                        "name": "brand",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.Brand"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domains.ProductResponse"
-                            }
+                            "$ref": "#/definitions/domains.Brand"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
                     }
                 }
-            }
-        },
-        "/products/images/{imageID}": {
+            },
             "delete": {
-                "description": "Delete a product image by its ID",
+                "description": "Delete a brand by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -594,14 +704,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "brands"
                 ],
-                "summary": "Delete product image",
+                "summary": "Delete brand",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Image ID",
-                        "name": "imageID",
+                        "description": "Brand ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -625,9 +735,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}": {
+        "/carts": {
+            "post": {
+                "description": "Create an empty guest cart and return its token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carts"
+                ],
+                "summary": "Create a guest cart",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Cart"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/carts/{token}": {
             "get": {
-                "description": "Get a product by its ID",
+                "description": "Get a guest cart with current prices",
                 "consumes": [
                     "application/json"
                 ],
@@ -635,14 +774,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "carts"
                 ],
-                "summary": "Get product by ID",
+                "summary": "Get guest cart",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Cart token",
+                        "name": "token",
                         "in": "path",
                         "required": true
                     }
@@ -651,25 +790,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domains.ProductResponse"
+                            "$ref": "#/definitions/domains.Cart"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing product",
+            }
+        },
+        "/carts/{token}/items": {
+            "post": {
+                "description": "Add a product or variant to a guest cart. Adding an existing line increases its quantity.",
                 "consumes": [
                     "application/json"
                 ],
@@ -677,24 +818,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "carts"
                 ],
-                "summary": "Update product",
+                "summary": "Add item to guest cart",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Cart token",
+                        "name": "token",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Product object",
-                        "name": "product",
+                        "description": "Cart item",
+                        "name": "item",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domains.ProductRequest"
+                            "$ref": "#/definitions/domains.CartItemRequest"
                         }
                     }
                 ],
@@ -702,7 +843,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domains.ProductResponse"
+                            "$ref": "#/definitions/domains.Cart"
                         }
                     },
                     "400": {
@@ -716,11 +857,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a product by its ID",
+            }
+        },
+        "/carts/{token}/items/{itemID}": {
+            "put": {
+                "description": "Set the quantity of a guest cart line",
                 "consumes": [
                     "application/json"
                 ],
@@ -728,21 +877,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "carts"
                 ],
-                "summary": "Delete product",
+                "summary": "Update guest cart item",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cart token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
+                        "description": "Cart item ID",
+                        "name": "itemID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New quantity",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.UpdateCartItemRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Cart"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -755,13 +923,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
                     }
                 }
-            }
-        },
-        "/products/{id}/images": {
-            "get": {
-                "description": "Get all images for a product",
+            },
+            "delete": {
+                "description": "Remove a line from a guest cart",
                 "consumes": [
                     "application/json"
                 ],
@@ -769,14 +941,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "carts"
                 ],
-                "summary": "Get product images",
+                "summary": "Remove guest cart item",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cart token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
+                        "description": "Cart item ID",
+                        "name": "itemID",
                         "in": "path",
                         "required": true
                     }
@@ -785,10 +964,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domains.ProductImage"
-                            }
+                            "$ref": "#/definitions/domains.Cart"
                         }
                     },
                     "400": {
@@ -797,6 +973,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domains.Error"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -804,52 +986,162 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Upload an image for a product",
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Get a list of all categories",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "categories"
                 ],
-                "summary": "Upload product image",
+                "summary": "Get all categories",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.Category"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new category with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Create a new category",
+                "parameters": [
+                    {
+                        "description": "Category object",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.Category"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Category"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}": {
+            "get": {
+                "description": "Get a category by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Get category by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Category"
+                        }
                     },
-                    {
-                        "type": "file",
-                        "description": "Image file",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Update category",
+                "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "Whether this is the main image",
-                        "name": "is_main",
-                        "in": "formData"
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Alternative text for the image",
-                        "name": "alt_text",
-                        "in": "formData"
+                        "description": "Category object",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.Category"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domains.ProductImage"
+                            "$ref": "#/definitions/domains.Category"
                         }
                     },
                     "400": {
@@ -858,8 +1150,47 @@ const docTemplate = `{
                             "$ref": "#/definitions/domains.Error"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a category by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Delete category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
@@ -867,9 +1198,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/skin-types": {
+        "/health": {
             "get": {
-                "description": "Get a list of all skin types",
+                "description": "Report database and cache availability. The service stays up with the cache disabled while Redis is down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/domains.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients": {
+            "get": {
+                "description": "Get a list of all ingredients ordered by INCI name",
                 "consumes": [
                     "application/json"
                 ],
@@ -877,16 +1234,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "skin-types"
+                    "ingredients"
                 ],
-                "summary": "Get all skin types",
+                "summary": "Get all ingredients",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/domains.SkinType"
+                                "$ref": "#/definitions/domains.Ingredient"
                             }
                         }
                     },
@@ -899,7 +1256,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new skin type with the provided details",
+                "description": "Create a new ingredient with the provided INCI details",
                 "consumes": [
                     "application/json"
                 ],
@@ -907,17 +1264,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "skin-types"
+                    "ingredients"
                 ],
-                "summary": "Create a new skin type",
+                "summary": "Create a new ingredient",
                 "parameters": [
                     {
-                        "description": "Skin Type object",
-                        "name": "skinType",
+                        "description": "Ingredient object",
+                        "name": "ingredient",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domains.SkinType"
+                            "$ref": "#/definitions/domains.Ingredient"
                         }
                     }
                 ],
@@ -925,7 +1282,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domains.SkinType"
+                            "$ref": "#/definitions/domains.Ingredient"
                         }
                     },
                     "400": {
@@ -934,6 +1291,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domains.Error"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -943,9 +1306,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/skin-types/{id}": {
+        "/ingredients/{id}": {
             "get": {
-                "description": "Get a skin type by its ID",
+                "description": "Get an ingredient by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -953,13 +1316,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "skin-types"
+                    "ingredients"
                 ],
-                "summary": "Get skin type by ID",
+                "summary": "Get ingredient by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Skin Type ID",
+                        "description": "Ingredient ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -969,7 +1332,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domains.SkinType"
+                            "$ref": "#/definitions/domains.Ingredient"
                         }
                     },
                     "400": {
@@ -987,7 +1350,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an existing skin type",
+                "description": "Update an existing ingredient",
                 "consumes": [
                     "application/json"
                 ],
@@ -995,24 +1358,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "skin-types"
+                    "ingredients"
                 ],
-                "summary": "Update skin type",
+                "summary": "Update ingredient",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Skin Type ID",
+                        "description": "Ingredient ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Skin Type object",
-                        "name": "skinType",
+                        "description": "Ingredient object",
+                        "name": "ingredient",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domains.SkinType"
+                            "$ref": "#/definitions/domains.Ingredient"
                         }
                     }
                 ],
@@ -1020,7 +1383,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domains.SkinType"
+                            "$ref": "#/definitions/domains.Ingredient"
                         }
                     },
                     "400": {
@@ -1034,11 +1397,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domains.Error"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Delete a skin type by its ID",
+                "description": "Delete an ingredient by its ID and remove it from every product",
                 "consumes": [
                     "application/json"
                 ],
@@ -1046,13 +1415,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "skin-types"
+                    "ingredients"
                 ],
-                "summary": "Delete skin type",
+                "summary": "Delete ingredient",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Skin Type ID",
+                        "description": "Ingredient ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1076,27 +1445,7978 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domains.Brand": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
+        },
+        "/inventory/adjustments": {
+            "post": {
+                "description": "Record a receipt, sale, return or manual adjustment and update the stock level",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Adjust stock",
+                "parameters": [
+                    {
+                        "description": "Stock adjustment",
+                        "name": "adjustment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.StockAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.StockAdjustmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/allocations": {
+            "post": {
+                "description": "Choose warehouses for an order line by priority and availability without reserving stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Allocate stock",
+                "parameters": [
+                    {
+                        "description": "Order line",
+                        "name": "allocation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.AllocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Allocation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/products/{id}": {
+            "get": {
+                "description": "Get stock levels of a product and its variants in every warehouse",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Get stock levels",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.StockLevel"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/products/{id}/movements": {
+            "get": {
+                "description": "Get the stock movement ledger of a product, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Get stock movements",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.StockMovement"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "description": "List orders newest first, optionally by customer, status and creation date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "List orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC 3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC 3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.Order"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Convert a guest or customer cart into a pending order with snapshotted prices and promotions applied",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Checkout",
+                "parameters": [
+                    {
+                        "description": "Cart to check out",
+                        "name": "checkout",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.CheckoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "description": "Get an order with its lines and status history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Get order by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/payments": {
+            "get": {
+                "description": "Get all payment attempts of an order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get order payments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.Payment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Authorize the total of a pending order with the configured payment provider",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Authorize payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment method",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.PaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/returns": {
+            "get": {
+                "description": "Get all return requests of an order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Get order returns",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.Return"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Open a return for a line of a delivered order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Request a return",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Return request",
+                        "name": "return",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.CreateReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Return"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/transitions": {
+            "post": {
+                "description": "Move an order along pending → paid → fulfilled → shipped → delivered, or to cancelled/refunded. Invalid transitions are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Change order status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target status",
+                        "name": "transition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.OrderTransitionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}": {
+            "get": {
+                "description": "Get a payment by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get payment by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/capture": {
+            "post": {
+                "description": "Capture an authorized payment and mark its order as paid",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Capture payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/refund": {
+            "post": {
+                "description": "Refund part or all of a captured payment. A full refund marks the order as refunded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Refund payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Refund amount",
+                        "name": "refund",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/domains.RefundRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/void": {
+            "post": {
+                "description": "Release an authorized payment and cancel its order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Void payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Get a list of all products",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get all products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Currency to show prices in (defaults to the base currency)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency to show prices in, used when the query parameter is absent",
+                        "name": "Accept-Currency",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.ProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new product with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Product object",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/filter": {
+            "get": {
+                "description": "Get products filtered by various criteria",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Filter products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of skin type IDs",
+                        "name": "skin-type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of brand IDs",
+                        "name": "brand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of category IDs",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum price in the requested currency, with at most two decimal places (matched against variant prices when the product has variants)",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum price in the requested currency, with at most two decimal places (matched against variant prices when the product has variants)",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only products with stock on hand",
+                        "name": "in_stock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of ingredient IDs the product must all contain",
+                        "name": "with_ingredient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of ingredient IDs the product must not contain, e.g. fragrance or alcohol",
+                        "name": "without_ingredient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum average rating from approved reviews, between 1 and 5",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "rating"
+                        ],
+                        "type": "string",
+                        "description": "Sort order: rating sorts the best rated products first (defaults to product ID)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency to show prices in (defaults to the base currency)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency to show prices in, used when the query parameter is absent",
+                        "name": "Accept-Currency",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.ProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/images/{imageID}": {
+            "delete": {
+                "description": "Delete a product image by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete product image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "imageID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Get a product by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get product by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency to show prices in (defaults to the base currency)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency to show prices in, used when the query parameter is absent",
+                        "name": "Accept-Currency",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Update product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product object",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a product by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/availability": {
+            "get": {
+                "description": "Get stock on hand in active warehouses, active reservations and the resulting available quantity",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Get available-to-sell quantity",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "variant_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Availability"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images": {
+            "get": {
+                "description": "Get all images for a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get product images",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.ProductImage"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Upload an image for a product",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Upload product image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether this is the main image",
+                        "name": "is_main",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alternative text for the image",
+                        "name": "alt_text",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ProductImage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/price-history": {
+            "get": {
+                "description": "Get every price and sale a product has had, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get product price history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.ProductPrice"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/questions": {
+            "get": {
+                "description": "Get a page of a product's published questions, newest first, with their published answers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Get product questions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.Question"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Post a question about a product. The question is queued for moderation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Ask a question about a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Question object",
+                        "name": "question",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.QuestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Question"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/reviews": {
+            "get": {
+                "description": "Get the approved reviews of a product, newest first, optionally only those by reviewers of a skin type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Get product reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Skin type ID",
+                        "name": "skin_type_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.Review"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Post a rating and review for a product. The review is queued for moderation and counts towards the rating once approved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Review a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review object",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/variants": {
+            "get": {
+                "description": "Get all variants of a product ordered by price",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "variants"
+                ],
+                "summary": "Get product variants",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.ProductVariant"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a variant (SKU) of a product, e.g. a volume or shade",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "variants"
+                ],
+                "summary": "Create a product variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant object",
+                        "name": "variant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ProductVariantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ProductVariant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/variants/{variantID}": {
+            "get": {
+                "description": "Get a single variant of a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "variants"
+                ],
+                "summary": "Get product variant by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "variantID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ProductVariant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing variant of a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "variants"
+                ],
+                "summary": "Update product variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "variantID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant object",
+                        "name": "variant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ProductVariantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ProductVariant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a variant of a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "variants"
+                ],
+                "summary": "Delete product variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "variantID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions": {
+            "get": {
+                "description": "Get a list of all promotions ordered by priority",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Get all promotions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.Promotion"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a percentage or fixed discount, optionally limited to products, brands, categories or skin types and behind a coupon code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Create a new promotion",
+                "parameters": [
+                    {
+                        "description": "Promotion object",
+                        "name": "promotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.PromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/apply": {
+            "post": {
+                "description": "Apply automatic promotions and coupon codes to a cart and return a per-line breakdown. Nothing is redeemed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Price a cart with promotions",
+                "parameters": [
+                    {
+                        "description": "Cart and coupon codes",
+                        "name": "pricing",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.PricingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.PricingResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/{id}": {
+            "get": {
+                "description": "Get a promotion by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Get promotion by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the rules of an existing promotion. The usage count is kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Update promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promotion object",
+                        "name": "promotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.PromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a promotion by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Delete promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/questionnaire": {
+            "get": {
+                "description": "Get all questions with their options in display order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "Get the skin questionnaire",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.QuestionnaireQuestion"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/questionnaire/profile": {
+            "post": {
+                "description": "Turn questionnaire answers into a skin profile of weighted skin types and concerns",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "Build a skin profile",
+                "parameters": [
+                    {
+                        "description": "Chosen options",
+                        "name": "answers",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.QuestionnaireAnswers"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.SkinProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/questionnaire/questions": {
+            "post": {
+                "description": "Add a question with its options and the weights each option adds to the profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "Create a questionnaire question",
+                "parameters": [
+                    {
+                        "description": "Question",
+                        "name": "question",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.QuestionnaireQuestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.QuestionnaireQuestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/questionnaire/questions/{id}": {
+            "get": {
+                "description": "Get a question with its options",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "Get questionnaire question by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.QuestionnaireQuestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace a question and all of its options; the options get new IDs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "Update questionnaire question",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Question",
+                        "name": "question",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.QuestionnaireQuestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.QuestionnaireQuestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a question and its options",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "Delete questionnaire question",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions": {
+            "get": {
+                "description": "Get questions for moderation, newest first. Defaults to the pending queue.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Get questions",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "default": "pending",
+                        "description": "Question status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.Question"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/{id}": {
+            "get": {
+                "description": "Get a question, whatever its status, with its published answers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Get question by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Question"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a question and its answers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Delete question",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/{id}/answers": {
+            "post": {
+                "description": "Answer a question as staff or as a customer. Staff answers are published immediately; customer answers are queued for moderation and need a published question.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Answer a question",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Answer object",
+                        "name": "answer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.AnswerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Answer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/{id}/moderation": {
+            "put": {
+                "description": "Approve or reject a question",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Moderate question",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Moderation decision",
+                        "name": "moderation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ModerationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Question"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/recommendations": {
+            "get": {
+                "description": "Rank products by how well they match a skin profile, their rating and brand diversity. Pass the profile returned by the questionnaire as comma-separated \"key:weight\" pairs; a missing weight counts as 1.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "Get product recommendations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Skin type weights, e.g. 2:0.6,5:0.4",
+                        "name": "skin_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Concern weights, e.g. acne:0.7,sensitivity:0.3",
+                        "name": "concerns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of products to return",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.Recommendation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations": {
+            "post": {
+                "description": "Hold quantity of a product or variant until the reservation expires or is released",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Reserve stock",
+                "parameters": [
+                    {
+                        "description": "Reservation",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ReservationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}": {
+            "get": {
+                "description": "Get a stock reservation by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Get reservation by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Release an active reservation and return its quantity to available stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Release reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{id}": {
+            "get": {
+                "description": "Get a return request with its status history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Get return by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Return ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Return"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{id}/approve": {
+            "post": {
+                "description": "Approve a requested return and optionally restock the goods",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Approve return",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Return ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Approval",
+                        "name": "approval",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ReturnApprovalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Return"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{id}/refund": {
+            "post": {
+                "description": "Refund an approved return, fully or partially, through the order payment. Retrying a return left refunding completes it once the payment shows the refund",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Refund return",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Return ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Refund amount",
+                        "name": "refund",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ReturnRefundRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Return"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{id}/reject": {
+            "post": {
+                "description": "Reject a requested return",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "returns"
+                ],
+                "summary": "Reject return",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Return ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection",
+                        "name": "rejection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ReturnRejectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Return"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews": {
+            "get": {
+                "description": "Get reviews for moderation, newest first. Defaults to the pending queue.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Get reviews",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "default": "pending",
+                        "description": "Review status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.Review"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}": {
+            "get": {
+                "description": "Get a review by its ID, whatever its status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Get review by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a review and refresh the product's rating",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Delete review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/moderation": {
+            "put": {
+                "description": "Approve or reject a review and refresh the product's rating",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Moderate review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Moderation decision",
+                        "name": "moderation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ModerationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/routines/check": {
+            "post": {
+                "description": "Find ingredient conflicts between the products of a routine and suggest which to use in the morning and which in the evening",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routines"
+                ],
+                "summary": "Check a skincare routine",
+                "parameters": [
+                    {
+                        "description": "Products used together",
+                        "name": "routine",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.RoutineCheckRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.RoutineCheck"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/routines/conflicts": {
+            "get": {
+                "description": "Get conflict rules, optionally only those involving an ingredient",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routines"
+                ],
+                "summary": "List ingredient conflicts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ingredient ID",
+                        "name": "ingredient_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.IngredientConflict"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a rule that two ingredients should not be used together",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routines"
+                ],
+                "summary": "Create an ingredient conflict",
+                "parameters": [
+                    {
+                        "description": "Conflict rule",
+                        "name": "conflict",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.IngredientConflictRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.IngredientConflict"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/routines/conflicts/{id}": {
+            "get": {
+                "description": "Get a conflict rule by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routines"
+                ],
+                "summary": "Get ingredient conflict by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Conflict ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.IngredientConflict"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace a conflict rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routines"
+                ],
+                "summary": "Update ingredient conflict",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Conflict ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Conflict rule",
+                        "name": "conflict",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.IngredientConflictRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.IngredientConflict"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a conflict rule by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routines"
+                ],
+                "summary": "Delete ingredient conflict",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Conflict ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/methods": {
+            "get": {
+                "description": "Get shipping methods, optionally only those of a zone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Get shipping methods",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shipping zone ID",
+                        "name": "zone_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.ShippingMethod"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a flat rate, weight based, free over threshold or pickup method in a zone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Create a new shipping method",
+                "parameters": [
+                    {
+                        "description": "Shipping method object",
+                        "name": "method",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ShippingMethodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ShippingMethod"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/methods/{id}": {
+            "get": {
+                "description": "Get a shipping method by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Get shipping method by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shipping method ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ShippingMethod"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace an existing shipping method",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Update shipping method",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shipping method ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Shipping method object",
+                        "name": "method",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ShippingMethodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ShippingMethod"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a shipping method by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Delete shipping method",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shipping method ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/quote": {
+            "post": {
+                "description": "List the active shipping methods that can ship a cart to an address, with their prices, cheapest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Quote shipping for a cart",
+                "parameters": [
+                    {
+                        "description": "Cart and address",
+                        "name": "quote",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ShippingQuoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ShippingQuote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/zones": {
+            "get": {
+                "description": "Get every shipping zone in matching order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Get all shipping zones",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.ShippingZone"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a zone covering a set of countries, optionally narrowed to postal code prefixes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Create a new shipping zone",
+                "parameters": [
+                    {
+                        "description": "Shipping zone object",
+                        "name": "zone",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ShippingZoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ShippingZone"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/zones/{id}": {
+            "get": {
+                "description": "Get a shipping zone by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Get shipping zone by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shipping zone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ShippingZone"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace an existing shipping zone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Update shipping zone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shipping zone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Shipping zone object",
+                        "name": "zone",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.ShippingZoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ShippingZone"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a shipping zone and its methods",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping"
+                ],
+                "summary": "Delete shipping zone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shipping zone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/skin-types": {
+            "get": {
+                "description": "Get a list of all skin types",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "skin-types"
+                ],
+                "summary": "Get all skin types",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.SkinType"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new skin type with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "skin-types"
+                ],
+                "summary": "Create a new skin type",
+                "parameters": [
+                    {
+                        "description": "Skin Type object",
+                        "name": "skinType",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.SkinType"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.SkinType"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/skin-types/{id}": {
+            "get": {
+                "description": "Get a skin type by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "skin-types"
+                ],
+                "summary": "Get skin type by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Skin Type ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.SkinType"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing skin type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "skin-types"
+                ],
+                "summary": "Update skin type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Skin Type ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Skin Type object",
+                        "name": "skinType",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.SkinType"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.SkinType"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a skin type by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "skin-types"
+                ],
+                "summary": "Delete skin type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Skin Type ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-classes": {
+            "get": {
+                "description": "Get every tax class",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Get all tax classes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.TaxClass"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a tax class; marking it as default takes the flag from the previous default class",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Create a new tax class",
+                "parameters": [
+                    {
+                        "description": "Tax class object",
+                        "name": "class",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.TaxClassRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.TaxClass"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-classes/{id}": {
+            "get": {
+                "description": "Get a tax class by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Get tax class by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.TaxClass"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace an existing tax class",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Update tax class",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tax class object",
+                        "name": "class",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.TaxClassRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.TaxClass"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a tax class and its rates; products and categories using it fall back to the default class",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Delete tax class",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-rates": {
+            "get": {
+                "description": "Get tax rates, optionally only those of a tax class or a country",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Get tax rates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax class ID",
+                        "name": "tax_class_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 3166-1 alpha-2 country code",
+                        "name": "country",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.TaxRate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Set the rate of a tax class for a country, or for one of its regions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Create a new tax rate",
+                "parameters": [
+                    {
+                        "description": "Tax rate object",
+                        "name": "rate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.TaxRateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.TaxRate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-rates/{id}": {
+            "put": {
+                "description": "Replace an existing tax rate",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Update tax rate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax rate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tax rate object",
+                        "name": "rate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.TaxRateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.TaxRate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a tax rate by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Delete tax rate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax rate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/cart": {
+            "get": {
+                "description": "Get the cart of a signed-in customer with current prices",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carts"
+                ],
+                "summary": "Get customer cart",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Cart"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/cart/items": {
+            "post": {
+                "description": "Add a product or variant to a customer cart. Adding an existing line increases its quantity.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carts"
+                ],
+                "summary": "Add item to customer cart",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cart item",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.CartItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Cart"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/cart/items/{itemID}": {
+            "put": {
+                "description": "Set the quantity of a customer cart line",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carts"
+                ],
+                "summary": "Update customer cart item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cart item ID",
+                        "name": "itemID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New quantity",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.UpdateCartItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Cart"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a line from a customer cart",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carts"
+                ],
+                "summary": "Remove customer cart item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cart item ID",
+                        "name": "itemID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Cart"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/cart/merge": {
+            "post": {
+                "description": "Move the lines of a guest cart into the customer cart on login and delete the guest cart",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carts"
+                ],
+                "summary": "Merge guest cart",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Guest cart token",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.MergeCartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Cart"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses": {
+            "get": {
+                "description": "Get a list of all warehouses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Get all warehouses",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domains.Warehouse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new warehouse with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Create a new warehouse",
+                "parameters": [
+                    {
+                        "description": "Warehouse object",
+                        "name": "warehouse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.WarehouseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/{id}": {
+            "get": {
+                "description": "Get a warehouse by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Get warehouse by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing warehouse",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Update warehouse",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Warehouse object",
+                        "name": "warehouse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.WarehouseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a warehouse by its ID. Warehouses that still hold stock cannot be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Delete warehouse",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/payments/{provider}": {
+            "post": {
+                "description": "Receive a signed provider notification and update the payment and order. Redelivered events are acknowledged without being applied again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Payment provider webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hex-encoded HMAC-SHA256 of the raw body",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook event",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.WebhookEvent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/wishlists/shared/{token}": {
+            "get": {
+                "description": "Get a wishlist through its public link, with current prices and stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Get shared wishlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Wishlist"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/wishlists/users/{id}": {
+            "get": {
+                "description": "Get the products a customer saved, with current prices and stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Get customer wishlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Wishlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/wishlists/users/{id}/items": {
+            "post": {
+                "description": "Save a product to a customer's wishlist. Saving a product twice has no effect.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Add product to wishlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product to save",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domains.WishlistItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Wishlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/wishlists/users/{id}/items/{productID}": {
+            "delete": {
+                "description": "Remove a saved product from a customer's wishlist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Remove product from wishlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Wishlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/wishlists/users/{id}/share": {
+            "post": {
+                "description": "Create a public link token for a customer's wishlist. Sharing again replaces the token and revokes the old link.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Share wishlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Wishlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Revoke the public link of a customer's wishlist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Stop sharing wishlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domains.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domains.Allocation": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.AllocationLine"
+                    }
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.AllocationLine": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.AllocationRequest": {
+            "type": "object",
+            "properties": {
+                "allow_split": {
+                    "type": "boolean"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.Answer": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_staff": {
+                    "type": "boolean"
+                },
+                "moderated_at": {
+                    "type": "string"
+                },
+                "moderation_note": {
+                    "type": "string"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/domains.ModerationStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "upvotes": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.AnswerRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "Yes, it contains no added fragrance or essential oils."
+                },
+                "is_staff": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.AppliedPromotion": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "260.00"
+                },
+                "coupon_code": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.Availability": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "on_hand": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "reserved": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.Brand": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.CacheHealth": {
+            "type": "object",
+            "properties": {
+                "breaker": {
+                    "type": "string",
+                    "example": "closed"
+                },
+                "consecutive_failures": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "up"
+                }
+            }
+        },
+        "domains.CacheMemoryStats": {
+            "type": "object",
+            "properties": {
+                "local_entries": {
+                    "type": "integer"
+                },
+                "used_bytes": {
+                    "type": "integer"
+                },
+                "used_human": {
+                    "type": "string",
+                    "example": "1.05M"
+                }
+            }
+        },
+        "domains.CachePrefixStats": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "integer"
+                },
+                "hit_rate": {
+                    "type": "number"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "keys": {
+                    "type": "integer"
+                },
+                "local_hits": {
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "brand"
+                }
+            }
+        },
+        "domains.CachePurgeResult": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.CacheStats": {
+            "type": "object",
+            "properties": {
+                "breaker": {
+                    "type": "string",
+                    "example": "closed"
+                },
+                "memory": {
+                    "$ref": "#/definitions/domains.CacheMemoryStats"
+                },
+                "prefixes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.CachePrefixStats"
+                    }
+                },
+                "redis_available": {
+                    "type": "boolean"
+                },
+                "redis_error": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.Cart": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "item_count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.CartItem"
+                    }
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "2599.98"
+                },
+                "tax": {
+                    "type": "string",
+                    "example": "433.33"
+                },
+                "tax_included": {
+                    "type": "boolean"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "2599.98"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.CartItem": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "line_total": {
+                    "type": "string",
+                    "example": "2599.98"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "unit_price": {
+                    "type": "string",
+                    "example": "1299.99"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.CartItemRequest": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.Category": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tax_class_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.CheckoutRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/domains.TaxAddress"
+                },
+                "cart_token": {
+                    "type": "string"
+                },
+                "coupon_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "email": {
+                    "type": "string",
+                    "example": "customer@example.com"
+                },
+                "reservation_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.Concern": {
+            "type": "string",
+            "enum": [
+                "acne",
+                "pigmentation",
+                "sensitivity",
+                "aging",
+                "dryness",
+                "oiliness"
+            ],
+            "x-enum-varnames": [
+                "ConcernAcne",
+                "ConcernPigmentation",
+                "ConcernSensitivity",
+                "ConcernAging",
+                "ConcernDryness",
+                "ConcernOiliness"
+            ]
+        },
+        "domains.ConcernWeight": {
+            "type": "object",
+            "properties": {
+                "concern": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domains.Concern"
+                        }
+                    ],
+                    "example": "acne"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 1
+                }
+            }
+        },
+        "domains.ConflictSeverity": {
+            "type": "string",
+            "enum": [
+                "low",
+                "medium",
+                "high"
+            ],
+            "x-enum-varnames": [
+                "SeverityLow",
+                "SeverityMedium",
+                "SeverityHigh"
+            ]
+        },
+        "domains.CreateReturnRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "order_item_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domains.ReturnReason"
+                        }
+                    ],
+                    "example": "damaged"
+                }
+            }
+        },
+        "domains.DiscountType": {
+            "type": "string",
+            "enum": [
+                "percentage",
+                "fixed"
+            ],
+            "x-enum-varnames": [
+                "DiscountPercentage",
+                "DiscountFixed"
+            ]
+        },
+        "domains.Error": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Error message"
+                }
+            }
+        },
+        "domains.ExchangeRate": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.ExchangeRateRequest": {
+            "type": "object",
+            "properties": {
+                "rate": {
+                    "type": "number",
+                    "example": 0.011
+                }
+            }
+        },
+        "domains.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "$ref": "#/definitions/domains.CacheHealth"
+                },
+                "database": {
+                    "type": "string",
+                    "example": "up"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "domains.Ingredient": {
+            "type": "object",
+            "properties": {
+                "comedogenic_rating": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "common_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Vitamin B3"
+                    ]
+                },
+                "function": {
+                    "type": "string",
+                    "example": "skin conditioning"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inci_name": {
+                    "type": "string",
+                    "example": "Niacinamide"
+                },
+                "is_allergen": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domains.IngredientConflict": {
+            "type": "object",
+            "properties": {
+                "conflicting_ingredient_id": {
+                    "type": "integer"
+                },
+                "conflicting_ingredient_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "explanation": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ingredient_id": {
+                    "type": "integer"
+                },
+                "ingredient_name": {
+                    "type": "string"
+                },
+                "severity": {
+                    "$ref": "#/definitions/domains.ConflictSeverity"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.IngredientConflictRequest": {
+            "type": "object",
+            "properties": {
+                "conflicting_ingredient_id": {
+                    "type": "integer"
+                },
+                "explanation": {
+                    "type": "string",
+                    "example": "Both exfoliate and together irritate the skin barrier."
+                },
+                "ingredient_id": {
+                    "type": "integer"
+                },
+                "severity": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domains.ConflictSeverity"
+                        }
+                    ],
+                    "example": "high"
+                }
+            }
+        },
+        "domains.LineDiscount": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "260.00"
+                },
+                "promotion_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.MergeCartRequest": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.ModerationRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domains.ModerationStatus"
+                        }
+                    ],
+                    "example": "approved"
+                }
+            }
+        },
+        "domains.ModerationStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "approved",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "ModerationPending",
+                "ModerationApproved",
+                "ModerationRejected"
+            ]
+        },
+        "domains.MovementType": {
+            "type": "string",
+            "enum": [
+                "receipt",
+                "sale",
+                "adjustment",
+                "return"
+            ],
+            "x-enum-varnames": [
+                "MovementReceipt",
+                "MovementSale",
+                "MovementAdjustment",
+                "MovementReturn"
+            ]
+        },
+        "domains.Order": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "260.00"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.OrderStatusChange"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.OrderItem"
+                    }
+                },
+                "promotion_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/domains.OrderStatus"
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "2599.98"
+                },
+                "tax": {
+                    "type": "string",
+                    "example": "390.00"
+                },
+                "tax_country": {
+                    "type": "string"
+                },
+                "tax_included": {
+                    "type": "boolean"
+                },
+                "tax_region": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "2339.98"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.OrderItem": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "string",
+                    "example": "260.00"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "line_total": {
+                    "type": "string",
+                    "example": "2599.98"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "tax": {
+                    "type": "string",
+                    "example": "390.00"
+                },
+                "tax_rate": {
+                    "type": "number",
+                    "example": 20
+                },
+                "unit_price": {
+                    "type": "string",
+                    "example": "1299.99"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "paid",
+                "fulfilled",
+                "shipped",
+                "delivered",
+                "cancelled",
+                "refunded"
+            ],
+            "x-enum-varnames": [
+                "OrderPending",
+                "OrderPaid",
+                "OrderFulfilled",
+                "OrderShipped",
+                "OrderDelivered",
+                "OrderCancelled",
+                "OrderRefunded"
+            ]
+        },
+        "domains.OrderStatusChange": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "$ref": "#/definitions/domains.OrderStatus"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "$ref": "#/definitions/domains.OrderStatus"
+                }
+            }
+        },
+        "domains.OrderTransitionRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domains.OrderStatus"
+                        }
+                    ],
+                    "example": "paid"
+                }
+            }
+        },
+        "domains.Payment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "2339.98"
+                },
+                "captured_amount": {
+                    "type": "string",
+                    "example": "2339.98"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "provider_ref": {
+                    "type": "string"
+                },
+                "refunded_amount": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "status": {
+                    "$ref": "#/definitions/domains.PaymentStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.PaymentRequest": {
+            "type": "object",
+            "properties": {
+                "payment_method": {
+                    "type": "string",
+                    "example": "fake_card"
+                }
+            }
+        },
+        "domains.PaymentStatus": {
+            "type": "string",
+            "enum": [
+                "authorized",
+                "captured",
+                "refunded",
+                "voided",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "PaymentAuthorized",
+                "PaymentCaptured",
+                "PaymentRefunded",
+                "PaymentVoided",
+                "PaymentFailed"
+            ]
+        },
+        "domains.PricedLine": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "string",
+                    "example": "260.00"
+                },
+                "discounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.LineDiscount"
+                    }
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "2599.98"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "2339.98"
+                },
+                "unit_price": {
+                    "type": "string",
+                    "example": "1299.99"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.PricingRequest": {
+            "type": "object",
+            "properties": {
+                "cart_token": {
+                    "type": "string"
+                },
+                "coupon_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.PricingResult": {
+            "type": "object",
+            "properties": {
+                "applied_promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.AppliedPromotion"
+                    }
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "260.00"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.PricedLine"
+                    }
+                },
+                "rejected_coupons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.RejectedCoupon"
+                    }
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "2599.98"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "2339.98"
+                }
+            }
+        },
+        "domains.ProductImage": {
+            "type": "object",
+            "properties": {
+                "alt_text": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_data": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_main": {
+                    "type": "boolean"
+                },
+                "product_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.ProductPrice": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "1299.99"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "sale_price": {
+                    "type": "string",
+                    "example": "999.99"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.ProductRequest": {
+            "type": "object",
+            "properties": {
+                "brand_id": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "concerns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.Concern"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "ingredient_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "1299.99"
+                },
+                "sale_ends_at": {
+                    "type": "string"
+                },
+                "sale_price": {
+                    "type": "string",
+                    "example": "999.99"
+                },
+                "sale_starts_at": {
+                    "type": "string"
+                },
+                "skin_type_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "tax_class_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.ProductResponse": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "$ref": "#/definitions/domains.Brand"
+                },
+                "category": {
+                    "$ref": "#/definitions/domains.Category"
+                },
+                "concerns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.Concern"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount_percent": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "in_stock": {
+                    "type": "boolean"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.Ingredient"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "original_price": {
+                    "type": "string",
+                    "example": "1299.99"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "999.99"
+                },
+                "rating_average": {
+                    "type": "number",
+                    "example": 4.6
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "sale_ends_at": {
+                    "type": "string"
+                },
+                "sale_price": {
+                    "type": "string",
+                    "example": "999.99"
+                },
+                "sale_starts_at": {
+                    "type": "string"
+                },
+                "skin_type_ratings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.SkinTypeRating"
+                    }
+                },
+                "skin_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.SkinType"
+                    }
+                },
+                "stock_quantity": {
+                    "type": "integer"
+                },
+                "tax_class_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.ProductVariant"
+                    }
+                }
+            }
+        },
+        "domains.ProductVariant": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "in_stock": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "1299.99"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "shade": {
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock_quantity": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "volume": {
+                    "type": "number"
+                },
+                "volume_unit": {
+                    "type": "string"
+                },
+                "weight_grams": {
+                    "type": "number"
+                }
+            }
+        },
+        "domains.ProductVariantRequest": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "1299.99"
+                },
+                "shade": {
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "volume": {
+                    "type": "number"
+                },
+                "volume_unit": {
+                    "type": "string"
+                },
+                "weight_grams": {
+                    "type": "number"
+                }
+            }
+        },
+        "domains.Promotion": {
+            "type": "object",
+            "properties": {
+                "coupon_code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount_type": {
+                    "$ref": "#/definitions/domains.DiscountType"
+                },
+                "discount_value": {
+                    "type": "string",
+                    "example": "20.00"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "min_order_amount": {
+                    "type": "string",
+                    "example": "3000.00"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "stackable": {
+                    "type": "boolean"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "target": {
+                    "$ref": "#/definitions/domains.PromotionTarget"
+                },
+                "target_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "usage_count": {
+                    "type": "integer"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.PromotionRequest": {
+            "type": "object",
+            "properties": {
+                "coupon_code": {
+                    "type": "string",
+                    "example": "SPRING20"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domains.DiscountType"
+                        }
+                    ],
+                    "example": "percentage"
+                },
+                "discount_value": {
+                    "type": "string",
+                    "example": "20.00"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "min_order_amount": {
+                    "type": "string",
+                    "example": "3000.00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "20% off CeraVe"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "stackable": {
+                    "type": "boolean"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "target": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domains.PromotionTarget"
+                        }
+                    ],
+                    "example": "brand"
+                },
+                "target_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "usage_limit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.PromotionTarget": {
+            "type": "string",
+            "enum": [
+                "all",
+                "product",
+                "brand",
+                "category",
+                "skin_type"
+            ],
+            "x-enum-varnames": [
+                "TargetAll",
+                "TargetProduct",
+                "TargetBrand",
+                "TargetCategory",
+                "TargetSkinType"
+            ]
+        },
+        "domains.Question": {
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.Answer"
+                    }
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "moderated_at": {
+                    "type": "string"
+                },
+                "moderation_note": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/domains.ModerationStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.QuestionRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "Is this fragrance-free?"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.QuestionnaireAnswer": {
+            "type": "object",
+            "properties": {
+                "option_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "question_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.QuestionnaireAnswers": {
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.QuestionnaireAnswer"
+                    }
+                }
+            }
+        },
+        "domains.QuestionnaireOption": {
+            "type": "object",
+            "properties": {
+                "concerns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.ConcernWeight"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "skin_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.SkinTypeWeight"
+                    }
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.QuestionnaireOptionRequest": {
+            "type": "object",
+            "properties": {
+                "concerns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.ConcernWeight"
+                    }
+                },
+                "skin_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.SkinTypeWeight"
+                    }
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Shiny by midday"
+                }
+            }
+        },
+        "domains.QuestionnaireQuestion": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "multiple_choice": {
+                    "type": "boolean"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.QuestionnaireOption"
+                    }
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.QuestionnaireQuestionRequest": {
+            "type": "object",
+            "properties": {
+                "multiple_choice": {
+                    "type": "boolean"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.QuestionnaireOptionRequest"
+                    }
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "How does your skin feel by midday?"
+                }
+            }
+        },
+        "domains.Recommendation": {
+            "type": "object",
+            "properties": {
+                "match_score": {
+                    "type": "number",
+                    "example": 0.9
+                },
+                "matched_concerns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.Concern"
+                    }
+                },
+                "matched_skin_type_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "product": {
+                    "$ref": "#/definitions/domains.ProductResponse"
+                },
+                "rating_score": {
+                    "type": "number",
+                    "example": 0.75
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.82
+                }
+            }
+        },
+        "domains.RefundRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "500.00"
+                }
+            }
+        },
+        "domains.RejectedCoupon": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.Reservation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "released_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domains.ReservationStatus"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.ReservationRequest": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "ttl_seconds": {
+                    "type": "integer",
+                    "example": 900
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.ReservationStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "released",
+                "expired"
+            ],
+            "x-enum-varnames": [
+                "ReservationActive",
+                "ReservationReleased",
+                "ReservationExpired"
+            ]
+        },
+        "domains.Return": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.ReturnStatusChange"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "order_item_id": {
+                    "type": "integer"
+                },
+                "payment_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "$ref": "#/definitions/domains.ReturnReason"
+                },
+                "refund_amount": {
+                    "type": "string",
+                    "example": "1299.99"
+                },
+                "restocked": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "$ref": "#/definitions/domains.ReturnStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.ReturnApprovalRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "restock": {
+                    "type": "boolean"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.ReturnReason": {
+            "type": "string",
+            "enum": [
+                "damaged",
+                "wrong_item",
+                "allergic_reaction",
+                "not_as_described",
+                "changed_mind",
+                "other"
+            ],
+            "x-enum-varnames": [
+                "ReturnReasonDamaged",
+                "ReturnReasonWrongItem",
+                "ReturnReasonAllergicReaction",
+                "ReturnReasonNotAsDescribed",
+                "ReturnReasonChangedMind",
+                "ReturnReasonOther"
+            ]
+        },
+        "domains.ReturnRefundRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "1299.99"
+                }
+            }
+        },
+        "domains.ReturnRejectionRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.ReturnStatus": {
+            "type": "string",
+            "enum": [
+                "requested",
+                "approved",
+                "rejected",
+                "refunding",
+                "refunded"
+            ],
+            "x-enum-varnames": [
+                "ReturnRequested",
+                "ReturnApproved",
+                "ReturnRejected",
+                "ReturnRefunding",
+                "ReturnRefunded"
+            ]
+        },
+        "domains.ReturnStatusChange": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "$ref": "#/definitions/domains.ReturnStatus"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "$ref": "#/definitions/domains.ReturnStatus"
+                }
+            }
+        },
+        "domains.Review": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "moderated_at": {
+                    "type": "string"
+                },
+                "moderation_note": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "skin_type": {
+                    "$ref": "#/definitions/domains.SkinType"
+                },
+                "status": {
+                    "$ref": "#/definitions/domains.ModerationStatus"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.ReviewRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "skin_type_id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Finally a cream that does not clog pores"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.RoutineCheck": {
+            "type": "object",
+            "properties": {
+                "am": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.RoutineProduct"
+                    }
+                },
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.RoutineConflict"
+                    }
+                },
+                "pm": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.RoutineProduct"
+                    }
+                }
+            }
+        },
+        "domains.RoutineCheckRequest": {
+            "type": "object",
+            "properties": {
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "domains.RoutineConflict": {
+            "type": "object",
+            "properties": {
+                "conflicting_ingredient": {
+                    "type": "string"
+                },
+                "conflicting_product_id": {
+                    "type": "integer"
+                },
+                "conflicting_product_name": {
+                    "type": "string"
+                },
+                "explanation": {
+                    "type": "string"
+                },
+                "ingredient": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "separable": {
+                    "type": "boolean"
+                },
+                "severity": {
+                    "$ref": "#/definitions/domains.ConflictSeverity"
+                }
+            }
+        },
+        "domains.RoutineProduct": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.ShippingAddress": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string",
+                    "example": "RU"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "101000"
+                }
+            }
+        },
+        "domains.ShippingMethod": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "estimated_days": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pickup_address": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "350.00"
+                },
+                "threshold": {
+                    "type": "string",
+                    "example": "5000.00"
+                },
+                "type": {
+                    "$ref": "#/definitions/domains.ShippingMethodType"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "weight_tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.WeightTier"
+                    }
+                },
+                "zone_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.ShippingMethodRequest": {
+            "type": "object",
+            "properties": {
+                "estimated_days": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Courier"
+                },
+                "pickup_address": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "350.00"
+                },
+                "threshold": {
+                    "type": "string",
+                    "example": "5000.00"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domains.ShippingMethodType"
+                        }
+                    ],
+                    "example": "flat"
+                },
+                "weight_tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.WeightTier"
+                    }
+                },
+                "zone_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.ShippingMethodType": {
+            "type": "string",
+            "enum": [
+                "flat",
+                "weight",
+                "free",
+                "pickup"
+            ],
+            "x-enum-varnames": [
+                "ShippingFlat",
+                "ShippingWeight",
+                "ShippingFree",
+                "ShippingPickup"
+            ]
+        },
+        "domains.ShippingOption": {
+            "type": "object",
+            "properties": {
+                "estimated_days": {
+                    "type": "integer"
+                },
+                "method_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pickup_address": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "350.00"
+                },
+                "type": {
+                    "$ref": "#/definitions/domains.ShippingMethodType"
+                }
+            }
+        },
+        "domains.ShippingQuote": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/domains.ShippingAddress"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.ShippingOption"
+                    }
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "2599.98"
+                },
+                "weight_grams": {
+                    "type": "number"
+                },
+                "zone_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.ShippingQuoteRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/domains.ShippingAddress"
+                },
+                "cart_token": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.ShippingZone": {
+            "type": "object",
+            "properties": {
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
-                "website": {
-                    "type": "string"
+                "postal_prefixes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.ShippingZoneRequest": {
+            "type": "object",
+            "properties": {
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "RU"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Moscow"
+                },
+                "postal_prefixes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "101",
+                        "102"
+                    ]
+                },
+                "priority": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.SkinProfile": {
+            "type": "object",
+            "properties": {
+                "concerns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.ConcernWeight"
+                    }
+                },
+                "hash": {
+                    "type": "string",
+                    "example": "3f1c9a0b7d2e4c58"
+                },
+                "skin_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.SkinTypeWeight"
+                    }
                 }
             }
         },
-        "domains.Category": {
+        "domains.SkinType": {
             "type": "object",
             "properties": {
                 "description": {
@@ -1110,110 +9430,404 @@ const docTemplate = `{
                 }
             }
         },
-        "domains.Error": {
+        "domains.SkinTypeRating": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "Error message"
+                "average": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "skin_type_id": {
+                    "type": "integer"
+                },
+                "skin_type_name": {
+                    "type": "string"
                 }
             }
         },
-        "domains.ProductImage": {
+        "domains.SkinTypeWeight": {
             "type": "object",
             "properties": {
-                "alt_text": {
+                "skin_type_id": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 1
+                }
+            }
+        },
+        "domains.StockAdjustmentRequest": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
                     "type": "string"
                 },
-                "id": {
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domains.MovementType"
+                        }
+                    ],
+                    "example": "receipt"
+                },
+                "variant_id": {
                     "type": "integer"
                 },
-                "image_data": {
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.StockAdjustmentResponse": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "$ref": "#/definitions/domains.StockLevel"
+                },
+                "movement": {
+                    "$ref": "#/definitions/domains.StockMovement"
+                }
+            }
+        },
+        "domains.StockLevel": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "image_url": {
+                "variant_id": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.StockMovement": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "is_main": {
-                    "type": "boolean"
+                "id": {
+                    "type": "integer"
                 },
                 "product_id": {
                     "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/domains.MovementType"
+                },
+                "variant_id": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "integer"
                 }
             }
         },
-        "domains.ProductRequest": {
+        "domains.TaxAddress": {
             "type": "object",
             "properties": {
-                "brand_id": {
-                    "type": "integer"
+                "country": {
+                    "type": "string",
+                    "example": "RU"
                 },
-                "category_id": {
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.TaxClass": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.TaxClassRequest": {
+            "type": "object",
+            "properties": {
                 "description": {
                     "type": "string"
                 },
+                "is_default": {
+                    "type": "boolean"
+                },
                 "name": {
+                    "type": "string",
+                    "example": "standard"
+                }
+            }
+        },
+        "domains.TaxRate": {
+            "type": "object",
+            "properties": {
+                "country": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "created_at": {
+                    "type": "string"
                 },
-                "skin_type_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number",
+                    "example": 20
+                },
+                "region": {
+                    "type": "string"
+                },
+                "tax_class_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "domains.ProductResponse": {
+        "domains.TaxRateRequest": {
             "type": "object",
             "properties": {
-                "brand": {
-                    "$ref": "#/definitions/domains.Brand"
+                "country": {
+                    "type": "string",
+                    "example": "RU"
                 },
-                "category": {
-                    "$ref": "#/definitions/domains.Category"
+                "name": {
+                    "type": "string",
+                    "example": "VAT"
                 },
-                "created_at": {
+                "rate": {
+                    "type": "number",
+                    "example": 20
+                },
+                "region": {
                     "type": "string"
                 },
-                "description": {
+                "tax_class_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.UpdateCartItemRequest": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.UpvoteRequest": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.Warehouse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "code": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.WarehouseRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "MAIN"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
                 "name": {
                     "type": "string"
                 },
+                "priority": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.WebhookEvent": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "500.00"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "provider_ref": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/domains.WebhookEventType"
+                }
+            }
+        },
+        "domains.WebhookEventType": {
+            "type": "string",
+            "enum": [
+                "payment.captured",
+                "payment.refunded",
+                "payment.voided",
+                "payment.failed"
+            ],
+            "x-enum-varnames": [
+                "WebhookPaymentCaptured",
+                "WebhookPaymentRefunded",
+                "WebhookPaymentVoided",
+                "WebhookPaymentFailed"
+            ]
+        },
+        "domains.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "processed"
+                }
+            }
+        },
+        "domains.WeightTier": {
+            "type": "object",
+            "properties": {
+                "max_weight_grams": {
+                    "type": "number",
+                    "example": 1000
+                },
                 "price": {
-                    "type": "number"
+                    "type": "string",
+                    "example": "350.00"
+                }
+            }
+        },
+        "domains.Wishlist": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
                 },
-                "skin_types": {
+                "item_count": {
+                    "type": "integer"
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domains.SkinType"
+                        "$ref": "#/definitions/domains.WishlistItem"
                     }
                 },
+                "share_token": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "domains.SkinType": {
+        "domains.WishlistItem": {
             "type": "object",
             "properties": {
-                "description": {
+                "added_at": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "in_stock": {
+                    "type": "boolean"
                 },
                 "name": {
                     "type": "string"
+                },
+                "original_price": {
+                    "type": "string",
+                    "example": "1299.99"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "999.99"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "stock_quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.WishlistItemRequest": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
                 }
             }
         }
@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"e-commerce/internal/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	service AdminService
+}
+
+func NewAdminHandler(service AdminService) *AdminHandler {
+	return &AdminHandler{service: service}
+}
+
+func (h *AdminHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/admin/cache/stats", h.GetCacheStats)
+	router.DELETE("/admin/cache/:prefix", h.PurgeCache)
+	router.DELETE("/admin/cache/:prefix/:id", h.PurgeCacheEntry)
+}
+
+// @Summary Get cache statistics
+// @Description Get hit/miss/error counters and key counts per cache prefix, plus memory usage
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} domains.CacheStats
+// @Failure 503 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /admin/cache/stats [get]
+func (h *AdminHandler) GetCacheStats(c *gin.Context) {
+	stats, err := h.service.GetCacheStats(c.Request.Context())
+	if err != nil {
+		c.JSON(cacheErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// @Summary Purge cache prefix
+// @Description Delete every cache entry stored under a prefix
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param prefix path string true "Cache prefix (e.g. brand, product)"
+// @Success 200 {object} domains.CachePurgeResult
+// @Failure 404 {object} domains.Error
+// @Failure 503 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /admin/cache/{prefix} [delete]
+func (h *AdminHandler) PurgeCache(c *gin.Context) {
+	result, err := h.service.PurgeCache(c.Request.Context(), c.Param("prefix"))
+	if err != nil {
+		c.JSON(cacheErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary Purge cache entry
+// @Description Delete a single cache entry by prefix and ID (use "all" for the list entry)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param prefix path string true "Cache prefix (e.g. brand, product)"
+// @Param id path string true "Entry ID or key"
+// @Success 200 {object} domains.CachePurgeResult
+// @Failure 404 {object} domains.Error
+// @Failure 503 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /admin/cache/{prefix}/{id} [delete]
+func (h *AdminHandler) PurgeCacheEntry(c *gin.Context) {
+	result, err := h.service.PurgeCacheEntry(c.Request.Context(), c.Param("prefix"), c.Param("id"))
+	if err != nil {
+		c.JSON(cacheErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func cacheErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, cache.ErrUnknownPrefix):
+		return http.StatusNotFound
+	case errors.Is(err, cache.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
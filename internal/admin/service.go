@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"context"
+	"e-commerce/internal/cache"
+	"e-commerce/internal/domains"
+)
+
+type AdminService interface {
+	GetCacheStats(ctx context.Context) (*domains.CacheStats, error)
+	PurgeCache(ctx context.Context, prefix string) (*domains.CachePurgeResult, error)
+	PurgeCacheEntry(ctx context.Context, prefix, id string) (*domains.CachePurgeResult, error)
+}
+
+type adminService struct {
+	cache *cache.Cache
+}
+
+func NewAdminService(cache *cache.Cache) AdminService {
+	return &adminService{cache: cache}
+}
+
+func (s *adminService) GetCacheStats(ctx context.Context) (*domains.CacheStats, error) {
+	return s.cache.Stats(ctx)
+}
+
+func (s *adminService) PurgeCache(ctx context.Context, prefix string) (*domains.CachePurgeResult, error) {
+	deleted, err := s.cache.Purge(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return &domains.CachePurgeResult{Deleted: deleted}, nil
+}
+
+func (s *adminService) PurgeCacheEntry(ctx context.Context, prefix, id string) (*domains.CachePurgeResult, error) {
+	deleted, err := s.cache.PurgeKey(ctx, prefix, id)
+	if err != nil {
+		return nil, err
+	}
+	return &domains.CachePurgeResult{Deleted: deleted}, nil
+}
@@ -26,10 +26,10 @@ type brandRepository struct {
 	cache cache.CacheRepository[domains.Brand]
 }
 
-func NewBrandRepository(db *pgxpool.Pool, redisClient *redis.Client) BrandRepository {
+func NewBrandRepository(db *pgxpool.Pool, cacheClient *cache.Cache) BrandRepository {
 	return &brandRepository{
 		db:    db,
-		cache: cache.NewCacheRepository[domains.Brand](redisClient, "brand"),
+		cache: cache.NewCacheRepository[domains.Brand](cacheClient, "brand"),
 	}
 }
 
@@ -72,7 +72,7 @@ func (r *brandRepository) GetByID(ctx context.Context, id int) (*domains.Brand,
 		logrus.Debugf("Cache hit for brand (ID: %d)", id)
 		return brand, nil
 	}
-	if !errors.Is(err, redis.Nil) {
+	if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
 		logrus.Errorf("Cache lookup failed for brand (ID: %d): %v", id, err)
 	}
 
@@ -179,7 +179,7 @@ func (r *brandRepository) GetAll(ctx context.Context) ([]*domains.Brand, error)
 		logrus.Debug("Cache hit for all brands")
 		return brands, nil
 	}
-	if !errors.Is(err, redis.Nil) {
+	if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
 		logrus.Errorf("Cache lookup failed for all brands: %v", err)
 	}
 
@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case stateClosed:
+		return "closed"
+	case stateOpen:
+		return "open"
+	case stateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// circuitBreaker stops calls to Redis after consecutive failures and lets a
+// single probe through once the cooldown has elapsed.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	state     breakerState
+	failures  int
+	threshold int
+	cooldown  time.Duration
+	openedAt  time.Time
+	probing   bool
+
+	onOpen    func(failures int)
+	onRecover func()
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// Allow reports whether a call may proceed.
+func (b *circuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateClosed:
+		return true
+	case stateOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = stateHalfOpen
+		b.probing = true
+		return true
+	default:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	}
+}
+
+func (b *circuitBreaker) Success() {
+	b.mu.Lock()
+	recovered := b.state != stateClosed
+	b.state = stateClosed
+	b.failures = 0
+	b.probing = false
+	onRecover := b.onRecover
+	b.mu.Unlock()
+
+	if recovered && onRecover != nil {
+		onRecover()
+	}
+}
+
+func (b *circuitBreaker) Failure() {
+	b.mu.Lock()
+	b.failures++
+	b.probing = false
+	opened := false
+	if b.state == stateHalfOpen || (b.state == stateClosed && b.failures >= b.threshold) {
+		opened = b.state == stateClosed
+		b.state = stateOpen
+		b.openedAt = time.Now()
+	}
+	failures := b.failures
+	onOpen := b.onOpen
+	b.mu.Unlock()
+
+	if opened && onOpen != nil {
+		onOpen(failures)
+	}
+}
+
+// Trip opens the breaker immediately, e.g. when Redis is unreachable at startup.
+func (b *circuitBreaker) Trip() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = stateOpen
+	b.openedAt = time.Now()
+}
+
+func (b *circuitBreaker) State() (breakerState, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state, b.failures
+}
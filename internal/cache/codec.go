@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ugorji/go/codec"
+)
+
+const (
+	CodecJSON     = "json"
+	CodecMsgpack  = "msgpack"
+	CodecGzipJSON = "gzip-json"
+)
+
+// Codec serializes values stored in Redis and in the local cache layer.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+func NewCodec(name string) (Codec, error) {
+	switch name {
+	case "", CodecJSON:
+		return jsonCodec{}, nil
+	case CodecMsgpack:
+		return newMsgpackCodec(), nil
+	case CodecGzipJSON:
+		return gzipJSONCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unknown cache codec %q", name)
+	}
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+type msgpackCodec struct {
+	handle *codec.MsgpackHandle
+}
+
+func newMsgpackCodec() msgpackCodec {
+	handle := &codec.MsgpackHandle{WriteExt: true}
+	handle.RawToString = true
+	return msgpackCodec{handle: handle}
+}
+
+func (c msgpackCodec) Marshal(v any) ([]byte, error) {
+	var data []byte
+	if err := codec.NewEncoderBytes(&data, c.handle).Encode(v); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c msgpackCodec) Unmarshal(data []byte, v any) error {
+	return codec.NewDecoderBytes(data, c.handle).Decode(v)
+}
+
+type gzipJSONCodec struct{}
+
+func (gzipJSONCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(v); err != nil {
+		zw.Close()
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipJSONCodec) Unmarshal(data []byte, v any) error {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type localEntry struct {
+	key       string
+	data      []byte
+	expiresAt time.Time
+}
+
+// localCache is a bounded in-process LRU with per-entry expiry. It stores
+// serialized values so callers always decode a fresh copy.
+type localCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+func newLocalCache(maxEntries int, ttl time.Duration) *localCache {
+	return &localCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func (c *localCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*localEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.removeElement(elem)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(elem)
+	return entry.data, true
+}
+
+func (c *localCache) Set(key string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*localEntry)
+		entry.data = data
+		entry.expiresAt = expiresAt
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&localEntry{key: key, data: data, expiresAt: expiresAt})
+	for c.maxEntries > 0 && c.ll.Len() > c.maxEntries {
+		c.removeElement(c.ll.Back())
+	}
+}
+
+func (c *localCache) Delete(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := c.items[key]; ok {
+			c.removeElement(elem)
+		}
+	}
+}
+
+func (c *localCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	c.items = make(map[string]*list.Element)
+}
+
+func (c *localCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
+
+func (c *localCache) removeElement(elem *list.Element) {
+	c.ll.Remove(elem)
+	delete(c.items, elem.Value.(*localEntry).key)
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// maxTrackedMembers bounds the sorted set so rarely used members are
+	// dropped.
+	maxTrackedMembers = 1000
+	// Every decayInterval all scores are multiplied by decayFactor so members
+	// that stopped being requested give way to new ones.
+	decayInterval = time.Hour
+	decayFactor   = 0.5
+)
+
+// PopularityTracker counts how often members (e.g. filter keys) are requested
+// using a Redis sorted set.
+type PopularityTracker interface {
+	Track(ctx context.Context, member string) error
+	Top(ctx context.Context, n int) ([]string, error)
+}
+
+type popularityTracker struct {
+	cache *Cache
+	key   string
+}
+
+func NewPopularityTracker(c *Cache, name string) PopularityTracker {
+	return &popularityTracker{
+		cache: c,
+		key:   c.settingsFor("popularity").keyPrefix + ":" + name,
+	}
+}
+
+// Track counts a request for member. The least requested members are
+// trimmed before the increment so that a new member is not evicted the
+// moment it is added.
+func (t *popularityTracker) Track(ctx context.Context, member string) error {
+	err := t.cache.execute(func() error {
+		_, err := t.cache.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.ZRemRangeByRank(ctx, t.key, 0, -maxTrackedMembers)
+			pipe.ZIncrBy(ctx, t.key, 1, member)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return t.decay(ctx)
+	})
+	if errors.Is(err, ErrUnavailable) {
+		return nil
+	}
+	return err
+}
+
+// decay scales all scores down once per decayInterval. The marker key makes
+// a single instance do it when several share the set.
+func (t *popularityTracker) decay(ctx context.Context) error {
+	due, err := t.cache.Client.SetNX(ctx, t.key+":decayed", 1, decayInterval).Result()
+	if err != nil || !due {
+		return err
+	}
+	return t.cache.Client.ZUnionStore(ctx, t.key, &redis.ZStore{
+		Keys:    []string{t.key},
+		Weights: []float64{decayFactor},
+	}).Err()
+}
+
+func (t *popularityTracker) Top(ctx context.Context, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	var members []string
+	err := t.cache.execute(func() error {
+		var err error
+		members, err = t.cache.Client.ZRevRange(ctx, t.key, 0, int64(n-1)).Result()
+		return err
+	})
+	return members, err
+}
@@ -2,16 +2,60 @@ package cache
 
 import (
 	"context"
+	"crypto/rand"
 	"e-commerce/internal/config"
+	"e-commerce/internal/domains"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
 )
 
+const (
+	invalidationChannel     = "cache:invalidate"
+	defaultTTL              = 20 * time.Minute
+	defaultFailureThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// ErrUnavailable is returned instead of calling Redis while the circuit
+// breaker is open.
+var ErrUnavailable = errors.New("cache unavailable")
+
 type Cache struct {
-	Client *redis.Client
-	config *config.RedisConfig
+	Client     *redis.Client
+	config     *config.RedisConfig
+	codecs     map[string]Codec
+	local      *localCache
+	pubsub     *redis.PubSub
+	instanceID string
+	breaker    *circuitBreaker
+
+	pendingMu sync.Mutex
+	pending   map[string]struct{}
+
+	listenersMu sync.RWMutex
+	listeners   []func(keyPrefix string)
+
+	statsMu sync.RWMutex
+	stats   map[string]*prefixStats
+}
+
+// entitySettings holds the resolved cache settings for one key prefix.
+type entitySettings struct {
+	keyPrefix string
+	ttl       time.Duration
+	ttlJitter time.Duration
+	codec     Codec
+}
+
+type invalidationMessage struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
 }
 
 func New(ctx context.Context, cfg *config.RedisConfig) (*Cache, error) {
@@ -21,14 +65,48 @@ func New(ctx context.Context, cfg *config.RedisConfig) (*Cache, error) {
 		DB:       cfg.DB,
 	})
 
-	if err := client.Ping(ctx).Err(); err != nil {
+	codecs, err := loadCodecs(cfg)
+	if err != nil {
+		client.Close()
 		return nil, err
 	}
 
-	return &Cache{
-		Client: client,
-		config: cfg,
-	}, nil
+	c := &Cache{
+		Client:  client,
+		config:  cfg,
+		codecs:  codecs,
+		breaker: newBreaker(&cfg.Breaker),
+		pending: make(map[string]struct{}),
+		stats:   make(map[string]*prefixStats),
+	}
+	c.breaker.onOpen = func(failures int) {
+		logrus.Warnf("Cache circuit breaker opened after %d consecutive failures, serving without cache", failures)
+	}
+	c.breaker.onRecover = func() {
+		go c.recover()
+	}
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		logrus.WithError(err).Warn("Cache is unavailable, starting with cache disabled")
+		c.breaker.Trip()
+	}
+
+	if cfg.Local.Enabled {
+		instanceID, err := newInstanceID()
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+		c.instanceID = instanceID
+		c.local = newLocalCache(cfg.Local.MaxEntries, cfg.Local.TTL)
+
+		// The subscription reconnects on its own, so a Redis outage at startup
+		// only delays invalidation delivery.
+		c.pubsub = client.Subscribe(ctx, invalidationChannel)
+		go c.listenInvalidations()
+	}
+
+	return c, nil
 }
 
 func (c *Cache) GetCache(ctx context.Context, key string, dest interface{}) error {
@@ -54,5 +132,224 @@ func (c *Cache) DeleteCache(ctx context.Context, key string) error {
 }
 
 func (c *Cache) Close() error {
+	if c.pubsub != nil {
+		c.pubsub.Close()
+	}
 	return c.Client.Close()
 }
+
+// Status reports the breaker state. When the cooldown has elapsed it pings
+// Redis itself so recovery is noticed even without cache traffic.
+func (c *Cache) Status(ctx context.Context) domains.CacheHealth {
+	if state, _ := c.breaker.State(); state != stateClosed {
+		_ = c.execute(func() error {
+			return c.Client.Ping(ctx).Err()
+		})
+	}
+
+	state, failures := c.breaker.State()
+	status := domains.CacheHealth{
+		Status:              "up",
+		Breaker:             state.String(),
+		ConsecutiveFailures: failures,
+	}
+	if state != stateClosed {
+		status.Status = "down"
+	}
+	return status
+}
+
+// execute runs fn against Redis unless the breaker is open. Cache misses do
+// not count as failures.
+func (c *Cache) execute(fn func() error) error {
+	if !c.breaker.Allow() {
+		return ErrUnavailable
+	}
+
+	err := fn()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		c.breaker.Failure()
+		return err
+	}
+	c.breaker.Success()
+	return err
+}
+
+// OnInvalidate registers fn to be called after a repository clears its list
+// entry with DeleteAll. fn receives the unnamespaced key prefix.
+func (c *Cache) OnInvalidate(fn func(keyPrefix string)) {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+
+	c.listeners = append(c.listeners, fn)
+}
+
+func (c *Cache) notifyInvalidated(keyPrefix string) {
+	c.listenersMu.RLock()
+	defer c.listenersMu.RUnlock()
+
+	for _, fn := range c.listeners {
+		fn(keyPrefix)
+	}
+}
+
+// deferInvalidation remembers a key that could not be deleted while Redis was
+// unavailable so it can be removed once the cache recovers.
+func (c *Cache) deferInvalidation(key string) {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+
+	c.pending[key] = struct{}{}
+}
+
+// recover drops entries that may have gone stale during the outage: keys whose
+// deletion was skipped and the whole local layer, since invalidation messages
+// were missed as well.
+func (c *Cache) recover() {
+	c.pendingMu.Lock()
+	keys := make([]string, 0, len(c.pending))
+	for key := range c.pending {
+		keys = append(keys, key)
+	}
+	c.pending = make(map[string]struct{})
+	c.pendingMu.Unlock()
+
+	if c.local != nil {
+		c.local.Clear()
+	}
+
+	if len(keys) > 0 {
+		ctx := context.Background()
+		if err := c.Client.Del(ctx, keys...).Err(); err != nil {
+			logrus.Warnf("Failed to apply deferred cache invalidations (Count: %d): %v", len(keys), err)
+			c.pendingMu.Lock()
+			for _, key := range keys {
+				c.pending[key] = struct{}{}
+			}
+			c.pendingMu.Unlock()
+		} else if err := c.publishInvalidation(ctx, keys...); err != nil {
+			logrus.Warnf("Failed to publish deferred cache invalidations: %v", err)
+		}
+	}
+
+	logrus.Infof("Cache recovered, applied %d deferred invalidations", len(keys))
+}
+
+// settingsFor resolves the namespaced key prefix, TTL and codec for an entity,
+// applying per-entity overrides on top of the global configuration.
+func (c *Cache) settingsFor(keyPrefix string) entitySettings {
+	settings := entitySettings{
+		keyPrefix: keyPrefix,
+		ttl:       c.config.TTL,
+		ttlJitter: c.config.TTLJitter,
+		codec:     c.codecs[c.config.Codec],
+	}
+	if c.config.Namespace != "" {
+		settings.keyPrefix = c.config.Namespace + ":" + keyPrefix
+	}
+
+	if entity, ok := c.config.Entities[keyPrefix]; ok {
+		if entity.TTL > 0 {
+			settings.ttl = entity.TTL
+		}
+		if entity.TTLJitter > 0 {
+			settings.ttlJitter = entity.TTLJitter
+		}
+		if entity.Codec != "" {
+			settings.codec = c.codecs[entity.Codec]
+		}
+	}
+	if settings.ttl <= 0 {
+		settings.ttl = defaultTTL
+	}
+
+	return settings
+}
+
+// localFor returns the in-process layer for the given key prefix, or nil when
+// the layer is disabled or not configured for that prefix.
+func (c *Cache) localFor(keyPrefix string) *localCache {
+	if c.local == nil {
+		return nil
+	}
+	if len(c.config.Local.Prefixes) == 0 {
+		return c.local
+	}
+	for _, prefix := range c.config.Local.Prefixes {
+		if prefix == keyPrefix {
+			return c.local
+		}
+	}
+	return nil
+}
+
+// publishInvalidation tells other instances to drop their local copies of keys.
+func (c *Cache) publishInvalidation(ctx context.Context, keys ...string) error {
+	if c.local == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(invalidationMessage{Origin: c.instanceID, Keys: keys})
+	if err != nil {
+		return err
+	}
+	return c.Client.Publish(ctx, invalidationChannel, payload).Err()
+}
+
+func (c *Cache) listenInvalidations() {
+	for msg := range c.pubsub.Channel() {
+		var inv invalidationMessage
+		if err := json.Unmarshal([]byte(msg.Payload), &inv); err != nil {
+			logrus.Warnf("Failed to decode cache invalidation message: %v", err)
+			continue
+		}
+		if inv.Origin == c.instanceID {
+			continue
+		}
+
+		c.local.Delete(inv.Keys...)
+		logrus.Debugf("Evicted local cache entries on invalidation from %s (keys: %v)", inv.Origin, inv.Keys)
+	}
+}
+
+func newBreaker(cfg *config.BreakerConfig) *circuitBreaker {
+	threshold := cfg.FailureThreshold
+	if threshold <= 0 {
+		threshold = defaultFailureThreshold
+	}
+	cooldown := cfg.Cooldown
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+	return newCircuitBreaker(threshold, cooldown)
+}
+
+func loadCodecs(cfg *config.RedisConfig) (map[string]Codec, error) {
+	names := []string{cfg.Codec}
+	for _, entity := range cfg.Entities {
+		if entity.Codec != "" {
+			names = append(names, entity.Codec)
+		}
+	}
+
+	codecs := make(map[string]Codec, len(names))
+	for _, name := range names {
+		if _, ok := codecs[name]; ok {
+			continue
+		}
+		codec, err := NewCodec(name)
+		if err != nil {
+			return nil, err
+		}
+		codecs[name] = codec
+	}
+	return codecs, nil
+}
+
+func newInstanceID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -2,8 +2,9 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -18,31 +19,40 @@ type CacheRepository[T any] interface {
 	SetAll(ctx context.Context, items []*T) error
 	Delete(ctx context.Context, id int) error
 	DeleteAll(ctx context.Context) error
+	DeleteByPrefix(ctx context.Context, prefix string) error
 }
 
 type cacheRepository[T any] struct {
+	cache     *Cache
 	client    *redis.Client
+	local     *localCache
+	codec     Codec
+	entity    string
 	keyPrefix string
+	stats     *prefixStats
 	ttl       time.Duration
+	ttlJitter time.Duration
 }
 
-func NewCacheRepository[T any](client *redis.Client, keyPrefix string) CacheRepository[T] {
+func NewCacheRepository[T any](c *Cache, keyPrefix string) CacheRepository[T] {
+	settings := c.settingsFor(keyPrefix)
 	return &cacheRepository[T]{
-		client:    client,
-		keyPrefix: keyPrefix,
-		ttl:       20 * time.Minute,
+		cache:     c,
+		client:    c.Client,
+		local:     c.localFor(keyPrefix),
+		codec:     settings.codec,
+		entity:    keyPrefix,
+		keyPrefix: settings.keyPrefix,
+		stats:     c.registerPrefix(keyPrefix, settings.keyPrefix),
+		ttl:       settings.ttl,
+		ttlJitter: settings.ttlJitter,
 	}
 }
 
 func (r *cacheRepository[T]) GetByID(ctx context.Context, id int) (*T, error) {
 	key := fmt.Sprintf("%s:%d", r.keyPrefix, id)
-	data, err := r.client.Get(ctx, key).Result()
-	if err != nil {
-		return nil, err
-	}
-
 	var item T
-	if err := json.Unmarshal([]byte(data), &item); err != nil {
+	if err := r.get(ctx, key, &item); err != nil {
 		return nil, err
 	}
 	return &item, nil
@@ -50,26 +60,16 @@ func (r *cacheRepository[T]) GetByID(ctx context.Context, id int) (*T, error) {
 
 func (r *cacheRepository[T]) GetByKey(ctx context.Context, key string) ([]*T, error) {
 	cacheKey := fmt.Sprintf("%s:%s", r.keyPrefix, key)
-	data, err := r.client.Get(ctx, cacheKey).Result()
-	if err != nil {
-		return nil, err
-	}
-
 	var items []*T
-	if err := json.Unmarshal([]byte(data), &items); err != nil {
+	if err := r.get(ctx, cacheKey, &items); err != nil {
 		return nil, err
 	}
 	return items, nil
 }
 
 func (r *cacheRepository[T]) GetAll(ctx context.Context) ([]*T, error) {
-	data, err := r.client.Get(ctx, r.keyPrefix+":all").Result()
-	if err != nil {
-		return nil, err
-	}
-
 	var items []*T
-	if err := json.Unmarshal([]byte(data), &items); err != nil {
+	if err := r.get(ctx, r.keyPrefix+":all", &items); err != nil {
 		return nil, err
 	}
 	return items, nil
@@ -77,35 +77,138 @@ func (r *cacheRepository[T]) GetAll(ctx context.Context) ([]*T, error) {
 
 func (r *cacheRepository[T]) SetByID(ctx context.Context, id int, item *T) error {
 	key := fmt.Sprintf("%s:%d", r.keyPrefix, id)
-	data, err := json.Marshal(item)
+	return r.set(ctx, key, item)
+}
+
+func (r *cacheRepository[T]) SetByKey(ctx context.Context, key string, items []*T) error {
+	cacheKey := fmt.Sprintf("%s:%s", r.keyPrefix, key)
+	return r.set(ctx, cacheKey, items)
+}
+
+func (r *cacheRepository[T]) SetAll(ctx context.Context, items []*T) error {
+	return r.set(ctx, r.keyPrefix+":all", items)
+}
+
+func (r *cacheRepository[T]) Delete(ctx context.Context, id int) error {
+	key := fmt.Sprintf("%s:%d", r.keyPrefix, id)
+	return r.del(ctx, key)
+}
+
+func (r *cacheRepository[T]) DeleteAll(ctx context.Context) error {
+	if err := r.del(ctx, r.keyPrefix+":all"); err != nil {
+		return err
+	}
+
+	r.cache.notifyInvalidated(r.entity)
+	return nil
+}
+
+// DeleteByPrefix deletes every entry stored with SetByKey under a key
+// starting with prefix, e.g. all filter results. While Redis is unavailable
+// it does nothing and the entries expire on their own.
+func (r *cacheRepository[T]) DeleteByPrefix(ctx context.Context, prefix string) error {
+	keys, err := r.cache.scanKeys(ctx, r.keyPrefix+":"+prefix)
+	if err == nil {
+		_, err = r.cache.purgeKeys(ctx, r.entity, keys)
+	}
+	if errors.Is(err, ErrUnavailable) {
+		return nil
+	}
+	return err
+}
+
+func (r *cacheRepository[T]) get(ctx context.Context, key string, dest any) error {
+	if r.local != nil {
+		if data, ok := r.local.Get(key); ok {
+			r.stats.localHits.Add(1)
+			return r.codec.Unmarshal(data, dest)
+		}
+	}
+
+	var data []byte
+	err := r.cache.execute(func() error {
+		var err error
+		data, err = r.client.Get(ctx, key).Bytes()
+		return err
+	})
+	if errors.Is(err, redis.Nil) {
+		r.stats.misses.Add(1)
+		return err
+	}
 	if err != nil {
+		r.stats.errors.Add(1)
+		return err
+	}
+	if err := r.codec.Unmarshal(data, dest); err != nil {
+		r.stats.errors.Add(1)
 		return err
 	}
-	return r.client.Set(ctx, key, data, r.ttl).Err()
+	r.stats.hits.Add(1)
+
+	if r.local != nil {
+		r.local.Set(key, data)
+	}
+	return nil
 }
 
-func (r *cacheRepository[T]) SetByKey(ctx context.Context, key string, items []*T) error {
-	cacheKey := fmt.Sprintf("%s:%s", r.keyPrefix, key)
-	data, err := json.Marshal(items)
+func (r *cacheRepository[T]) set(ctx context.Context, key string, value any) error {
+	data, err := r.codec.Marshal(value)
 	if err != nil {
 		return err
 	}
-	return r.client.Set(ctx, cacheKey, data, r.ttl).Err()
+	err = r.cache.execute(func() error {
+		return r.client.Set(ctx, key, data, r.expiration()).Err()
+	})
+	if errors.Is(err, ErrUnavailable) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if r.local != nil {
+		r.local.Set(key, data)
+		return r.publish(ctx, key)
+	}
+	return nil
 }
 
-func (r *cacheRepository[T]) SetAll(ctx context.Context, items []*T) error {
-	data, err := json.Marshal(items)
+func (r *cacheRepository[T]) del(ctx context.Context, key string) error {
+	if r.local != nil {
+		r.local.Delete(key)
+	}
+	err := r.cache.execute(func() error {
+		return r.client.Del(ctx, key).Err()
+	})
 	if err != nil {
+		r.cache.deferInvalidation(key)
+		if errors.Is(err, ErrUnavailable) {
+			return nil
+		}
 		return err
 	}
-	return r.client.Set(ctx, r.keyPrefix+":all", data, r.ttl).Err()
+
+	if r.local != nil {
+		return r.publish(ctx, key)
+	}
+	return nil
 }
 
-func (r *cacheRepository[T]) Delete(ctx context.Context, id int) error {
-	key := fmt.Sprintf("%s:%d", r.keyPrefix, id)
-	return r.client.Del(ctx, key).Err()
+func (r *cacheRepository[T]) publish(ctx context.Context, key string) error {
+	err := r.cache.execute(func() error {
+		return r.cache.publishInvalidation(ctx, key)
+	})
+	if errors.Is(err, ErrUnavailable) {
+		return nil
+	}
+	return err
 }
 
-func (r *cacheRepository[T]) DeleteAll(ctx context.Context) error {
-	return r.client.Del(ctx, r.keyPrefix+":all").Err()
+// expiration spreads key expiry over [ttl, ttl+jitter) so entries written
+// together do not all expire at the same moment.
+func (r *cacheRepository[T]) expiration() time.Duration {
+	if r.ttlJitter <= 0 {
+		return r.ttl
+	}
+	return r.ttl + time.Duration(rand.Int64N(int64(r.ttlJitter)))
 }
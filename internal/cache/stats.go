@@ -0,0 +1,197 @@
+package cache
+
+import (
+	"context"
+	"e-commerce/internal/domains"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const scanBatchSize = 500
+
+// ErrUnknownPrefix is returned by admin operations for prefixes that no cache
+// repository has been created for.
+var ErrUnknownPrefix = errors.New("unknown cache prefix")
+
+type prefixStats struct {
+	keyPrefix string
+	hits      atomic.Int64
+	localHits atomic.Int64
+	misses    atomic.Int64
+	errors    atomic.Int64
+}
+
+// registerPrefix returns the counters for an entity, creating them on first use.
+func (c *Cache) registerPrefix(entity, keyPrefix string) *prefixStats {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+
+	if stats, ok := c.stats[entity]; ok {
+		return stats
+	}
+	stats := &prefixStats{keyPrefix: keyPrefix}
+	c.stats[entity] = stats
+	return stats
+}
+
+func (c *Cache) prefixStats(entity string) (*prefixStats, error) {
+	c.statsMu.RLock()
+	defer c.statsMu.RUnlock()
+
+	stats, ok := c.stats[entity]
+	if !ok {
+		return nil, ErrUnknownPrefix
+	}
+	return stats, nil
+}
+
+// Stats returns hit/miss counters and key counts per prefix along with Redis
+// and local layer memory usage. It does not fail when Redis is unreachable or
+// the breaker is open; the Redis-derived fields are left unset instead.
+func (c *Cache) Stats(ctx context.Context) (*domains.CacheStats, error) {
+	c.statsMu.RLock()
+	entities := make([]string, 0, len(c.stats))
+	for entity := range c.stats {
+		entities = append(entities, entity)
+	}
+	c.statsMu.RUnlock()
+	sort.Strings(entities)
+
+	state, _ := c.breaker.State()
+	result := &domains.CacheStats{
+		Breaker:        state.String(),
+		RedisAvailable: true,
+		Prefixes:       make([]domains.CachePrefixStats, 0, len(entities)),
+	}
+	if c.local != nil {
+		result.Memory.LocalEntries = c.local.Len()
+	}
+	unavailable := func(err error) {
+		result.RedisAvailable = false
+		result.RedisError = err.Error()
+	}
+
+	for _, entity := range entities {
+		stats, _ := c.prefixStats(entity)
+		prefix := domains.CachePrefixStats{
+			Prefix:    entity,
+			Hits:      stats.hits.Load(),
+			LocalHits: stats.localHits.Load(),
+			Misses:    stats.misses.Load(),
+			Errors:    stats.errors.Load(),
+		}
+		if lookups := prefix.Hits + prefix.LocalHits + prefix.Misses; lookups > 0 {
+			prefix.HitRate = float64(prefix.Hits+prefix.LocalHits) / float64(lookups)
+		}
+
+		if result.RedisAvailable {
+			keys, err := c.scanKeys(ctx, stats.keyPrefix)
+			if err != nil {
+				unavailable(err)
+			} else {
+				count := int64(len(keys))
+				prefix.Keys = &count
+			}
+		}
+
+		result.Prefixes = append(result.Prefixes, prefix)
+	}
+
+	if !result.RedisAvailable {
+		return result, nil
+	}
+	var info string
+	err := c.execute(func() error {
+		var err error
+		info, err = c.Client.Info(ctx, "memory").Result()
+		return err
+	})
+	if err != nil {
+		unavailable(err)
+		return result, nil
+	}
+	for _, line := range strings.Split(info, "\r\n") {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch name {
+		case "used_memory":
+			if used, err := strconv.ParseInt(value, 10, 64); err == nil {
+				result.Memory.UsedBytes = &used
+			}
+		case "used_memory_human":
+			result.Memory.UsedHuman = value
+		}
+	}
+
+	return result, nil
+}
+
+// Purge deletes every key stored under the given prefix.
+func (c *Cache) Purge(ctx context.Context, entity string) (int64, error) {
+	stats, err := c.prefixStats(entity)
+	if err != nil {
+		return 0, err
+	}
+
+	keys, err := c.scanKeys(ctx, stats.keyPrefix)
+	if err != nil {
+		return 0, err
+	}
+	return c.purgeKeys(ctx, entity, keys)
+}
+
+// PurgeKey deletes a single entry, e.g. "brand"/"3" or "brand"/"all".
+func (c *Cache) PurgeKey(ctx context.Context, entity, id string) (int64, error) {
+	stats, err := c.prefixStats(entity)
+	if err != nil {
+		return 0, err
+	}
+	return c.purgeKeys(ctx, entity, []string{stats.keyPrefix + ":" + id})
+}
+
+func (c *Cache) purgeKeys(ctx context.Context, entity string, keys []string) (int64, error) {
+	if c.local != nil {
+		c.local.Delete(keys...)
+	}
+
+	var deleted int64
+	for start := 0; start < len(keys); start += scanBatchSize {
+		batch := keys[start:min(start+scanBatchSize, len(keys))]
+		err := c.execute(func() error {
+			n, err := c.Client.Del(ctx, batch...).Result()
+			deleted += n
+			return err
+		})
+		if err != nil {
+			return deleted, err
+		}
+		err = c.execute(func() error {
+			return c.publishInvalidation(ctx, batch...)
+		})
+		if err != nil {
+			return deleted, err
+		}
+	}
+
+	if deleted > 0 {
+		c.notifyInvalidated(entity)
+	}
+	return deleted, nil
+}
+
+func (c *Cache) scanKeys(ctx context.Context, keyPrefix string) ([]string, error) {
+	var keys []string
+	err := c.execute(func() error {
+		iter := c.Client.Scan(ctx, 0, keyPrefix+":*", scanBatchSize).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		return iter.Err()
+	})
+	return keys, err
+}
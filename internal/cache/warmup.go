@@ -0,0 +1,169 @@
+package cache
+
+import (
+	"context"
+	"e-commerce/internal/config"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultWarmupConcurrency = 4
+	defaultWarmupTimeout     = 30 * time.Second
+	defaultWarmupDebounce    = 2 * time.Second
+)
+
+// WarmupTask loads one cache entry, typically by calling a repository method
+// that populates the cache on a miss.
+type WarmupTask func(ctx context.Context) error
+
+// WarmupSource produces tasks at warm-up time, e.g. for the currently most
+// requested filter keys.
+type WarmupSource func(ctx context.Context) ([]WarmupTask, error)
+
+// Warmer preloads cache entries at startup and after a prefix is invalidated.
+// Runs are bounded by a concurrency limit and a timeout; invalidations of the
+// same prefix arriving within the debounce window are coalesced.
+type Warmer struct {
+	concurrency int
+	timeout     time.Duration
+	debounce    time.Duration
+
+	mu      sync.Mutex
+	sources map[string][]WarmupSource
+	timers  map[string]*time.Timer
+}
+
+func NewWarmer(cfg *config.WarmupConfig) *Warmer {
+	w := &Warmer{
+		concurrency: cfg.Concurrency,
+		timeout:     cfg.Timeout,
+		debounce:    cfg.Debounce,
+		sources:     make(map[string][]WarmupSource),
+		timers:      make(map[string]*time.Timer),
+	}
+	if w.concurrency <= 0 {
+		w.concurrency = defaultWarmupConcurrency
+	}
+	if w.timeout <= 0 {
+		w.timeout = defaultWarmupTimeout
+	}
+	if w.debounce <= 0 {
+		w.debounce = defaultWarmupDebounce
+	}
+	return w
+}
+
+// Register adds a fixed task warmed together with the given key prefix.
+func (w *Warmer) Register(keyPrefix string, task WarmupTask) {
+	w.RegisterSource(keyPrefix, func(ctx context.Context) ([]WarmupTask, error) {
+		return []WarmupTask{task}, nil
+	})
+}
+
+func (w *Warmer) RegisterSource(keyPrefix string, source WarmupSource) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.sources[keyPrefix] = append(w.sources[keyPrefix], source)
+}
+
+// WarmAll warms every registered prefix.
+func (w *Warmer) WarmAll(ctx context.Context) {
+	w.mu.Lock()
+	prefixes := make([]string, 0, len(w.sources))
+	for prefix := range w.sources {
+		prefixes = append(prefixes, prefix)
+	}
+	w.mu.Unlock()
+
+	w.warm(ctx, prefixes...)
+}
+
+// Schedule warms a prefix after the debounce window. It is meant to be
+// passed to Cache.OnInvalidate.
+func (w *Warmer) Schedule(keyPrefix string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.sources[keyPrefix]; !ok {
+		return
+	}
+	if timer, ok := w.timers[keyPrefix]; ok {
+		timer.Reset(w.debounce)
+		return
+	}
+	w.timers[keyPrefix] = time.AfterFunc(w.debounce, func() {
+		w.mu.Lock()
+		delete(w.timers, keyPrefix)
+		w.mu.Unlock()
+
+		w.warm(context.Background(), keyPrefix)
+	})
+}
+
+// Stop cancels pending scheduled warm-ups.
+func (w *Warmer) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for prefix, timer := range w.timers {
+		timer.Stop()
+		delete(w.timers, prefix)
+	}
+}
+
+func (w *Warmer) warm(ctx context.Context, prefixes ...string) {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	start := time.Now()
+	var sources []WarmupSource
+	w.mu.Lock()
+	for _, prefix := range prefixes {
+		sources = append(sources, w.sources[prefix]...)
+	}
+	w.mu.Unlock()
+
+	var tasks []WarmupTask
+	for _, source := range sources {
+		sourceTasks, err := source(ctx)
+		if err != nil {
+			logrus.Warnf("Failed to collect cache warm-up tasks (prefixes: %v): %v", prefixes, err)
+			continue
+		}
+		tasks = append(tasks, sourceTasks...)
+	}
+
+	sem := make(chan struct{}, w.concurrency)
+	var wg sync.WaitGroup
+	var failed int
+	var failedMu sync.Mutex
+	for _, task := range tasks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			logrus.Warnf("Cache warm-up timed out before all tasks started (prefixes: %v)", prefixes)
+			wg.Wait()
+			return
+		}
+
+		wg.Add(1)
+		go func(task WarmupTask) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := task(ctx); err != nil {
+				failedMu.Lock()
+				failed++
+				failedMu.Unlock()
+				logrus.Warnf("Cache warm-up task failed (prefixes: %v): %v", prefixes, err)
+			}
+		}(task)
+	}
+	wg.Wait()
+
+	logrus.Infof("Cache warm-up finished (prefixes: %v, tasks: %d, failed: %d, took: %s)", prefixes, len(tasks), failed, time.Since(start))
+}
@@ -0,0 +1,311 @@
+package cart
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CartHandler struct {
+	service CartService
+}
+
+func NewCartHandler(service CartService) *CartHandler {
+	return &CartHandler{service: service}
+}
+
+func (h *CartHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/carts", h.CreateGuestCart)
+	router.GET("/carts/:token", h.GetGuestCart)
+	router.POST("/carts/:token/items", h.AddGuestCartItem)
+	router.PUT("/carts/:token/items/:itemID", h.UpdateGuestCartItem)
+	router.DELETE("/carts/:token/items/:itemID", h.RemoveGuestCartItem)
+
+	router.GET("/users/:id/cart", h.GetUserCart)
+	router.POST("/users/:id/cart/items", h.AddUserCartItem)
+	router.PUT("/users/:id/cart/items/:itemID", h.UpdateUserCartItem)
+	router.DELETE("/users/:id/cart/items/:itemID", h.RemoveUserCartItem)
+	router.POST("/users/:id/cart/merge", h.MergeGuestCart)
+}
+
+// @Summary Create a guest cart
+// @Description Create an empty guest cart and return its token
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Success 201 {object} domains.Cart
+// @Failure 500 {object} domains.Error
+// @Router /carts [post]
+func (h *CartHandler) CreateGuestCart(c *gin.Context) {
+	cart, err := h.service.CreateGuestCart(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, cart)
+}
+
+// @Summary Get guest cart
+// @Description Get a guest cart with current prices
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param token path string true "Cart token"
+// @Success 200 {object} domains.Cart
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /carts/{token} [get]
+func (h *CartHandler) GetGuestCart(c *gin.Context) {
+	h.getCart(c, CartRef{Token: c.Param("token")})
+}
+
+// @Summary Add item to guest cart
+// @Description Add a product or variant to a guest cart. Adding an existing line increases its quantity.
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param token path string true "Cart token"
+// @Param item body domains.CartItemRequest true "Cart item"
+// @Success 200 {object} domains.Cart
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /carts/{token}/items [post]
+func (h *CartHandler) AddGuestCartItem(c *gin.Context) {
+	h.addItem(c, CartRef{Token: c.Param("token")})
+}
+
+// @Summary Update guest cart item
+// @Description Set the quantity of a guest cart line
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param token path string true "Cart token"
+// @Param itemID path int true "Cart item ID"
+// @Param item body domains.UpdateCartItemRequest true "New quantity"
+// @Success 200 {object} domains.Cart
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /carts/{token}/items/{itemID} [put]
+func (h *CartHandler) UpdateGuestCartItem(c *gin.Context) {
+	h.updateItem(c, CartRef{Token: c.Param("token")})
+}
+
+// @Summary Remove guest cart item
+// @Description Remove a line from a guest cart
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param token path string true "Cart token"
+// @Param itemID path int true "Cart item ID"
+// @Success 200 {object} domains.Cart
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /carts/{token}/items/{itemID} [delete]
+func (h *CartHandler) RemoveGuestCartItem(c *gin.Context) {
+	h.removeItem(c, CartRef{Token: c.Param("token")})
+}
+
+// @Summary Get customer cart
+// @Description Get the cart of a signed-in customer with current prices
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} domains.Cart
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /users/{id}/cart [get]
+func (h *CartHandler) GetUserCart(c *gin.Context) {
+	ref, ok := userRef(c)
+	if !ok {
+		return
+	}
+	h.getCart(c, ref)
+}
+
+// @Summary Add item to customer cart
+// @Description Add a product or variant to a customer cart. Adding an existing line increases its quantity.
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param item body domains.CartItemRequest true "Cart item"
+// @Success 200 {object} domains.Cart
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /users/{id}/cart/items [post]
+func (h *CartHandler) AddUserCartItem(c *gin.Context) {
+	ref, ok := userRef(c)
+	if !ok {
+		return
+	}
+	h.addItem(c, ref)
+}
+
+// @Summary Update customer cart item
+// @Description Set the quantity of a customer cart line
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param itemID path int true "Cart item ID"
+// @Param item body domains.UpdateCartItemRequest true "New quantity"
+// @Success 200 {object} domains.Cart
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /users/{id}/cart/items/{itemID} [put]
+func (h *CartHandler) UpdateUserCartItem(c *gin.Context) {
+	ref, ok := userRef(c)
+	if !ok {
+		return
+	}
+	h.updateItem(c, ref)
+}
+
+// @Summary Remove customer cart item
+// @Description Remove a line from a customer cart
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param itemID path int true "Cart item ID"
+// @Success 200 {object} domains.Cart
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /users/{id}/cart/items/{itemID} [delete]
+func (h *CartHandler) RemoveUserCartItem(c *gin.Context) {
+	ref, ok := userRef(c)
+	if !ok {
+		return
+	}
+	h.removeItem(c, ref)
+}
+
+// @Summary Merge guest cart
+// @Description Move the lines of a guest cart into the customer cart on login and delete the guest cart
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param merge body domains.MergeCartRequest true "Guest cart token"
+// @Success 200 {object} domains.Cart
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /users/{id}/cart/merge [post]
+func (h *CartHandler) MergeGuestCart(c *gin.Context) {
+	ref, ok := userRef(c)
+	if !ok {
+		return
+	}
+
+	var req domains.MergeCartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cart, err := h.service.MergeGuestCart(c.Request.Context(), ref.UserID, req.Token)
+	if err != nil {
+		c.JSON(cartErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+func (h *CartHandler) getCart(c *gin.Context, ref CartRef) {
+	cart, err := h.service.GetCart(c.Request.Context(), ref)
+	if err != nil {
+		c.JSON(cartErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+func (h *CartHandler) addItem(c *gin.Context, ref CartRef) {
+	var req domains.CartItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cart, err := h.service.AddItem(c.Request.Context(), ref, &req)
+	if err != nil {
+		c.JSON(cartErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+func (h *CartHandler) updateItem(c *gin.Context, ref CartRef) {
+	itemID, err := strconv.Atoi(c.Param("itemID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cart item id"})
+		return
+	}
+
+	var req domains.UpdateCartItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cart, err := h.service.UpdateItem(c.Request.Context(), ref, itemID, &req)
+	if err != nil {
+		c.JSON(cartErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+func (h *CartHandler) removeItem(c *gin.Context, ref CartRef) {
+	itemID, err := strconv.Atoi(c.Param("itemID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cart item id"})
+		return
+	}
+
+	cart, err := h.service.RemoveItem(c.Request.Context(), ref, itemID)
+	if err != nil {
+		c.JSON(cartErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+func userRef(c *gin.Context) (CartRef, bool) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return CartRef{}, false
+	}
+	return CartRef{UserID: userID}, true
+}
+
+func cartErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domains.ErrInvalidCartItem):
+		return http.StatusBadRequest
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
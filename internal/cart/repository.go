@@ -0,0 +1,254 @@
+package cart
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"e-commerce/internal/cache"
+	"e-commerce/internal/domains"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultGuestTTL = 7 * 24 * time.Hour
+
+// guestKeyPrefix is deliberately outside the cache namespace: guest carts are
+// the only copy of the data, so cache invalidation and purges must never
+// reach them.
+const guestKeyPrefix = "cart:guest:"
+
+type CartRepository interface {
+	CreateGuest(ctx context.Context) (*domains.Cart, error)
+	GetGuest(ctx context.Context, token string) (*domains.Cart, error)
+	SaveGuest(ctx context.Context, cart *domains.Cart) error
+	DeleteGuest(ctx context.Context, token string) error
+	GetUser(ctx context.Context, userID int) (*domains.Cart, error)
+	SaveUser(ctx context.Context, cart *domains.Cart) error
+	PriceItems(ctx context.Context, items []domains.CartItem) ([]domains.CartItem, error)
+}
+
+type cartRepository struct {
+	db       *pgxpool.Pool
+	client   *redis.Client
+	guestTTL time.Duration
+}
+
+func NewCartRepository(db *pgxpool.Pool, cacheClient *cache.Cache, guestTTL time.Duration) CartRepository {
+	if guestTTL <= 0 {
+		guestTTL = defaultGuestTTL
+	}
+	return &cartRepository{
+		db:       db,
+		client:   cacheClient.Client,
+		guestTTL: guestTTL,
+	}
+}
+
+// storedItem is the persisted form of a cart line: catalog data is looked up
+// again on every read so the cart never shows stale prices.
+type storedItem struct {
+	ID        int  `json:"id"`
+	ProductID int  `json:"product_id"`
+	VariantID *int `json:"variant_id,omitempty"`
+	Quantity  int  `json:"quantity"`
+}
+
+type storedCart struct {
+	Items     []storedItem `json:"items"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+func toStoredItems(items []domains.CartItem) []storedItem {
+	stored := make([]storedItem, 0, len(items))
+	for _, item := range items {
+		stored = append(stored, storedItem{
+			ID:        item.ID,
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			Quantity:  item.Quantity,
+		})
+	}
+	return stored
+}
+
+func fromStoredItems(stored []storedItem) []domains.CartItem {
+	items := make([]domains.CartItem, 0, len(stored))
+	for _, item := range stored {
+		items = append(items, domains.CartItem{
+			ID:        item.ID,
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			Quantity:  item.Quantity,
+		})
+	}
+	return items
+}
+
+func (r *cartRepository) guestKey(token string) string {
+	return guestKeyPrefix + token
+}
+
+func (r *cartRepository) CreateGuest(ctx context.Context) (*domains.Cart, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		logrus.Errorf("Failed to generate guest cart token: %v", err)
+		return nil, err
+	}
+
+	cart := &domains.Cart{Token: hex.EncodeToString(buf), Items: []domains.CartItem{}}
+	if err := r.SaveGuest(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	logrus.Debugf("Guest cart created successfully (token: %s)", cart.Token)
+	return cart, nil
+}
+
+func (r *cartRepository) GetGuest(ctx context.Context, token string) (*domains.Cart, error) {
+	data, err := r.client.Get(ctx, r.guestKey(token)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			logrus.Infof("Guest cart not found (token: %s)", token)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get guest cart (token: %s): %v", token, err)
+		return nil, err
+	}
+
+	var stored storedCart
+	if err := json.Unmarshal(data, &stored); err != nil {
+		logrus.Errorf("Failed to decode guest cart (token: %s): %v", token, err)
+		return nil, err
+	}
+
+	return &domains.Cart{
+		Token:     token,
+		Items:     fromStoredItems(stored.Items),
+		UpdatedAt: &stored.UpdatedAt,
+	}, nil
+}
+
+// SaveGuest stores the cart and restarts its expiry, so guest carts live for
+// the configured TTL after the last change.
+func (r *cartRepository) SaveGuest(ctx context.Context, cart *domains.Cart) error {
+	now := time.Now().UTC()
+	data, err := json.Marshal(storedCart{Items: toStoredItems(cart.Items), UpdatedAt: now})
+	if err != nil {
+		return err
+	}
+
+	if err := r.client.Set(ctx, r.guestKey(cart.Token), data, r.guestTTL).Err(); err != nil {
+		logrus.Errorf("Failed to save guest cart (token: %s): %v", cart.Token, err)
+		return err
+	}
+
+	cart.UpdatedAt = &now
+	return nil
+}
+
+func (r *cartRepository) DeleteGuest(ctx context.Context, token string) error {
+	if err := r.client.Del(ctx, r.guestKey(token)).Err(); err != nil {
+		logrus.Errorf("Failed to delete guest cart (token: %s): %v", token, err)
+		return err
+	}
+	return nil
+}
+
+// GetUser returns the customer's cart, or an empty one if nothing has been
+// saved yet.
+func (r *cartRepository) GetUser(ctx context.Context, userID int) (*domains.Cart, error) {
+	const getQuery = `SELECT items, updated_at FROM carts WHERE user_id = $1`
+
+	var stored []storedItem
+	var updatedAt *time.Time
+	err := r.db.QueryRow(ctx, getQuery, userID).Scan(&stored, &updatedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		logrus.Errorf("Failed to get cart (user_id: %d): %v", userID, err)
+		return nil, err
+	}
+
+	return &domains.Cart{
+		UserID:    &userID,
+		Items:     fromStoredItems(stored),
+		UpdatedAt: updatedAt,
+	}, nil
+}
+
+func (r *cartRepository) SaveUser(ctx context.Context, cart *domains.Cart) error {
+	const upsertQuery = `
+        INSERT INTO carts (user_id, items)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id)
+        DO UPDATE SET items = EXCLUDED.items, updated_at = CURRENT_TIMESTAMP
+        RETURNING updated_at`
+
+	err := r.db.QueryRow(ctx, upsertQuery, *cart.UserID, toStoredItems(cart.Items)).Scan(&cart.UpdatedAt)
+	if err != nil {
+		logrus.Errorf("Failed to save cart (user_id: %d): %v", *cart.UserID, err)
+		return err
+	}
+	return nil
+}
+
+// PriceItems fills names and current prices. A variant's own price wins over
+// the product price, which includes a running sale. Lines whose product or
+// variant no longer exists are dropped.
+func (r *cartRepository) PriceItems(ctx context.Context, items []domains.CartItem) ([]domains.CartItem, error) {
+	if len(items) == 0 {
+		return []domains.CartItem{}, nil
+	}
+
+	productIDs := make([]int, len(items))
+	variantIDs := make([]int, len(items))
+	for i, item := range items {
+		productIDs[i] = item.ProductID
+		if item.VariantID != nil {
+			variantIDs[i] = *item.VariantID
+		}
+	}
+
+	const priceQuery = `
+        SELECT i.idx, p.name, COALESCE(pv.sku, ''), COALESCE(pv.price, product_current_price(p.price, p.sale_price, p.sale_starts_at, p.sale_ends_at))
+        FROM unnest($1::int[], $2::int[]) WITH ORDINALITY AS i(product_id, variant_id, idx)
+        JOIN products p ON p.id = i.product_id
+        LEFT JOIN product_variants pv ON pv.id = i.variant_id AND pv.product_id = p.id
+        WHERE i.variant_id = 0 OR pv.id IS NOT NULL
+        ORDER BY i.idx`
+
+	rows, err := r.db.Query(ctx, priceQuery, productIDs, variantIDs)
+	if err != nil {
+		logrus.Errorf("Failed to query cart item prices: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	priced := make([]domains.CartItem, 0, len(items))
+	for rows.Next() {
+		var idx int
+		var name, sku string
+		var price domains.Money
+		if err := rows.Scan(&idx, &name, &sku, &price); err != nil {
+			logrus.Errorf("Failed to scan cart item price: %v", err)
+			return nil, err
+		}
+
+		item := items[idx-1]
+		item.Name = name
+		item.SKU = sku
+		item.UnitPrice = price
+		priced = append(priced, item)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating cart item price rows: %v", err)
+		return nil, err
+	}
+
+	return priced, nil
+}
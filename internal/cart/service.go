@@ -0,0 +1,253 @@
+package cart
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/domains"
+	"e-commerce/internal/tax"
+	"fmt"
+)
+
+// CartRef identifies a cart: a guest cart by token or a customer cart by
+// user ID.
+type CartRef struct {
+	Token  string
+	UserID int
+}
+
+type CartService interface {
+	CreateGuestCart(ctx context.Context) (*domains.Cart, error)
+	GetCart(ctx context.Context, ref CartRef) (*domains.Cart, error)
+	AddItem(ctx context.Context, ref CartRef, req *domains.CartItemRequest) (*domains.Cart, error)
+	UpdateItem(ctx context.Context, ref CartRef, itemID int, req *domains.UpdateCartItemRequest) (*domains.Cart, error)
+	RemoveItem(ctx context.Context, ref CartRef, itemID int) (*domains.Cart, error)
+	MergeGuestCart(ctx context.Context, userID int, token string) (*domains.Cart, error)
+	ClearCart(ctx context.Context, ref CartRef) error
+}
+
+type cartService struct {
+	repo     CartRepository
+	taxes    tax.TaxService
+	currency string
+}
+
+func NewCartService(repo CartRepository, taxes tax.TaxService, currency string) CartService {
+	return &cartService{repo: repo, taxes: taxes, currency: currency}
+}
+
+func (s *cartService) CreateGuestCart(ctx context.Context) (*domains.Cart, error) {
+	cart, err := s.repo.CreateGuest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cart.Currency = s.currency
+	return cart, nil
+}
+
+func (s *cartService) GetCart(ctx context.Context, ref CartRef) (*domains.Cart, error) {
+	cart, err := s.load(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.price(ctx, cart)
+}
+
+// AddItem adds a line, or increases the quantity when the same product and
+// variant are already in the cart.
+func (s *cartService) AddItem(ctx context.Context, ref CartRef, req *domains.CartItemRequest) (*domains.Cart, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	priced, err := s.repo.PriceItems(ctx, []domains.CartItem{{ProductID: req.ProductID, VariantID: req.VariantID}})
+	if err != nil {
+		return nil, err
+	}
+	if len(priced) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	cart, err := s.load(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	cart.Items, err = addItem(cart.Items, domains.CartItem{
+		ProductID: req.ProductID,
+		VariantID: req.VariantID,
+		Quantity:  req.Quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, cart); err != nil {
+		return nil, err
+	}
+	return s.price(ctx, cart)
+}
+
+func (s *cartService) UpdateItem(ctx context.Context, ref CartRef, itemID int, req *domains.UpdateCartItemRequest) (*domains.Cart, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	cart, err := s.load(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for i := range cart.Items {
+		if cart.Items[i].ID == itemID {
+			cart.Items[i].Quantity = req.Quantity
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, sql.ErrNoRows
+	}
+
+	if err := s.save(ctx, cart); err != nil {
+		return nil, err
+	}
+	return s.price(ctx, cart)
+}
+
+func (s *cartService) RemoveItem(ctx context.Context, ref CartRef, itemID int) (*domains.Cart, error) {
+	cart, err := s.load(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	items := cart.Items[:0]
+	for _, item := range cart.Items {
+		if item.ID != itemID {
+			items = append(items, item)
+		}
+	}
+	if len(items) == len(cart.Items) {
+		return nil, sql.ErrNoRows
+	}
+	cart.Items = items
+
+	if err := s.save(ctx, cart); err != nil {
+		return nil, err
+	}
+	return s.price(ctx, cart)
+}
+
+// MergeGuestCart moves the lines of a guest cart into the customer's cart,
+// summing quantities of matching lines, and deletes the guest cart.
+func (s *cartService) MergeGuestCart(ctx context.Context, userID int, token string) (*domains.Cart, error) {
+	if token == "" {
+		return nil, fmt.Errorf("%w: token is required", domains.ErrInvalidCartItem)
+	}
+
+	guest, err := s.repo.GetGuest(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	cart, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range guest.Items {
+		if cart.Items, err = addItem(cart.Items, item); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.SaveUser(ctx, cart); err != nil {
+		return nil, err
+	}
+	if err := s.repo.DeleteGuest(ctx, token); err != nil {
+		return nil, err
+	}
+
+	return s.price(ctx, cart)
+}
+
+// ClearCart empties a customer cart or deletes a guest cart, e.g. after
+// checkout.
+func (s *cartService) ClearCart(ctx context.Context, ref CartRef) error {
+	if ref.Token != "" {
+		return s.repo.DeleteGuest(ctx, ref.Token)
+	}
+	return s.repo.SaveUser(ctx, &domains.Cart{UserID: &ref.UserID, Items: []domains.CartItem{}})
+}
+
+func (s *cartService) load(ctx context.Context, ref CartRef) (*domains.Cart, error) {
+	if ref.Token != "" {
+		return s.repo.GetGuest(ctx, ref.Token)
+	}
+	return s.repo.GetUser(ctx, ref.UserID)
+}
+
+func (s *cartService) save(ctx context.Context, cart *domains.Cart) error {
+	if cart.UserID != nil {
+		return s.repo.SaveUser(ctx, cart)
+	}
+	return s.repo.SaveGuest(ctx, cart)
+}
+
+// price recalculates line totals, the subtotal and the estimated tax from
+// current catalog prices.
+func (s *cartService) price(ctx context.Context, cart *domains.Cart) (*domains.Cart, error) {
+	items, err := s.repo.PriceItems(ctx, cart.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	cart.Items = items
+	cart.ItemCount = 0
+	cart.Subtotal = 0
+	lines := make([]domains.TaxableLine, 0, len(cart.Items))
+	for i := range cart.Items {
+		item := &cart.Items[i]
+		item.LineTotal = item.UnitPrice.Mul(item.Quantity)
+		cart.ItemCount += item.Quantity
+		cart.Subtotal += item.LineTotal
+		lines = append(lines, domains.TaxableLine{ItemID: item.ID, ProductID: item.ProductID, Amount: item.LineTotal})
+	}
+	cart.Currency = s.currency
+
+	taxes, err := s.taxes.Calculate(ctx, lines, nil)
+	if err != nil {
+		return nil, err
+	}
+	cart.Tax = taxes.Tax
+	cart.TaxIncluded = taxes.Mode == domains.TaxInclusive
+	cart.Total = taxes.Total
+
+	return cart, nil
+}
+
+// addItem appends item or adds its quantity to the matching line, failing
+// when the line would exceed MaxCartQuantity.
+func addItem(items []domains.CartItem, item domains.CartItem) ([]domains.CartItem, error) {
+	nextID := 1
+	for i := range items {
+		if items[i].ProductID == item.ProductID && sameVariant(items[i].VariantID, item.VariantID) {
+			if item.Quantity > domains.MaxCartQuantity-items[i].Quantity {
+				return nil, fmt.Errorf("%w: quantity must not exceed %d", domains.ErrInvalidCartItem, domains.MaxCartQuantity)
+			}
+			items[i].Quantity += item.Quantity
+			return items, nil
+		}
+		nextID = max(nextID, items[i].ID+1)
+	}
+
+	if item.Quantity <= 0 || item.Quantity > domains.MaxCartQuantity {
+		return nil, fmt.Errorf("%w: quantity must be between 1 and %d", domains.ErrInvalidCartItem, domains.MaxCartQuantity)
+	}
+	item.ID = nextID
+	return append(items, item), nil
+}
+
+func sameVariant(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
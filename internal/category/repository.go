@@ -26,24 +26,25 @@ type categoryRepository struct {
 	cache cache.CacheRepository[domains.Category]
 }
 
-func NewCategoryRepository(db *pgxpool.Pool, redisClient *redis.Client) CategoryRepository {
+func NewCategoryRepository(db *pgxpool.Pool, cacheClient *cache.Cache) CategoryRepository {
 	return &categoryRepository{
 		db:    db,
-		cache: cache.NewCacheRepository[domains.Category](redisClient, "category"),
+		cache: cache.NewCacheRepository[domains.Category](cacheClient, "category"),
 	}
 }
 
 func (r *categoryRepository) Create(ctx context.Context, category *domains.Category) (*domains.Category, error) {
 	const insertQuery = `
-        INSERT INTO categories (name, description)
-        VALUES ($1, $2)
-        RETURNING id, name, description`
+        INSERT INTO categories (name, description, tax_class_id)
+        VALUES ($1, $2, $3)
+        RETURNING id, name, description, tax_class_id`
 
 	createdCategory := &domains.Category{}
-	err := r.db.QueryRow(ctx, insertQuery, category.Name, category.Description).Scan(
+	err := r.db.QueryRow(ctx, insertQuery, category.Name, category.Description, category.TaxClassID).Scan(
 		&createdCategory.ID,
 		&createdCategory.Name,
 		&createdCategory.Description,
+		&createdCategory.TaxClassID,
 	)
 	if err != nil {
 		logrus.WithError(err).WithField("category", category).Error("Failed to insert category")
@@ -71,16 +72,17 @@ func (r *categoryRepository) GetByID(ctx context.Context, id int) (*domains.Cate
 		logrus.Debugf("Cache hit for category (ID: %d)", id)
 		return category, nil
 	}
-	if !errors.Is(err, redis.Nil) {
+	if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
 		logrus.Errorf("Cache lookup failed for category (ID: %d): %v", id, err)
 	}
 
-	const getQuery = `SELECT id, name, description FROM categories WHERE id = $1`
+	const getQuery = `SELECT id, name, description, tax_class_id FROM categories WHERE id = $1`
 	category = &domains.Category{}
 	err = r.db.QueryRow(ctx, getQuery, id).Scan(
 		&category.ID,
 		&category.Name,
 		&category.Description,
+		&category.TaxClassID,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -106,19 +108,21 @@ func (r *categoryRepository) GetByID(ctx context.Context, id int) (*domains.Cate
 func (r *categoryRepository) Update(ctx context.Context, id int, category *domains.Category) (*domains.Category, error) {
 	const updateQuery = `
         UPDATE categories 
-        SET name = $1, description = $2 
-        WHERE id = $3
-        RETURNING id, name, description`
+        SET name = $1, description = $2, tax_class_id = $3
+        WHERE id = $4
+        RETURNING id, name, description, tax_class_id`
 
 	updatedCategory := &domains.Category{}
 	err := r.db.QueryRow(ctx, updateQuery,
 		category.Name,
 		category.Description,
+		category.TaxClassID,
 		id,
 	).Scan(
 		&updatedCategory.ID,
 		&updatedCategory.Name,
 		&updatedCategory.Description,
+		&updatedCategory.TaxClassID,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -175,11 +179,11 @@ func (r *categoryRepository) GetAll(ctx context.Context) ([]*domains.Category, e
 		logrus.Debug("Cache hit for all categories")
 		return categories, nil
 	}
-	if !errors.Is(err, redis.Nil) {
+	if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
 		logrus.Errorf("Cache lookup failed for all categories: %v", err)
 	}
 
-	const getAllQuery = `SELECT id, name, description FROM categories`
+	const getAllQuery = `SELECT id, name, description, tax_class_id FROM categories`
 	rows, err := r.db.Query(ctx, getAllQuery)
 	if err != nil {
 		logrus.Errorf("Failed to get all categories: %v", err)
@@ -194,6 +198,7 @@ func (r *categoryRepository) GetAll(ctx context.Context) ([]*domains.Category, e
 			&category.ID,
 			&category.Name,
 			&category.Description,
+			&category.TaxClassID,
 		); err != nil {
 			logrus.Errorf("Failed to scan category record: %v", err)
 			return nil, err
@@ -2,14 +2,21 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Database PostgresConfig
-	Cache    RedisConfig
-	Minio    MinioConfig
+	Database        PostgresConfig
+	Cache           RedisConfig
+	Minio           MinioConfig
+	Reservations    ReservationConfig
+	Carts           CartConfig
+	Payments        PaymentConfig
+	Currency        CurrencyConfig
+	Tax             TaxConfig
+	Recommendations RecommendationConfig
 }
 
 type PostgresConfig struct {
@@ -24,9 +31,110 @@ type PostgresConfig struct {
 }
 
 type RedisConfig struct {
-	Addr     string
-	Password string
-	DB       int
+	Addr      string
+	Password  string
+	DB        int
+	Namespace string
+	Codec     string
+	TTL       time.Duration
+	TTLJitter time.Duration `mapstructure:"ttl_jitter"`
+	Entities  map[string]CacheEntityConfig
+	Local     LocalCacheConfig
+	Breaker   BreakerConfig
+	Warmup    WarmupConfig
+}
+
+// CacheEntityConfig overrides the global TTL, jitter and codec for a single
+// cache key prefix. Zero values fall back to the global settings.
+type CacheEntityConfig struct {
+	TTL       time.Duration
+	TTLJitter time.Duration `mapstructure:"ttl_jitter"`
+	Codec     string
+}
+
+// LocalCacheConfig controls the in-process layer in front of Redis. An empty
+// Prefixes list enables it for every cache repository.
+type LocalCacheConfig struct {
+	Enabled    bool
+	MaxEntries int `mapstructure:"max_entries"`
+	TTL        time.Duration
+	Prefixes   []string
+}
+
+// BreakerConfig controls when the cache stops calling Redis and how long it
+// waits before probing it again.
+type BreakerConfig struct {
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	Cooldown         time.Duration
+}
+
+// WarmupConfig controls cache preloading at startup and after invalidation.
+// TopFilters is the number of most requested product filters to preload.
+type WarmupConfig struct {
+	Enabled     bool
+	Concurrency int
+	Timeout     time.Duration
+	Debounce    time.Duration
+	TopFilters  int `mapstructure:"top_filters"`
+}
+
+// ReservationConfig controls how long stock is held when a request does not
+// set a TTL, the longest TTL allowed and how often expired holds are released.
+type ReservationConfig struct {
+	TTL           time.Duration
+	MaxTTL        time.Duration `mapstructure:"max_ttl"`
+	SweepInterval time.Duration `mapstructure:"sweep_interval"`
+}
+
+// CartConfig controls how long an untouched guest cart is kept in Redis.
+type CartConfig struct {
+	GuestTTL time.Duration `mapstructure:"guest_ttl"`
+}
+
+// PaymentConfig selects the payment provider and the secret its webhooks are
+// signed with.
+type PaymentConfig struct {
+	Provider      string
+	WebhookSecret string `mapstructure:"webhook_secret"`
+}
+
+// CurrencyConfig sets the currency catalog prices are stored in and how
+// converted prices are rounded.
+type CurrencyConfig struct {
+	Base     string
+	Rounding RoundingConfig
+}
+
+// RoundingConfig rounds converted prices to a multiple of Increment using
+// Mode ("nearest", "up" or "down"). Increments overrides the increment per
+// currency code.
+type RoundingConfig struct {
+	Mode       string
+	Increment  float64
+	Increments map[string]float64
+}
+
+// TaxConfig sets whether catalog prices include tax ("inclusive") or tax is
+// added at checkout ("exclusive"), and the address carts are taxed for
+// before the customer gives one.
+type TaxConfig struct {
+	Mode    string
+	Country string
+	Region  string
+}
+
+// RecommendationConfig weighs how well a product matches a skin profile
+// against its rating, and sets how much each earlier pick from the same
+// brand lowers a product's score. RatingPrior is the number of average
+// ratings a product's own ratings are blended with, so products with few
+// reviews are not ranked on them alone.
+type RecommendationConfig struct {
+	MatchWeight  float64 `mapstructure:"match_weight"`
+	RatingWeight float64 `mapstructure:"rating_weight"`
+	BrandPenalty float64 `mapstructure:"brand_penalty"`
+	RatingPrior  int     `mapstructure:"rating_prior"`
+	Limit        int
+	MaxLimit     int `mapstructure:"max_limit"`
 }
 
 type MinioConfig struct {
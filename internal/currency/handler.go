@@ -0,0 +1,101 @@
+package currency
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CurrencyHandler struct {
+	service CurrencyService
+}
+
+func NewCurrencyHandler(service CurrencyService) *CurrencyHandler {
+	return &CurrencyHandler{service: service}
+}
+
+func (h *CurrencyHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/admin/exchange-rates", h.GetExchangeRates)
+	router.PUT("/admin/exchange-rates/:currency", h.SetExchangeRate)
+	router.DELETE("/admin/exchange-rates/:currency", h.DeleteExchangeRate)
+}
+
+// @Summary Get exchange rates
+// @Description Get every exchange rate, as units of the currency per unit of the base currency
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {array} domains.ExchangeRate
+// @Failure 500 {object} domains.Error
+// @Router /admin/exchange-rates [get]
+func (h *CurrencyHandler) GetExchangeRates(c *gin.Context) {
+	rates, err := h.service.GetExchangeRates(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rates)
+}
+
+// @Summary Set exchange rate
+// @Description Create or replace the exchange rate of a currency
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param currency path string true "ISO 4217 currency code"
+// @Param rate body domains.ExchangeRateRequest true "Units of the currency per unit of the base currency"
+// @Success 200 {object} domains.ExchangeRate
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /admin/exchange-rates/{currency} [put]
+func (h *CurrencyHandler) SetExchangeRate(c *gin.Context) {
+	var req domains.ExchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rate, err := h.service.SetExchangeRate(c.Request.Context(), c.Param("currency"), &req)
+	if err != nil {
+		c.JSON(currencyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rate)
+}
+
+// @Summary Delete exchange rate
+// @Description Delete the exchange rate of a currency; prices can no longer be shown in it
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param currency path string true "ISO 4217 currency code"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /admin/exchange-rates/{currency} [delete]
+func (h *CurrencyHandler) DeleteExchangeRate(c *gin.Context) {
+	if err := h.service.DeleteExchangeRate(c.Request.Context(), c.Param("currency")); err != nil {
+		c.JSON(currencyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func currencyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domains.ErrInvalidCurrency):
+		return http.StatusBadRequest
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
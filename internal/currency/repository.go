@@ -0,0 +1,123 @@
+package currency
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/cache"
+	"e-commerce/internal/domains"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+type ExchangeRateRepository interface {
+	GetAll(ctx context.Context) ([]*domains.ExchangeRate, error)
+	Set(ctx context.Context, currency string, rate float64) (*domains.ExchangeRate, error)
+	Delete(ctx context.Context, currency string) error
+}
+
+type exchangeRateRepository struct {
+	db    *pgxpool.Pool
+	cache cache.CacheRepository[domains.ExchangeRate]
+}
+
+func NewExchangeRateRepository(db *pgxpool.Pool, cacheClient *cache.Cache) ExchangeRateRepository {
+	return &exchangeRateRepository{
+		db:    db,
+		cache: cache.NewCacheRepository[domains.ExchangeRate](cacheClient, "exchangerate"),
+	}
+}
+
+// GetAll returns every rate. Rates are read on each converted product
+// request, so the whole table is cached as one entry.
+func (r *exchangeRateRepository) GetAll(ctx context.Context) ([]*domains.ExchangeRate, error) {
+	rates, err := r.cache.GetAll(ctx)
+	if err == nil {
+		logrus.Debug("Cache hit for all exchange rates")
+		return rates, nil
+	}
+	if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
+		logrus.Errorf("Cache lookup failed for all exchange rates: %v", err)
+	}
+
+	const getAllQuery = `SELECT currency, rate, updated_at FROM exchange_rates ORDER BY currency`
+
+	rows, err := r.db.Query(ctx, getAllQuery)
+	if err != nil {
+		logrus.Errorf("Failed to query exchange rates: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates = []*domains.ExchangeRate{}
+	for rows.Next() {
+		rate := &domains.ExchangeRate{}
+		if err := rows.Scan(&rate.Currency, &rate.Rate, &rate.UpdatedAt); err != nil {
+			logrus.Errorf("Failed to scan exchange rate row: %v", err)
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating exchange rate rows: %v", err)
+		return nil, err
+	}
+
+	go func(rl []*domains.ExchangeRate) {
+		if err := r.cache.SetAll(context.Background(), rl); err != nil {
+			logrus.Warnf("Failed to cache all exchange rates asynchronously: %v", err)
+		} else {
+			logrus.Debugf("Successfully cached all exchange rates asynchronously (Count: %d)", len(rl))
+		}
+	}(rates)
+
+	logrus.Debugf("Exchange rates retrieved successfully (Count: %d)", len(rates))
+	return rates, nil
+}
+
+func (r *exchangeRateRepository) Set(ctx context.Context, currency string, rate float64) (*domains.ExchangeRate, error) {
+	const upsertQuery = `
+        INSERT INTO exchange_rates (currency, rate)
+        VALUES ($1, $2)
+        ON CONFLICT (currency) DO UPDATE SET rate = EXCLUDED.rate, updated_at = CURRENT_TIMESTAMP
+        RETURNING currency, rate, updated_at`
+
+	exchangeRate := &domains.ExchangeRate{}
+	err := r.db.QueryRow(ctx, upsertQuery, currency, rate).Scan(&exchangeRate.Currency, &exchangeRate.Rate, &exchangeRate.UpdatedAt)
+	if err != nil {
+		logrus.Errorf("Failed to set exchange rate (currency: %s): %v", currency, err)
+		return nil, err
+	}
+
+	if err := r.cache.DeleteAll(ctx); err != nil {
+		logrus.Warnf("Failed to clear exchange rate cache after update (currency: %s): %v", currency, err)
+	}
+
+	logrus.Debugf("Exchange rate set successfully (currency: %s, rate: %v)", currency, rate)
+	return exchangeRate, nil
+}
+
+func (r *exchangeRateRepository) Delete(ctx context.Context, currency string) error {
+	const deleteQuery = `DELETE FROM exchange_rates WHERE currency = $1 RETURNING currency`
+
+	var deleted string
+	err := r.db.QueryRow(ctx, deleteQuery, currency).Scan(&deleted)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to delete non-existent exchange rate (currency: %s)", currency)
+			return sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to delete exchange rate (currency: %s): %v", currency, err)
+		return err
+	}
+
+	if err := r.cache.DeleteAll(ctx); err != nil {
+		logrus.Warnf("Failed to clear exchange rate cache after deletion (currency: %s): %v", currency, err)
+	}
+
+	logrus.Debugf("Exchange rate deleted successfully (currency: %s)", deleted)
+	return nil
+}
@@ -0,0 +1,174 @@
+package currency
+
+import (
+	"context"
+	"e-commerce/internal/config"
+	"e-commerce/internal/domains"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultBaseCurrency = "RUB"
+	defaultIncrement    = 0.01
+)
+
+type CurrencyService interface {
+	BaseCurrency() string
+	GetExchangeRates(ctx context.Context) ([]*domains.ExchangeRate, error)
+	SetExchangeRate(ctx context.Context, currency string, req *domains.ExchangeRateRequest) (*domains.ExchangeRate, error)
+	DeleteExchangeRate(ctx context.Context, currency string) error
+	Conversion(ctx context.Context, currency string) (*Conversion, error)
+}
+
+type currencyService struct {
+	repo       ExchangeRateRepository
+	base       string
+	mode       string
+	increment  float64
+	increments map[string]float64
+}
+
+func NewCurrencyService(repo ExchangeRateRepository, cfg *config.CurrencyConfig) CurrencyService {
+	s := &currencyService{
+		repo:       repo,
+		base:       defaultBaseCurrency,
+		mode:       cfg.Rounding.Mode,
+		increment:  cfg.Rounding.Increment,
+		increments: make(map[string]float64, len(cfg.Rounding.Increments)),
+	}
+	if base, err := domains.NormalizeCurrency(cfg.Base); err == nil {
+		s.base = base
+	} else if cfg.Base != "" {
+		logrus.Warnf("Invalid base currency %q, using %s", cfg.Base, defaultBaseCurrency)
+	}
+	switch s.mode {
+	case domains.RoundNearest, domains.RoundUp, domains.RoundDown:
+	default:
+		if s.mode != "" {
+			logrus.Warnf("Unknown rounding mode %q, using %s", s.mode, domains.RoundNearest)
+		}
+		s.mode = domains.RoundNearest
+	}
+	if s.increment <= 0 {
+		s.increment = defaultIncrement
+	}
+	// Viper lower-cases map keys, so currency codes are upper-cased here.
+	for code, increment := range cfg.Rounding.Increments {
+		if increment > 0 {
+			s.increments[strings.ToUpper(code)] = increment
+		}
+	}
+	return s
+}
+
+func (s *currencyService) BaseCurrency() string {
+	return s.base
+}
+
+func (s *currencyService) GetExchangeRates(ctx context.Context) ([]*domains.ExchangeRate, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *currencyService) SetExchangeRate(ctx context.Context, currency string, req *domains.ExchangeRateRequest) (*domains.ExchangeRate, error) {
+	code, err := domains.NormalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	if code == s.base {
+		return nil, fmt.Errorf("%w: %s is the base currency", domains.ErrInvalidCurrency, code)
+	}
+	if req.Rate <= 0 {
+		return nil, fmt.Errorf("%w: rate must be positive", domains.ErrInvalidCurrency)
+	}
+	return s.repo.Set(ctx, code, req.Rate)
+}
+
+func (s *currencyService) DeleteExchangeRate(ctx context.Context, currency string) error {
+	code, err := domains.NormalizeCurrency(currency)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, code)
+}
+
+// Conversion returns the conversion from the base currency into currency.
+// An empty code selects the base currency. Currencies without an exchange
+// rate are rejected with domains.ErrInvalidCurrency.
+func (s *currencyService) Conversion(ctx context.Context, currency string) (*Conversion, error) {
+	if currency == "" {
+		return &Conversion{Currency: s.base, rate: 1}, nil
+	}
+	code, err := domains.NormalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	if code == s.base {
+		return &Conversion{Currency: s.base, rate: 1}, nil
+	}
+
+	rates, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rate := range rates {
+		if rate.Currency == code {
+			increment, ok := s.increments[code]
+			if !ok {
+				increment = s.increment
+			}
+			return &Conversion{Currency: code, rate: rate.Rate, increment: toMinorUnits(increment), mode: s.mode}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no exchange rate for %s", domains.ErrInvalidCurrency, code)
+}
+
+// Conversion converts base-currency amounts into Currency and rounds them.
+// The identity conversion into the base currency leaves amounts untouched.
+type Conversion struct {
+	Currency  string
+	rate      float64
+	increment domains.Money
+	mode      string
+}
+
+// IsBase reports whether the conversion is into the base currency.
+func (c *Conversion) IsBase() bool {
+	return c.rate == 1 && c.increment == 0
+}
+
+// PriceConversion returns the rate and rounding of the conversion, so
+// queries can compare converted prices the way Amount computes them. It is
+// nil for the base currency.
+func (c *Conversion) PriceConversion() *domains.PriceConversion {
+	if c.IsBase() {
+		return nil
+	}
+	return &domains.PriceConversion{Rate: c.rate, Increment: c.increment, Mode: c.mode}
+}
+
+func (c *Conversion) Amount(amount domains.Money) domains.Money {
+	if c.IsBase() {
+		return amount
+	}
+
+	steps := float64(amount) * c.rate / float64(c.increment)
+	switch c.mode {
+	case domains.RoundUp:
+		steps = math.Ceil(steps - 1e-9)
+	case domains.RoundDown:
+		steps = math.Floor(steps + 1e-9)
+	default:
+		steps = math.Round(steps)
+	}
+	return domains.Money(steps) * c.increment
+}
+
+// toMinorUnits turns a configured rounding increment into Money. Increments
+// finer than the minor unit round up to it.
+func toMinorUnits(increment float64) domains.Money {
+	return max(domains.Money(math.Round(increment*100)), 1)
+}
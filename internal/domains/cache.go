@@ -0,0 +1,32 @@
+package domains
+
+// CacheStats reports the cache layers. Counters and the breaker state are
+// kept in process and always present; key counts and Redis memory are nil
+// when Redis could not be queried, with RedisError saying why.
+type CacheStats struct {
+	Breaker        string             `json:"breaker" example:"closed"`
+	RedisAvailable bool               `json:"redis_available"`
+	RedisError     string             `json:"redis_error,omitempty"`
+	Prefixes       []CachePrefixStats `json:"prefixes"`
+	Memory         CacheMemoryStats   `json:"memory"`
+}
+
+type CachePrefixStats struct {
+	Prefix    string  `json:"prefix" example:"brand"`
+	Hits      int64   `json:"hits"`
+	LocalHits int64   `json:"local_hits"`
+	Misses    int64   `json:"misses"`
+	Errors    int64   `json:"errors"`
+	HitRate   float64 `json:"hit_rate"`
+	Keys      *int64  `json:"keys"`
+}
+
+type CacheMemoryStats struct {
+	UsedBytes    *int64 `json:"used_bytes"`
+	UsedHuman    string `json:"used_human,omitempty" example:"1.05M"`
+	LocalEntries int    `json:"local_entries"`
+}
+
+type CachePurgeResult struct {
+	Deleted int64 `json:"deleted"`
+}
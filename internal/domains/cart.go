@@ -0,0 +1,79 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidCartItem = errors.New("invalid cart item")
+
+// MaxCartQuantity caps the quantity of a cart line, including lines summed
+// when adding or merging, so line totals cannot overflow and quantities fit
+// the INT order_items column.
+const MaxCartQuantity = 10000
+
+type CartItemRequest struct {
+	ProductID int  `json:"product_id"`
+	VariantID *int `json:"variant_id,omitempty"`
+	Quantity  int  `json:"quantity"`
+}
+
+func (r *CartItemRequest) Validate() error {
+	if r.ProductID <= 0 {
+		return fmt.Errorf("%w: product_id is required", ErrInvalidCartItem)
+	}
+	return validateCartQuantity(r.Quantity)
+}
+
+type UpdateCartItemRequest struct {
+	Quantity int `json:"quantity"`
+}
+
+func (r *UpdateCartItemRequest) Validate() error {
+	return validateCartQuantity(r.Quantity)
+}
+
+func validateCartQuantity(quantity int) error {
+	if quantity <= 0 {
+		return fmt.Errorf("%w: quantity must be positive", ErrInvalidCartItem)
+	}
+	if quantity > MaxCartQuantity {
+		return fmt.Errorf("%w: quantity must not exceed %d", ErrInvalidCartItem, MaxCartQuantity)
+	}
+	return nil
+}
+
+type MergeCartRequest struct {
+	Token string `json:"token"`
+}
+
+// CartItem is a cart line. Name, SKU and prices are filled from the catalog
+// on every read and are not part of the stored cart.
+type CartItem struct {
+	ID        int    `json:"id"`
+	ProductID int    `json:"product_id"`
+	VariantID *int   `json:"variant_id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	SKU       string `json:"sku,omitempty"`
+	Quantity  int    `json:"quantity"`
+	UnitPrice Money  `json:"unit_price" swaggertype:"string" example:"1299.99"`
+	LineTotal Money  `json:"line_total" swaggertype:"string" example:"2599.98"`
+}
+
+// Cart is either a guest cart identified by Token or a customer cart
+// identified by UserID. Carts are priced in the base currency. Tax is an
+// estimate for the configured default address; checkout taxes the order for
+// the customer's address. Total includes Tax either way.
+type Cart struct {
+	Token       string     `json:"token,omitempty"`
+	UserID      *int       `json:"user_id,omitempty"`
+	Items       []CartItem `json:"items"`
+	ItemCount   int        `json:"item_count"`
+	Subtotal    Money      `json:"subtotal" swaggertype:"string" example:"2599.98"`
+	Tax         Money      `json:"tax" swaggertype:"string" example:"433.33"`
+	TaxIncluded bool       `json:"tax_included"`
+	Total       Money      `json:"total" swaggertype:"string" example:"2599.98"`
+	Currency    string     `json:"currency"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
@@ -1,7 +1,10 @@
 package domains
 
+// Category groups products. TaxClassID is the tax class of its products
+// that have none of their own.
 type Category struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
+	TaxClassID  *int   `json:"tax_class_id,omitempty"`
 }
@@ -0,0 +1,46 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCurrency = errors.New("invalid currency")
+
+// Rounding modes for converted prices.
+const (
+	RoundNearest = "nearest"
+	RoundUp      = "up"
+	RoundDown    = "down"
+)
+
+// NormalizeCurrency upper-cases an ISO 4217 code and checks its shape.
+func NormalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 || strings.Trim(code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", fmt.Errorf("%w: %q is not an ISO 4217 code", ErrInvalidCurrency, code)
+	}
+	return code, nil
+}
+
+type ExchangeRateRequest struct {
+	Rate float64 `json:"rate" example:"0.011"`
+}
+
+// ExchangeRate is the number of units of Currency per unit of the base
+// currency.
+type ExchangeRate struct {
+	Currency  string     `json:"currency"`
+	Rate      float64    `json:"rate"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// PriceConversion converts base-currency prices into another currency: they
+// are multiplied by Rate and rounded to a multiple of Increment as Mode says.
+type PriceConversion struct {
+	Rate      float64
+	Increment Money
+	Mode      string
+}
@@ -0,0 +1,13 @@
+package domains
+
+type HealthResponse struct {
+	Status   string      `json:"status" example:"ok"`
+	Database string      `json:"database" example:"up"`
+	Cache    CacheHealth `json:"cache"`
+}
+
+type CacheHealth struct {
+	Status              string `json:"status" example:"up"`
+	Breaker             string `json:"breaker" example:"closed"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+}
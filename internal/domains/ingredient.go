@@ -0,0 +1,33 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidIngredient = errors.New("invalid ingredient")
+
+const MaxComedogenicRating = 5
+
+// Ingredient is a cosmetic ingredient identified by its INCI name.
+// ComedogenicRating runs from 0 to 5 and is nil when unknown.
+type Ingredient struct {
+	ID                int      `json:"id"`
+	INCIName          string   `json:"inci_name" example:"Niacinamide"`
+	CommonNames       []string `json:"common_names,omitempty" example:"Vitamin B3"`
+	Function          string   `json:"function,omitempty" example:"skin conditioning"`
+	ComedogenicRating *int     `json:"comedogenic_rating,omitempty" example:"0"`
+	IsAllergen        bool     `json:"is_allergen"`
+}
+
+func (i *Ingredient) Validate() error {
+	i.INCIName = strings.TrimSpace(i.INCIName)
+	if i.INCIName == "" {
+		return fmt.Errorf("%w: inci_name is required", ErrInvalidIngredient)
+	}
+	if i.ComedogenicRating != nil && (*i.ComedogenicRating < 0 || *i.ComedogenicRating > MaxComedogenicRating) {
+		return fmt.Errorf("%w: comedogenic_rating must be between 0 and %d", ErrInvalidIngredient, MaxComedogenicRating)
+	}
+	return nil
+}
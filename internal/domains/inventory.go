@@ -0,0 +1,125 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidAdjustment = errors.New("invalid stock adjustment")
+	ErrInsufficientStock = errors.New("insufficient stock")
+)
+
+type MovementType string
+
+const (
+	MovementReceipt    MovementType = "receipt"
+	MovementSale       MovementType = "sale"
+	MovementAdjustment MovementType = "adjustment"
+	MovementReturn     MovementType = "return"
+)
+
+// StockAdjustmentRequest records a stock change. Quantity is positive for
+// receipts, sales and returns (the sign follows from the type) and signed for
+// manual adjustments. Without a warehouse the change is applied to the
+// highest-priority active warehouse.
+type StockAdjustmentRequest struct {
+	ProductID   int          `json:"product_id"`
+	VariantID   *int         `json:"variant_id,omitempty"`
+	WarehouseID *int         `json:"warehouse_id,omitempty"`
+	Type        MovementType `json:"type" example:"receipt"`
+	Quantity    int          `json:"quantity"`
+	Reason      string       `json:"reason,omitempty"`
+}
+
+// Delta validates the request and returns the signed quantity change.
+func (r *StockAdjustmentRequest) Delta() (int, error) {
+	if r.ProductID <= 0 {
+		return 0, fmt.Errorf("%w: product_id is required", ErrInvalidAdjustment)
+	}
+
+	switch r.Type {
+	case MovementReceipt, MovementReturn:
+		if r.Quantity <= 0 {
+			return 0, fmt.Errorf("%w: quantity must be positive for %s", ErrInvalidAdjustment, r.Type)
+		}
+		return r.Quantity, nil
+	case MovementSale:
+		if r.Quantity <= 0 {
+			return 0, fmt.Errorf("%w: quantity must be positive for %s", ErrInvalidAdjustment, r.Type)
+		}
+		return -r.Quantity, nil
+	case MovementAdjustment:
+		if r.Quantity == 0 {
+			return 0, fmt.Errorf("%w: quantity must not be zero", ErrInvalidAdjustment)
+		}
+		if r.Reason == "" {
+			return 0, fmt.Errorf("%w: reason is required for manual adjustments", ErrInvalidAdjustment)
+		}
+		return r.Quantity, nil
+	default:
+		return 0, fmt.Errorf("%w: unknown movement type %q", ErrInvalidAdjustment, r.Type)
+	}
+}
+
+type StockLevel struct {
+	WarehouseID int        `json:"warehouse_id"`
+	ProductID   int        `json:"product_id"`
+	VariantID   *int       `json:"variant_id,omitempty"`
+	Quantity    int        `json:"quantity"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+type StockMovement struct {
+	ID          int          `json:"id"`
+	WarehouseID int          `json:"warehouse_id"`
+	ProductID   int          `json:"product_id"`
+	VariantID   *int         `json:"variant_id,omitempty"`
+	Type        MovementType `json:"type"`
+	Quantity    int          `json:"quantity"`
+	Reason      string       `json:"reason,omitempty"`
+	CreatedAt   *time.Time   `json:"created_at,omitempty"`
+}
+
+type StockAdjustmentResponse struct {
+	Level    StockLevel    `json:"level"`
+	Movement StockMovement `json:"movement"`
+}
+
+type AllocationRequest struct {
+	ProductID  int  `json:"product_id"`
+	VariantID  *int `json:"variant_id,omitempty"`
+	Quantity   int  `json:"quantity"`
+	AllowSplit bool `json:"allow_split,omitempty"`
+}
+
+func (r *AllocationRequest) Validate() error {
+	if r.ProductID <= 0 {
+		return fmt.Errorf("%w: product_id is required", ErrInvalidAdjustment)
+	}
+	if r.Quantity <= 0 {
+		return fmt.Errorf("%w: quantity must be positive", ErrInvalidAdjustment)
+	}
+	return nil
+}
+
+// WarehouseStock is the on-hand quantity of one product or variant in one
+// active warehouse.
+type WarehouseStock struct {
+	WarehouseID int `json:"warehouse_id"`
+	Priority    int `json:"priority"`
+	Quantity    int `json:"quantity"`
+}
+
+type AllocationLine struct {
+	WarehouseID int `json:"warehouse_id"`
+	Quantity    int `json:"quantity"`
+}
+
+type Allocation struct {
+	ProductID int              `json:"product_id"`
+	VariantID *int             `json:"variant_id,omitempty"`
+	Quantity  int              `json:"quantity"`
+	Lines     []AllocationLine `json:"lines"`
+}
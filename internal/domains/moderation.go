@@ -0,0 +1,40 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidModeration = errors.New("invalid moderation decision")
+
+// ModerationStatus tracks user content through the moderation queue. Only
+// approved content is shown publicly.
+type ModerationStatus string
+
+const (
+	ModerationPending  ModerationStatus = "pending"
+	ModerationApproved ModerationStatus = "approved"
+	ModerationRejected ModerationStatus = "rejected"
+)
+
+func (s ModerationStatus) Valid() bool {
+	switch s {
+	case ModerationPending, ModerationApproved, ModerationRejected:
+		return true
+	}
+	return false
+}
+
+// ModerationRequest approves or rejects user content. Note is shown to
+// moderators only.
+type ModerationRequest struct {
+	Status ModerationStatus `json:"status" example:"approved"`
+	Note   string           `json:"note,omitempty"`
+}
+
+func (r *ModerationRequest) Validate() error {
+	if r.Status != ModerationApproved && r.Status != ModerationRejected {
+		return fmt.Errorf("%w: status must be %s or %s", ErrInvalidModeration, ModerationApproved, ModerationRejected)
+	}
+	return nil
+}
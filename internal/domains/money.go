@@ -0,0 +1,184 @@
+package domains
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidMoney = errors.New("invalid money amount")
+
+// Money is an exact amount in minor units (kopecks, cents). It is written
+// to JSON as a decimal string such as "1299.99" and stored in NUMERIC
+// columns with two decimal places.
+type Money int64
+
+const (
+	// MoneyScale is the number of decimal places Money keeps.
+	MoneyScale = 2
+
+	// MaxPrice is the largest value a NUMERIC(10,2) price column holds.
+	MaxPrice Money = 99_999_999_99
+	// MaxMoney is the largest value a NUMERIC(12,2) total column holds and
+	// the largest amount ParseMoney accepts.
+	MaxMoney Money = 9_999_999_999_99
+)
+
+// ParseMoney parses a decimal such as "12", "12.3" or "-12.34". Digits past
+// the second decimal place must be zero; amounts are never rounded.
+func ParseMoney(s string) (Money, error) {
+	text := strings.TrimSpace(s)
+	negative := strings.HasPrefix(text, "-")
+	if negative {
+		text = text[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(text, ".")
+	if whole == "" || !isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("%w: %q is not a decimal number", ErrInvalidMoney, s)
+	}
+	if len(fraction) > MoneyScale {
+		if strings.Trim(fraction[MoneyScale:], "0") != "" {
+			return 0, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidMoney, s, MoneyScale)
+		}
+		fraction = fraction[:MoneyScale]
+	}
+	fraction += strings.Repeat("0", MoneyScale-len(fraction))
+
+	whole = strings.TrimLeft(whole, "0")
+	if len(whole) > 13 {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidMoney, s)
+	}
+	units, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil || Money(units) > MaxMoney {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidMoney, s)
+	}
+	if negative {
+		units = -units
+	}
+	return Money(units), nil
+}
+
+func isDigits(s string) bool {
+	return strings.Trim(s, "0123456789") == ""
+}
+
+func (m Money) String() string {
+	units := int64(m)
+	sign := ""
+	if units < 0 {
+		sign, units = "-", -units
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, units/100, units%100)
+}
+
+// Mul returns the amount times n, e.g. a unit price times a quantity.
+func (m Money) Mul(n int) Money {
+	return m * Money(n)
+}
+
+// Percent returns percent of the amount, rounded half away from zero to
+// the minor unit. The percentage itself has two decimal places.
+func (m Money) Percent(percent Money) Money {
+	return m.MulDiv(int64(percent), 10000)
+}
+
+// MulDiv returns the amount times num divided by den, rounded half away from
+// zero to the minor unit; den must be positive. The product is computed in
+// 128 bits, so it cannot overflow; a result beyond the range of Money
+// saturates.
+func (m Money) MulDiv(num, den int64) Money {
+	return m.mulDiv(num, den, uint64(den)/2)
+}
+
+// MulDivDown is MulDiv rounded toward zero, for splitting an amount into
+// parts that must not add up to more than the whole.
+func (m Money) MulDivDown(num, den int64) Money {
+	return m.mulDiv(num, den, 0)
+}
+
+// mulDiv adds half to the magnitude of the 128-bit product before dividing.
+func (m Money) mulDiv(num, den int64, half uint64) Money {
+	hi, lo := bits.Mul64(absUnits(m), absUnits(Money(num)))
+	lo, carry := bits.Add64(lo, half, 0)
+	hi += carry
+
+	result := Money(math.MaxInt64)
+	if hi < uint64(den) {
+		if quotient, _ := bits.Div64(hi, lo, uint64(den)); quotient <= math.MaxInt64 {
+			result = Money(quotient)
+		}
+	}
+	if (m < 0) != (num < 0) {
+		return -result
+	}
+	return result
+}
+
+// absUnits returns the magnitude of m in minor units.
+func absUnits(m Money) uint64 {
+	if m < 0 {
+		return uint64(-(m + 1)) + 1
+	}
+	return uint64(m)
+}
+
+// Float64 returns the amount in major units, for ratios and display only.
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + m.String() + `"`), nil
+}
+
+// UnmarshalJSON accepts the decimal as a string or, for older clients, as
+// a JSON number. Either way the text is parsed exactly.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	if strings.ContainsAny(text, "eE") {
+		return fmt.Errorf("%w: %q must not use an exponent", ErrInvalidMoney, text)
+	}
+
+	amount, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = amount
+	return nil
+}
+
+// Scan reads a NUMERIC column, which pgx hands over as a decimal string.
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		amount, err := ParseMoney(v)
+		if err != nil {
+			return err
+		}
+		*m = amount
+	case []byte:
+		return m.Scan(string(v))
+	case int64:
+		*m = Money(v * 100)
+	case nil:
+		return fmt.Errorf("%w: cannot scan NULL", ErrInvalidMoney)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidMoney, src)
+	}
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
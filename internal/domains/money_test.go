@@ -0,0 +1,128 @@
+package domains
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{name: "whole amount", input: "12", want: 1200},
+		{name: "one decimal place", input: "12.3", want: 1230},
+		{name: "two decimal places", input: "1299.99", want: 129999},
+		{name: "negative", input: "-12.34", want: -1234},
+		{name: "surrounding space", input: " 7.50 ", want: 750},
+		{name: "leading zeros", input: "0009.99", want: 999},
+		{name: "zeros past the scale", input: "1.2300", want: 123},
+		{name: "largest amount", input: "9999999999.99", want: MaxMoney},
+		{name: "beyond the largest amount", input: "10000000000", wantErr: true},
+		{name: "digits past the scale", input: "1.234", wantErr: true},
+		{name: "exponent", input: "1e3", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+		{name: "missing whole part", input: ".5", wantErr: true},
+		{name: "double sign", input: "--1", wantErr: true},
+		{name: "not a number", input: "abc", wantErr: true},
+		{name: "too many digits", input: "10000000000000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMoney) {
+					t.Fatalf("ParseMoney(%q) error = %v, want %v", tt.input, err, ErrInvalidMoney)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMoney(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMoney(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMulDiv(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Money
+		num, den int64
+		want     Money
+	}{
+		{name: "exact", amount: 1000, num: 15, den: 100, want: 150},
+		{name: "rounds down below half", amount: 4, num: 1, den: 3, want: 1},
+		{name: "rounds up above half", amount: 5, num: 1, den: 3, want: 2},
+		{name: "half rounds away from zero", amount: 5, num: 1, den: 2, want: 3},
+		{name: "negative amount", amount: -5, num: 1, den: 2, want: -3},
+		{name: "negative multiplier", amount: 5, num: -1, den: 2, want: -3},
+		{name: "both negative", amount: -5, num: -1, den: 2, want: 3},
+		{name: "product beyond 64 bits", amount: math.MaxInt64, num: 2, den: 4, want: 1 << 62},
+		{name: "quotient beyond range saturates", amount: math.MaxInt64, num: 3, den: 2, want: math.MaxInt64},
+		{name: "high word not below divisor saturates", amount: math.MaxInt64, num: math.MaxInt64, den: 1, want: math.MaxInt64},
+		{name: "negative result saturates", amount: math.MinInt64, num: 2, den: 1, want: -math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.amount.MulDiv(tt.num, tt.den); got != tt.want {
+				t.Errorf("Money(%d).MulDiv(%d, %d) = %d, want %d", tt.amount, tt.num, tt.den, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMulDivDown(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Money
+		num, den int64
+		want     Money
+	}{
+		{name: "exact", amount: 1000, num: 15, den: 100, want: 150},
+		{name: "half rounds down", amount: 5, num: 1, den: 2, want: 2},
+		{name: "just below the next unit", amount: 199, num: 1, den: 100, want: 1},
+		{name: "negative rounds toward zero", amount: -5, num: 1, den: 2, want: -2},
+		{name: "product beyond 64 bits", amount: MaxMoney, num: int64(MaxMoney), den: 2 * int64(MaxMoney), want: 4_999_999_999_99},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.amount.MulDivDown(tt.num, tt.den); got != tt.want {
+				t.Errorf("Money(%d).MulDivDown(%d, %d) = %d, want %d", tt.amount, tt.num, tt.den, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  Money
+		percent Money
+		want    Money
+	}{
+		{name: "whole percent", amount: 10000, percent: 1500, want: 1500},
+		{name: "fractional percent", amount: 999, percent: 1250, want: 125},
+		{name: "negative amount", amount: -999, percent: 1250, want: -125},
+		{name: "half a unit rounds up", amount: 1, percent: 5000, want: 1},
+		{name: "below half a unit rounds down", amount: 1, percent: 4999, want: 0},
+		{name: "zero percent", amount: 12345, percent: 0, want: 0},
+		{name: "hundred percent", amount: MaxMoney, percent: 10000, want: MaxMoney},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.amount.Percent(tt.percent); got != tt.want {
+				t.Errorf("Money(%d).Percent(%d) = %d, want %d", tt.amount, tt.percent, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidOrder      = errors.New("invalid order")
+	ErrInvalidTransition = errors.New("invalid order status transition")
+)
+
+type OrderStatus string
+
+const (
+	OrderPending   OrderStatus = "pending"
+	OrderPaid      OrderStatus = "paid"
+	OrderFulfilled OrderStatus = "fulfilled"
+	OrderShipped   OrderStatus = "shipped"
+	OrderDelivered OrderStatus = "delivered"
+	OrderCancelled OrderStatus = "cancelled"
+	OrderRefunded  OrderStatus = "refunded"
+)
+
+// orderTransitions lists the statuses each status may move to. Cancelled and
+// refunded orders are final.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderPending:   {OrderPaid, OrderCancelled},
+	OrderPaid:      {OrderFulfilled, OrderCancelled, OrderRefunded},
+	OrderFulfilled: {OrderShipped, OrderRefunded},
+	OrderShipped:   {OrderDelivered, OrderRefunded},
+	OrderDelivered: {OrderRefunded},
+	OrderCancelled: {},
+	OrderRefunded:  {},
+}
+
+func (s OrderStatus) Valid() bool {
+	_, ok := orderTransitions[s]
+	return ok
+}
+
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckoutRequest turns a cart into an order. Exactly one of UserID and
+// CartToken selects the cart; guests must leave an email. Coupon codes that
+// cannot be applied are ignored. Address is where tax is due and defaults to
+// the configured tax address. ReservationIDs are stock holds taken for the
+// cart; they are released when the order takes the stock.
+type CheckoutRequest struct {
+	UserID         *int        `json:"user_id,omitempty"`
+	CartToken      string      `json:"cart_token,omitempty"`
+	Email          string      `json:"email,omitempty" example:"customer@example.com"`
+	CouponCodes    []string    `json:"coupon_codes,omitempty"`
+	Address        *TaxAddress `json:"address,omitempty"`
+	ReservationIDs []int       `json:"reservation_ids,omitempty"`
+}
+
+func (r *CheckoutRequest) Validate() error {
+	if (r.UserID == nil) == (r.CartToken == "") {
+		return fmt.Errorf("%w: exactly one of user_id and cart_token is required", ErrInvalidOrder)
+	}
+	if r.UserID != nil && *r.UserID <= 0 {
+		return fmt.Errorf("%w: user_id must be positive", ErrInvalidOrder)
+	}
+	if r.CartToken != "" && r.Email == "" {
+		return fmt.Errorf("%w: email is required for guest checkout", ErrInvalidOrder)
+	}
+	seen := make(map[int]bool, len(r.ReservationIDs))
+	for _, id := range r.ReservationIDs {
+		if id <= 0 {
+			return fmt.Errorf("%w: reservation_ids must be positive", ErrInvalidOrder)
+		}
+		if seen[id] {
+			return fmt.Errorf("%w: duplicate reservation %d", ErrInvalidOrder, id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+type OrderTransitionRequest struct {
+	Status OrderStatus `json:"status" example:"paid"`
+	Note   string      `json:"note,omitempty"`
+}
+
+// OrderFilter selects orders for listing. Zero values are ignored; From is
+// inclusive and To exclusive.
+type OrderFilter struct {
+	UserID *int
+	Status OrderStatus
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
+
+type OrderItem struct {
+	ID          int        `json:"id"`
+	ProductID   *int       `json:"product_id,omitempty"`
+	VariantID   *int       `json:"variant_id,omitempty"`
+	ProductName string     `json:"product_name"`
+	SKU         string     `json:"sku,omitempty"`
+	Quantity    int        `json:"quantity"`
+	UnitPrice   Money      `json:"unit_price" swaggertype:"string" example:"1299.99"`
+	LineTotal   Money      `json:"line_total" swaggertype:"string" example:"2599.98"`
+	Discount    Money      `json:"discount" swaggertype:"string" example:"260.00"`
+	Tax         Money      `json:"tax" swaggertype:"string" example:"390.00"`
+	TaxRate     TaxPercent `json:"tax_rate" swaggertype:"number" example:"20"`
+}
+
+type OrderStatusChange struct {
+	FromStatus OrderStatus `json:"from_status,omitempty"`
+	ToStatus   OrderStatus `json:"to_status"`
+	Note       string      `json:"note,omitempty"`
+	CreatedAt  *time.Time  `json:"created_at,omitempty"`
+}
+
+// Order totals are snapshotted at checkout: Total is Subtotal less Discount,
+// plus Tax unless TaxIncluded, and PromotionIDs lists the promotions that
+// made up the discount.
+type Order struct {
+	ID           int                 `json:"id"`
+	UserID       *int                `json:"user_id,omitempty"`
+	Email        string              `json:"email,omitempty"`
+	Status       OrderStatus         `json:"status"`
+	Subtotal     Money               `json:"subtotal" swaggertype:"string" example:"2599.98"`
+	Discount     Money               `json:"discount" swaggertype:"string" example:"260.00"`
+	Tax          Money               `json:"tax" swaggertype:"string" example:"390.00"`
+	TaxIncluded  bool                `json:"tax_included"`
+	TaxCountry   string              `json:"tax_country,omitempty"`
+	TaxRegion    string              `json:"tax_region,omitempty"`
+	Total        Money               `json:"total" swaggertype:"string" example:"2339.98"`
+	Currency     string              `json:"currency,omitempty"`
+	PromotionIDs []int               `json:"promotion_ids,omitempty"`
+	Items        []OrderItem         `json:"items,omitempty"`
+	History      []OrderStatusChange `json:"history,omitempty"`
+	CreatedAt    *time.Time          `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time          `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,70 @@
+package domains
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidPayment   = errors.New("invalid payment")
+	ErrPaymentDeclined  = errors.New("payment declined")
+	ErrPaymentState     = errors.New("operation not allowed in the current payment state")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+type PaymentStatus string
+
+const (
+	PaymentAuthorized PaymentStatus = "authorized"
+	PaymentCaptured   PaymentStatus = "captured"
+	PaymentRefunded   PaymentStatus = "refunded"
+	PaymentVoided     PaymentStatus = "voided"
+	PaymentFailed     PaymentStatus = "failed"
+)
+
+type PaymentRequest struct {
+	PaymentMethod string `json:"payment_method" example:"fake_card"`
+}
+
+// RefundRequest refunds Amount, or everything captured and not yet refunded
+// when Amount is omitted.
+type RefundRequest struct {
+	Amount *Money `json:"amount,omitempty" swaggertype:"string" example:"500.00"`
+}
+
+type Payment struct {
+	ID             int           `json:"id"`
+	OrderID        int           `json:"order_id"`
+	Provider       string        `json:"provider"`
+	ProviderRef    string        `json:"provider_ref,omitempty"`
+	Status         PaymentStatus `json:"status"`
+	Amount         Money         `json:"amount" swaggertype:"string" example:"2339.98"`
+	CapturedAmount Money         `json:"captured_amount" swaggertype:"string" example:"2339.98"`
+	RefundedAmount Money         `json:"refunded_amount" swaggertype:"string" example:"0.00"`
+	FailureReason  string        `json:"failure_reason,omitempty"`
+	CreatedAt      *time.Time    `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time    `json:"updated_at,omitempty"`
+}
+
+type WebhookEventType string
+
+const (
+	WebhookPaymentCaptured WebhookEventType = "payment.captured"
+	WebhookPaymentRefunded WebhookEventType = "payment.refunded"
+	WebhookPaymentVoided   WebhookEventType = "payment.voided"
+	WebhookPaymentFailed   WebhookEventType = "payment.failed"
+)
+
+// WebhookEvent is a verified provider notification. For refunds Amount is the
+// total refunded so far, which keeps redelivered events harmless.
+type WebhookEvent struct {
+	ID          string           `json:"id"`
+	Type        WebhookEventType `json:"type"`
+	ProviderRef string           `json:"provider_ref"`
+	Amount      Money            `json:"amount,omitempty" swaggertype:"string" example:"500.00"`
+	Reason      string           `json:"reason,omitempty"`
+}
+
+type WebhookResponse struct {
+	Status string `json:"status" example:"processed"`
+}
@@ -1,28 +1,138 @@
 package domains
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
 	"time"
 )
 
+var ErrInvalidProduct = errors.New("invalid product")
+
+// ProductRequest creates or replaces a product. A sale price applies between
+// SaleStartsAt and SaleEndsAt; either bound may be left open. Variants with
+// their own price are not affected by the sale. IngredientIDs lists the
+// ingredients in label order and Concerns the skin concerns the product
+// addresses.
 type ProductRequest struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description,omitempty"`
-	Price       float64 `json:"price"`
-	CategoryID  *int    `json:"category_id,omitempty"`
-	BrandID     *int    `json:"brand_id,omitempty"`
-	SkinTypeIDs []int   `json:"skin_type_ids,omitempty"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description,omitempty"`
+	Price         Money      `json:"price" swaggertype:"string" example:"1299.99"`
+	SalePrice     *Money     `json:"sale_price,omitempty" swaggertype:"string" example:"999.99"`
+	SaleStartsAt  *time.Time `json:"sale_starts_at,omitempty"`
+	SaleEndsAt    *time.Time `json:"sale_ends_at,omitempty"`
+	CategoryID    *int       `json:"category_id,omitempty"`
+	BrandID       *int       `json:"brand_id,omitempty"`
+	TaxClassID    *int       `json:"tax_class_id,omitempty"`
+	SkinTypeIDs   []int      `json:"skin_type_ids,omitempty"`
+	IngredientIDs []int      `json:"ingredient_ids,omitempty"`
+	Concerns      []Concern  `json:"concerns,omitempty"`
+}
+
+// Validate checks the price, the sale settings, the ingredient list and the
+// concerns, and stores the sale window in UTC.
+func (r *ProductRequest) Validate() error {
+	if r.Price < 0 || r.Price > MaxPrice {
+		return fmt.Errorf("%w: price must be between 0 and %s", ErrInvalidProduct, MaxPrice)
+	}
+	seen := make(map[int]bool, len(r.IngredientIDs))
+	for _, id := range r.IngredientIDs {
+		if seen[id] {
+			return fmt.Errorf("%w: ingredient %d is listed twice", ErrInvalidProduct, id)
+		}
+		seen[id] = true
+	}
+	for i, concern := range r.Concerns {
+		if !concern.Valid() {
+			return fmt.Errorf("%w: unknown concern %q", ErrInvalidProduct, concern)
+		}
+		if slices.Contains(r.Concerns[:i], concern) {
+			return fmt.Errorf("%w: concern %q is listed twice", ErrInvalidProduct, concern)
+		}
+	}
+	if r.SalePrice == nil {
+		if r.SaleStartsAt != nil || r.SaleEndsAt != nil {
+			return fmt.Errorf("%w: a sale window requires sale_price", ErrInvalidProduct)
+		}
+		return nil
+	}
+	if *r.SalePrice < 0 || *r.SalePrice >= r.Price {
+		return fmt.Errorf("%w: sale_price must be below price", ErrInvalidProduct)
+	}
+	if r.SaleStartsAt != nil && r.SaleEndsAt != nil && !r.SaleEndsAt.After(*r.SaleStartsAt) {
+		return fmt.Errorf("%w: sale_ends_at must be after sale_starts_at", ErrInvalidProduct)
+	}
+	if r.SaleStartsAt != nil {
+		startsAt := r.SaleStartsAt.UTC()
+		r.SaleStartsAt = &startsAt
+	}
+	if r.SaleEndsAt != nil {
+		endsAt := r.SaleEndsAt.UTC()
+		r.SaleEndsAt = &endsAt
+	}
+	return nil
 }
 
+// ProductResponse is a product as shown to customers. Price is the price it
+// sells at now and OriginalPrice the regular price; they differ, and
+// DiscountPercent is set, while a sale is running. All prices, including
+// those of the variants, are in Currency.
 type ProductResponse struct {
-	ID          int        `json:"id"`
-	Name        string     `json:"name"`
-	Description string     `json:"description,omitempty"`
-	Price       float64    `json:"price"`
-	Category    *Category  `json:"category,omitempty"`
-	Brand       *Brand     `json:"brand,omitempty"`
-	SkinTypes   []SkinType `json:"skin_types,omitempty"`
-	CreatedAt   *time.Time `json:"created_at,omitempty"`
-	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	ID              int              `json:"id"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description,omitempty"`
+	Price           Money            `json:"price" swaggertype:"string" example:"999.99"`
+	OriginalPrice   Money            `json:"original_price" swaggertype:"string" example:"1299.99"`
+	DiscountPercent int              `json:"discount_percent,omitempty"`
+	SalePrice       *Money           `json:"sale_price,omitempty" swaggertype:"string" example:"999.99"`
+	SaleStartsAt    *time.Time       `json:"sale_starts_at,omitempty"`
+	SaleEndsAt      *time.Time       `json:"sale_ends_at,omitempty"`
+	Currency        string           `json:"currency"`
+	Category        *Category        `json:"category,omitempty"`
+	Brand           *Brand           `json:"brand,omitempty"`
+	TaxClassID      *int             `json:"tax_class_id,omitempty"`
+	SkinTypes       []SkinType       `json:"skin_types,omitempty"`
+	Ingredients     []Ingredient     `json:"ingredients,omitempty"`
+	Concerns        []Concern        `json:"concerns,omitempty"`
+	Variants        []ProductVariant `json:"variants,omitempty"`
+	InStock         bool             `json:"in_stock"`
+	StockQuantity   int              `json:"stock_quantity"`
+	RatingAverage   float64          `json:"rating_average" example:"4.6"`
+	RatingCount     int              `json:"rating_count"`
+	SkinTypeRatings []SkinTypeRating `json:"skin_type_ratings,omitempty"`
+	CreatedAt       *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time       `json:"updated_at,omitempty"`
+}
+
+// ApplySale sets Price and DiscountPercent from OriginalPrice and the sale
+// settings as of now. It runs on every read, cached or not, so sales start
+// and end on schedule.
+func (p *ProductResponse) ApplySale(now time.Time) {
+	p.Price = p.OriginalPrice
+	p.DiscountPercent = 0
+	if p.SalePrice == nil ||
+		(p.SaleStartsAt != nil && now.Before(*p.SaleStartsAt)) ||
+		(p.SaleEndsAt != nil && !now.Before(*p.SaleEndsAt)) {
+		return
+	}
+
+	p.Price = *p.SalePrice
+	if p.OriginalPrice > 0 {
+		p.DiscountPercent = int(math.Round((1 - p.Price.Float64()/p.OriginalPrice.Float64()) * 100))
+	}
+}
+
+// ProductPrice is an entry in a product's price history.
+type ProductPrice struct {
+	ID        int        `json:"id"`
+	ProductID int        `json:"product_id"`
+	Price     Money      `json:"price" swaggertype:"string" example:"1299.99"`
+	SalePrice *Money     `json:"sale_price,omitempty" swaggertype:"string" example:"999.99"`
+	StartsAt  *time.Time `json:"starts_at,omitempty"`
+	EndsAt    *time.Time `json:"ends_at,omitempty"`
+	ChangedAt *time.Time `json:"changed_at,omitempty"`
 }
 
 type ProductImage struct {
@@ -35,6 +145,95 @@ type ProductImage struct {
 }
 
 type PriceRange struct {
-	MinPrice *float64 `json:"min_price,omitempty"`
-	MaxPrice *float64 `json:"max_price,omitempty"`
+	MinPrice *Money `json:"min_price,omitempty" swaggertype:"string" example:"500.00"`
+	MaxPrice *Money `json:"max_price,omitempty" swaggertype:"string" example:"2000.00"`
+}
+
+func (r *PriceRange) Validate() error {
+	if r.MinPrice != nil && *r.MinPrice < 0 {
+		return fmt.Errorf("%w: min_price must not be negative", ErrInvalidMoney)
+	}
+	if r.MinPrice != nil && r.MaxPrice != nil && *r.MaxPrice < *r.MinPrice {
+		return fmt.Errorf("%w: max_price must not be below min_price", ErrInvalidMoney)
+	}
+	return nil
+}
+
+// ProductSort orders filtered products. The default is by ID.
+type ProductSort string
+
+const (
+	SortByID     ProductSort = ""
+	SortByRating ProductSort = "rating"
+)
+
+// ProductFilter selects products. WithIngredientIDs matches products
+// containing all of the ingredients, WithoutIngredientIDs products containing
+// none of them.
+type ProductFilter struct {
+	SkinTypeIDs          []int       `json:"skin_type_ids,omitempty"`
+	BrandIDs             []int       `json:"brand_ids,omitempty"`
+	CategoryIDs          []int       `json:"category_ids,omitempty"`
+	PriceRange           *PriceRange `json:"price_range,omitempty"`
+	InStock              bool        `json:"in_stock,omitempty"`
+	MinRating            *float64    `json:"min_rating,omitempty"`
+	WithIngredientIDs    []int       `json:"with_ingredient_ids,omitempty"`
+	WithoutIngredientIDs []int       `json:"without_ingredient_ids,omitempty"`
+	Sort                 ProductSort `json:"sort,omitempty"`
+	Currency             string      `json:"currency,omitempty"`
+	// Conversion turns base prices into Currency for the price range. The
+	// service sets it from Currency, so it is not part of the cache key.
+	Conversion *PriceConversion `json:"-"`
+}
+
+func (f *ProductFilter) Validate() error {
+	if f.PriceRange != nil {
+		if err := f.PriceRange.Validate(); err != nil {
+			return err
+		}
+	}
+	if f.MinRating != nil && !(*f.MinRating >= MinRating && *f.MinRating <= MaxRating) {
+		return fmt.Errorf("%w: min_rating must be between %d and %d", ErrInvalidProduct, MinRating, MaxRating)
+	}
+	if f.Sort != SortByID && f.Sort != SortByRating {
+		return fmt.Errorf("%w: unknown sort %q", ErrInvalidProduct, f.Sort)
+	}
+	return nil
+}
+
+// ProductFilterKeyPrefix starts every ProductFilter.CacheKey, so cached
+// filter results can be dropped together.
+const ProductFilterKeyPrefix = "filter"
+
+// CacheKey returns a stable key for the filter; ID order does not matter.
+// The currency is part of the key because the price range is expressed in
+// it.
+func (f *ProductFilter) CacheKey() string {
+	var minPrice, maxPrice, minRating string
+	if f.PriceRange != nil {
+		if f.PriceRange.MinPrice != nil {
+			minPrice = f.PriceRange.MinPrice.String()
+		}
+		if f.PriceRange.MaxPrice != nil {
+			maxPrice = f.PriceRange.MaxPrice.String()
+		}
+	}
+
+	if f.MinRating != nil {
+		minRating = strconv.FormatFloat(*f.MinRating, 'f', -1, 64)
+	}
+
+	return fmt.Sprintf("%s:skin=%v:brand=%v:category=%v:price=%s-%s:in_stock=%t:rating=%s:with=%v:without=%v:sort=%s:currency=%s",
+		ProductFilterKeyPrefix,
+		slices.Sorted(slices.Values(f.SkinTypeIDs)),
+		slices.Sorted(slices.Values(f.BrandIDs)),
+		slices.Sorted(slices.Values(f.CategoryIDs)),
+		minPrice, maxPrice,
+		f.InStock,
+		minRating,
+		slices.Sorted(slices.Values(f.WithIngredientIDs)),
+		slices.Sorted(slices.Values(f.WithoutIngredientIDs)),
+		f.Sort,
+		f.Currency,
+	)
 }
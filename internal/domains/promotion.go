@@ -0,0 +1,211 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidPromotion = errors.New("invalid promotion")
+	ErrCouponExhausted  = errors.New("coupon usage limit reached")
+)
+
+type DiscountType string
+
+const (
+	DiscountPercentage DiscountType = "percentage"
+	DiscountFixed      DiscountType = "fixed"
+)
+
+type PromotionTarget string
+
+const (
+	TargetAll      PromotionTarget = "all"
+	TargetProduct  PromotionTarget = "product"
+	TargetBrand    PromotionTarget = "brand"
+	TargetCategory PromotionTarget = "category"
+	TargetSkinType PromotionTarget = "skin_type"
+)
+
+func (t PromotionTarget) Valid() bool {
+	switch t {
+	case TargetAll, TargetProduct, TargetBrand, TargetCategory, TargetSkinType:
+		return true
+	}
+	return false
+}
+
+// PromotionRequest creates or replaces a promotion. Promotions without a
+// coupon code apply automatically; Target defaults to the whole store.
+// DiscountValue is an amount for fixed discounts and a percentage, with two
+// decimal places, for percentage discounts.
+type PromotionRequest struct {
+	Name           string          `json:"name" example:"20% off CeraVe"`
+	Description    string          `json:"description,omitempty"`
+	DiscountType   DiscountType    `json:"discount_type" example:"percentage"`
+	DiscountValue  Money           `json:"discount_value" swaggertype:"string" example:"20.00"`
+	Target         PromotionTarget `json:"target,omitempty" example:"brand"`
+	TargetIDs      []int           `json:"target_ids,omitempty"`
+	MinOrderAmount *Money          `json:"min_order_amount,omitempty" swaggertype:"string" example:"3000.00"`
+	CouponCode     string          `json:"coupon_code,omitempty" example:"SPRING20"`
+	UsageLimit     *int            `json:"usage_limit,omitempty"`
+	StartsAt       *time.Time      `json:"starts_at,omitempty"`
+	EndsAt         *time.Time      `json:"ends_at,omitempty"`
+	Stackable      bool            `json:"stackable"`
+	Priority       int             `json:"priority"`
+	IsActive       *bool           `json:"is_active,omitempty"`
+}
+
+// Validate checks the request and normalizes the target, coupon code and
+// the promotion window, which is stored in UTC.
+func (r *PromotionRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidPromotion)
+	}
+	switch r.DiscountType {
+	case DiscountPercentage:
+		if r.DiscountValue <= 0 || r.DiscountValue > 100_00 {
+			return fmt.Errorf("%w: percentage must be between 0 and 100", ErrInvalidPromotion)
+		}
+	case DiscountFixed:
+		if r.DiscountValue <= 0 || r.DiscountValue > MaxPrice {
+			return fmt.Errorf("%w: fixed discount must be positive", ErrInvalidPromotion)
+		}
+	default:
+		return fmt.Errorf("%w: unknown discount type %q", ErrInvalidPromotion, r.DiscountType)
+	}
+
+	if r.Target == "" {
+		r.Target = TargetAll
+	}
+	if !r.Target.Valid() {
+		return fmt.Errorf("%w: unknown target %q", ErrInvalidPromotion, r.Target)
+	}
+	if r.Target == TargetAll && len(r.TargetIDs) > 0 {
+		return fmt.Errorf("%w: target_ids must be empty for store-wide promotions", ErrInvalidPromotion)
+	}
+	if r.Target != TargetAll && len(r.TargetIDs) == 0 {
+		return fmt.Errorf("%w: target_ids are required for %s promotions", ErrInvalidPromotion, r.Target)
+	}
+
+	if r.MinOrderAmount != nil && *r.MinOrderAmount < 0 {
+		return fmt.Errorf("%w: min_order_amount must not be negative", ErrInvalidPromotion)
+	}
+	if r.UsageLimit != nil && *r.UsageLimit <= 0 {
+		return fmt.Errorf("%w: usage_limit must be positive", ErrInvalidPromotion)
+	}
+	if r.StartsAt != nil && r.EndsAt != nil && !r.EndsAt.After(*r.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidPromotion)
+	}
+	if r.StartsAt != nil {
+		startsAt := r.StartsAt.UTC()
+		r.StartsAt = &startsAt
+	}
+	if r.EndsAt != nil {
+		endsAt := r.EndsAt.UTC()
+		r.EndsAt = &endsAt
+	}
+
+	r.CouponCode = NormalizeCouponCode(r.CouponCode)
+	return nil
+}
+
+// NormalizeCouponCode makes coupon codes case-insensitive.
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+type Promotion struct {
+	ID             int             `json:"id"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description,omitempty"`
+	DiscountType   DiscountType    `json:"discount_type"`
+	DiscountValue  Money           `json:"discount_value" swaggertype:"string" example:"20.00"`
+	Target         PromotionTarget `json:"target"`
+	TargetIDs      []int           `json:"target_ids,omitempty"`
+	MinOrderAmount *Money          `json:"min_order_amount,omitempty" swaggertype:"string" example:"3000.00"`
+	CouponCode     string          `json:"coupon_code,omitempty"`
+	UsageLimit     *int            `json:"usage_limit,omitempty"`
+	UsageCount     int             `json:"usage_count"`
+	StartsAt       *time.Time      `json:"starts_at,omitempty"`
+	EndsAt         *time.Time      `json:"ends_at,omitempty"`
+	Stackable      bool            `json:"stackable"`
+	Priority       int             `json:"priority"`
+	IsActive       bool            `json:"is_active"`
+	CreatedAt      *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time      `json:"updated_at,omitempty"`
+}
+
+// Unavailable explains why the promotion cannot be used at now, or returns
+// an empty string when it can.
+func (p *Promotion) Unavailable(now time.Time) string {
+	switch {
+	case !p.IsActive:
+		return "promotion is not active"
+	case p.StartsAt != nil && now.Before(*p.StartsAt):
+		return "promotion has not started yet"
+	case p.EndsAt != nil && !now.Before(*p.EndsAt):
+		return "promotion has ended"
+	case p.UsageLimit != nil && p.UsageCount >= *p.UsageLimit:
+		return "usage limit reached"
+	}
+	return ""
+}
+
+// PricingRequest prices a cart with the automatic promotions and the given
+// coupon codes. Exactly one of UserID and CartToken selects the cart.
+type PricingRequest struct {
+	UserID      *int     `json:"user_id,omitempty"`
+	CartToken   string   `json:"cart_token,omitempty"`
+	CouponCodes []string `json:"coupon_codes,omitempty"`
+}
+
+func (r *PricingRequest) Validate() error {
+	if (r.UserID == nil) == (r.CartToken == "") {
+		return fmt.Errorf("%w: exactly one of user_id and cart_token is required", ErrInvalidPromotion)
+	}
+	return nil
+}
+
+type LineDiscount struct {
+	PromotionID int   `json:"promotion_id"`
+	Amount      Money `json:"amount" swaggertype:"string" example:"260.00"`
+}
+
+type PricedLine struct {
+	ItemID    int            `json:"item_id"`
+	ProductID int            `json:"product_id"`
+	VariantID *int           `json:"variant_id,omitempty"`
+	Quantity  int            `json:"quantity"`
+	UnitPrice Money          `json:"unit_price" swaggertype:"string" example:"1299.99"`
+	Subtotal  Money          `json:"subtotal" swaggertype:"string" example:"2599.98"`
+	Discount  Money          `json:"discount" swaggertype:"string" example:"260.00"`
+	Total     Money          `json:"total" swaggertype:"string" example:"2339.98"`
+	Discounts []LineDiscount `json:"discounts,omitempty"`
+}
+
+type AppliedPromotion struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	CouponCode string `json:"coupon_code,omitempty"`
+	Amount     Money  `json:"amount" swaggertype:"string" example:"260.00"`
+}
+
+type RejectedCoupon struct {
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+// PricingResult is a cart priced with promotions. Lines follow the cart
+// order and their discounts add up to Discount.
+type PricingResult struct {
+	Lines             []PricedLine       `json:"lines"`
+	Subtotal          Money              `json:"subtotal" swaggertype:"string" example:"2599.98"`
+	Discount          Money              `json:"discount" swaggertype:"string" example:"260.00"`
+	Total             Money              `json:"total" swaggertype:"string" example:"2339.98"`
+	Currency          string             `json:"currency"`
+	AppliedPromotions []AppliedPromotion `json:"applied_promotions,omitempty"`
+	RejectedCoupons   []RejectedCoupon   `json:"rejected_coupons,omitempty"`
+}
@@ -0,0 +1,87 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidQuestion = errors.New("invalid question")
+
+type QuestionRequest struct {
+	UserID int    `json:"user_id"`
+	Body   string `json:"body" example:"Is this fragrance-free?"`
+}
+
+func (r *QuestionRequest) Validate() error {
+	if r.UserID <= 0 {
+		return fmt.Errorf("%w: user_id is required", ErrInvalidQuestion)
+	}
+	r.Body = strings.TrimSpace(r.Body)
+	if r.Body == "" {
+		return fmt.Errorf("%w: body is required", ErrInvalidQuestion)
+	}
+	return nil
+}
+
+// AnswerRequest answers a question. Staff answers skip the moderation
+// queue.
+type AnswerRequest struct {
+	UserID  int    `json:"user_id"`
+	IsStaff bool   `json:"is_staff,omitempty"`
+	Body    string `json:"body" example:"Yes, it contains no added fragrance or essential oils."`
+}
+
+func (r *AnswerRequest) Validate() error {
+	if r.UserID <= 0 {
+		return fmt.Errorf("%w: user_id is required", ErrInvalidQuestion)
+	}
+	r.Body = strings.TrimSpace(r.Body)
+	if r.Body == "" {
+		return fmt.Errorf("%w: body is required", ErrInvalidQuestion)
+	}
+	return nil
+}
+
+type UpvoteRequest struct {
+	UserID int `json:"user_id"`
+}
+
+// Question is a customer question. Answers holds only the approved answers
+// on public listings, staff answers first and then the most upvoted.
+type Question struct {
+	ID             int              `json:"id"`
+	ProductID      int              `json:"product_id"`
+	UserID         int              `json:"user_id"`
+	Body           string           `json:"body"`
+	Status         ModerationStatus `json:"status"`
+	ModerationNote string           `json:"moderation_note,omitempty"`
+	ModeratedAt    *time.Time       `json:"moderated_at,omitempty"`
+	Answers        []Answer         `json:"answers,omitempty"`
+	CreatedAt      *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time       `json:"updated_at,omitempty"`
+}
+
+type Answer struct {
+	ID             int              `json:"id"`
+	QuestionID     int              `json:"question_id"`
+	UserID         int              `json:"user_id"`
+	IsStaff        bool             `json:"is_staff"`
+	Body           string           `json:"body"`
+	Status         ModerationStatus `json:"status"`
+	Upvotes        int              `json:"upvotes"`
+	ModerationNote string           `json:"moderation_note,omitempty"`
+	ModeratedAt    *time.Time       `json:"moderated_at,omitempty"`
+	CreatedAt      *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time       `json:"updated_at,omitempty"`
+}
+
+// QuestionFilter selects questions or answers for listing. Zero values are
+// ignored.
+type QuestionFilter struct {
+	ProductID int
+	Status    ModerationStatus
+	Limit     int
+	Offset    int
+}
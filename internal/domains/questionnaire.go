@@ -0,0 +1,164 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+var ErrInvalidQuestionnaire = errors.New("invalid questionnaire")
+
+// Concern is a skin problem a product addresses.
+type Concern string
+
+const (
+	ConcernAcne         Concern = "acne"
+	ConcernPigmentation Concern = "pigmentation"
+	ConcernSensitivity  Concern = "sensitivity"
+	ConcernAging        Concern = "aging"
+	ConcernDryness      Concern = "dryness"
+	ConcernOiliness     Concern = "oiliness"
+)
+
+// Concerns lists every known concern.
+var Concerns = []Concern{
+	ConcernAcne, ConcernPigmentation, ConcernSensitivity, ConcernAging, ConcernDryness, ConcernOiliness,
+}
+
+func (c Concern) Valid() bool {
+	return slices.Contains(Concerns, c)
+}
+
+type SkinTypeWeight struct {
+	SkinTypeID int     `json:"skin_type_id"`
+	Weight     float64 `json:"weight" example:"1"`
+}
+
+type ConcernWeight struct {
+	Concern Concern `json:"concern" example:"acne"`
+	Weight  float64 `json:"weight" example:"1"`
+}
+
+// QuestionnaireOptionRequest is an answer to a question and the weight it
+// adds to skin types and concerns when chosen.
+type QuestionnaireOptionRequest struct {
+	Text      string           `json:"text" example:"Shiny by midday"`
+	SkinTypes []SkinTypeWeight `json:"skin_types,omitempty"`
+	Concerns  []ConcernWeight  `json:"concerns,omitempty"`
+}
+
+// QuestionnaireQuestionRequest creates or replaces a question together with
+// its options, which are shown in the order given. Replacing a question
+// gives its options new ids.
+type QuestionnaireQuestionRequest struct {
+	Text           string                       `json:"text" example:"How does your skin feel by midday?"`
+	Position       int                          `json:"position"`
+	MultipleChoice bool                         `json:"multiple_choice"`
+	Options        []QuestionnaireOptionRequest `json:"options"`
+}
+
+func (r *QuestionnaireQuestionRequest) Validate() error {
+	r.Text = strings.TrimSpace(r.Text)
+	if r.Text == "" {
+		return fmt.Errorf("%w: text is required", ErrInvalidQuestionnaire)
+	}
+	if r.Position < 0 {
+		return fmt.Errorf("%w: position must not be negative", ErrInvalidQuestionnaire)
+	}
+	if len(r.Options) < 2 {
+		return fmt.Errorf("%w: a question needs at least two options", ErrInvalidQuestionnaire)
+	}
+	for i := range r.Options {
+		if err := r.Options[i].validate(); err != nil {
+			return fmt.Errorf("%w (option %d)", err, i+1)
+		}
+	}
+	return nil
+}
+
+func (r *QuestionnaireOptionRequest) validate() error {
+	r.Text = strings.TrimSpace(r.Text)
+	if r.Text == "" {
+		return fmt.Errorf("%w: option text is required", ErrInvalidQuestionnaire)
+	}
+	skinTypes := make(map[int]bool, len(r.SkinTypes))
+	for _, w := range r.SkinTypes {
+		if w.SkinTypeID <= 0 || skinTypes[w.SkinTypeID] {
+			return fmt.Errorf("%w: invalid or repeated skin_type_id %d", ErrInvalidQuestionnaire, w.SkinTypeID)
+		}
+		if !validWeight(w.Weight) {
+			return fmt.Errorf("%w: weights must be positive", ErrInvalidQuestionnaire)
+		}
+		skinTypes[w.SkinTypeID] = true
+	}
+	concerns := make(map[Concern]bool, len(r.Concerns))
+	for _, w := range r.Concerns {
+		if !w.Concern.Valid() || concerns[w.Concern] {
+			return fmt.Errorf("%w: invalid or repeated concern %q", ErrInvalidQuestionnaire, w.Concern)
+		}
+		if !validWeight(w.Weight) {
+			return fmt.Errorf("%w: weights must be positive", ErrInvalidQuestionnaire)
+		}
+		concerns[w.Concern] = true
+	}
+	return nil
+}
+
+func validWeight(w float64) bool {
+	return w > 0 && !math.IsInf(w, 1)
+}
+
+type QuestionnaireOption struct {
+	ID        int              `json:"id"`
+	Text      string           `json:"text"`
+	SkinTypes []SkinTypeWeight `json:"skin_types,omitempty"`
+	Concerns  []ConcernWeight  `json:"concerns,omitempty"`
+}
+
+type QuestionnaireQuestion struct {
+	ID             int                   `json:"id"`
+	Text           string                `json:"text"`
+	Position       int                   `json:"position"`
+	MultipleChoice bool                  `json:"multiple_choice"`
+	Options        []QuestionnaireOption `json:"options"`
+	CreatedAt      *time.Time            `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time            `json:"updated_at,omitempty"`
+}
+
+type QuestionnaireAnswer struct {
+	QuestionID int   `json:"question_id"`
+	OptionIDs  []int `json:"option_ids"`
+}
+
+// QuestionnaireAnswers holds the options a customer chose. Questions may be
+// skipped.
+type QuestionnaireAnswers struct {
+	Answers []QuestionnaireAnswer `json:"answers"`
+}
+
+func (a *QuestionnaireAnswers) Validate() error {
+	if len(a.Answers) == 0 {
+		return fmt.Errorf("%w: answers are required", ErrInvalidQuestionnaire)
+	}
+	questions := make(map[int]bool, len(a.Answers))
+	for _, answer := range a.Answers {
+		if answer.QuestionID <= 0 || questions[answer.QuestionID] {
+			return fmt.Errorf("%w: invalid or repeated question_id %d", ErrInvalidQuestionnaire, answer.QuestionID)
+		}
+		questions[answer.QuestionID] = true
+		if len(answer.OptionIDs) == 0 {
+			return fmt.Errorf("%w: question %d has no options chosen", ErrInvalidQuestionnaire, answer.QuestionID)
+		}
+		options := make(map[int]bool, len(answer.OptionIDs))
+		for _, id := range answer.OptionIDs {
+			if id <= 0 || options[id] {
+				return fmt.Errorf("%w: invalid or repeated option_id %d", ErrInvalidQuestionnaire, id)
+			}
+			options[id] = true
+		}
+	}
+	return nil
+}
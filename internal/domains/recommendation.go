@@ -0,0 +1,106 @@
+package domains
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidRecommendation = errors.New("invalid recommendation request")
+
+// SkinProfile weighs skin types and concerns. Normalized profiles have the
+// weights of each list sum to 1 and carry a Hash identifying them.
+type SkinProfile struct {
+	SkinTypes []SkinTypeWeight `json:"skin_types"`
+	Concerns  []ConcernWeight  `json:"concerns"`
+	Hash      string           `json:"hash" example:"3f1c9a0b7d2e4c58"`
+}
+
+// Normalize merges repeated entries, drops those without weight, scales
+// each list to sum to 1, sorts them by weight and sets Hash. Weights are
+// rounded so profiles that differ only by float noise share a hash.
+func (p *SkinProfile) Normalize() error {
+	skinTypes := make(map[int]float64)
+	for _, w := range p.SkinTypes {
+		if w.SkinTypeID <= 0 {
+			return fmt.Errorf("%w: invalid skin type id %d", ErrInvalidRecommendation, w.SkinTypeID)
+		}
+		if validWeight(w.Weight) {
+			skinTypes[w.SkinTypeID] += w.Weight
+		}
+	}
+	concerns := make(map[Concern]float64)
+	for _, w := range p.Concerns {
+		if !w.Concern.Valid() {
+			return fmt.Errorf("%w: unknown concern %q", ErrInvalidRecommendation, w.Concern)
+		}
+		if validWeight(w.Weight) {
+			concerns[w.Concern] += w.Weight
+		}
+	}
+	if len(skinTypes) == 0 && len(concerns) == 0 {
+		return fmt.Errorf("%w: the profile needs a skin type or a concern", ErrInvalidRecommendation)
+	}
+
+	p.SkinTypes = p.SkinTypes[:0]
+	for id, weight := range normalizeWeights(skinTypes) {
+		p.SkinTypes = append(p.SkinTypes, SkinTypeWeight{SkinTypeID: id, Weight: weight})
+	}
+	slices.SortFunc(p.SkinTypes, func(a, b SkinTypeWeight) int {
+		return cmp.Or(cmp.Compare(b.Weight, a.Weight), cmp.Compare(a.SkinTypeID, b.SkinTypeID))
+	})
+	p.Concerns = p.Concerns[:0]
+	for concern, weight := range normalizeWeights(concerns) {
+		p.Concerns = append(p.Concerns, ConcernWeight{Concern: concern, Weight: weight})
+	}
+	slices.SortFunc(p.Concerns, func(a, b ConcernWeight) int {
+		return cmp.Or(cmp.Compare(b.Weight, a.Weight), cmp.Compare(a.Concern, b.Concern))
+	})
+
+	var key strings.Builder
+	for _, w := range p.SkinTypes {
+		fmt.Fprintf(&key, "s%d=%.4f;", w.SkinTypeID, w.Weight)
+	}
+	for _, w := range p.Concerns {
+		fmt.Fprintf(&key, "c%s=%.4f;", w.Concern, w.Weight)
+	}
+	sum := sha256.Sum256([]byte(key.String()))
+	p.Hash = hex.EncodeToString(sum[:8])
+	return nil
+}
+
+func normalizeWeights[K comparable](weights map[K]float64) map[K]float64 {
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	for k, w := range weights {
+		weights[k] = math.Round(w/total*1e4) / 1e4
+	}
+	return weights
+}
+
+// Recommendation is a product ranked for a skin profile. MatchScore and
+// RatingScore run from 0 to 1; Score combines them and is lowered for each
+// better ranked product of the same brand.
+type Recommendation struct {
+	Product          ProductResponse `json:"product"`
+	Score            float64         `json:"score" example:"0.82"`
+	MatchScore       float64         `json:"match_score" example:"0.9"`
+	RatingScore      float64         `json:"rating_score" example:"0.75"`
+	MatchedSkinTypes []int           `json:"matched_skin_type_ids,omitempty"`
+	MatchedConcerns  []Concern       `json:"matched_concerns,omitempty"`
+}
+
+// RecommendationCandidate is a product that matches a profile, with what
+// ranking needs to know about it.
+type RecommendationCandidate struct {
+	Product     ProductResponse
+	SkinTypeIDs []int
+	Concerns    []Concern
+}
@@ -0,0 +1,63 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidReservation  = errors.New("invalid stock reservation")
+	ErrReservationInactive = errors.New("reservation is no longer active")
+)
+
+type ReservationStatus string
+
+const (
+	ReservationActive   ReservationStatus = "active"
+	ReservationReleased ReservationStatus = "released"
+	ReservationExpired  ReservationStatus = "expired"
+)
+
+// ReservationRequest holds quantity of a product or variant for TTLSeconds,
+// or for the configured default when it is zero.
+type ReservationRequest struct {
+	ProductID  int  `json:"product_id"`
+	VariantID  *int `json:"variant_id,omitempty"`
+	Quantity   int  `json:"quantity"`
+	TTLSeconds int  `json:"ttl_seconds,omitempty" example:"900"`
+}
+
+func (r *ReservationRequest) Validate() error {
+	if r.ProductID <= 0 {
+		return fmt.Errorf("%w: product_id is required", ErrInvalidReservation)
+	}
+	if r.Quantity <= 0 {
+		return fmt.Errorf("%w: quantity must be positive", ErrInvalidReservation)
+	}
+	if r.TTLSeconds < 0 {
+		return fmt.Errorf("%w: ttl_seconds must not be negative", ErrInvalidReservation)
+	}
+	return nil
+}
+
+type Reservation struct {
+	ID         int               `json:"id"`
+	ProductID  int               `json:"product_id"`
+	VariantID  *int              `json:"variant_id,omitempty"`
+	Quantity   int               `json:"quantity"`
+	Status     ReservationStatus `json:"status"`
+	ExpiresAt  time.Time         `json:"expires_at"`
+	CreatedAt  *time.Time        `json:"created_at,omitempty"`
+	ReleasedAt *time.Time        `json:"released_at,omitempty"`
+}
+
+// Availability is the available-to-sell quantity: stock on hand in active
+// warehouses minus unexpired active reservations.
+type Availability struct {
+	ProductID int  `json:"product_id"`
+	VariantID *int `json:"variant_id,omitempty"`
+	OnHand    int  `json:"on_hand"`
+	Reserved  int  `json:"reserved"`
+	Available int  `json:"available"`
+}
@@ -0,0 +1,107 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidReturn = errors.New("invalid return request")
+	ErrReturnState   = errors.New("operation not allowed in the current return state")
+)
+
+type ReturnStatus string
+
+const (
+	ReturnRequested ReturnStatus = "requested"
+	ReturnApproved  ReturnStatus = "approved"
+	ReturnRejected  ReturnStatus = "rejected"
+	ReturnRefunding ReturnStatus = "refunding"
+	ReturnRefunded  ReturnStatus = "refunded"
+)
+
+type ReturnReason string
+
+const (
+	ReturnReasonDamaged          ReturnReason = "damaged"
+	ReturnReasonWrongItem        ReturnReason = "wrong_item"
+	ReturnReasonAllergicReaction ReturnReason = "allergic_reaction"
+	ReturnReasonNotAsDescribed   ReturnReason = "not_as_described"
+	ReturnReasonChangedMind      ReturnReason = "changed_mind"
+	ReturnReasonOther            ReturnReason = "other"
+)
+
+func (r ReturnReason) Valid() bool {
+	switch r {
+	case ReturnReasonDamaged, ReturnReasonWrongItem, ReturnReasonAllergicReaction,
+		ReturnReasonNotAsDescribed, ReturnReasonChangedMind, ReturnReasonOther:
+		return true
+	default:
+		return false
+	}
+}
+
+type CreateReturnRequest struct {
+	OrderItemID int          `json:"order_item_id"`
+	Quantity    int          `json:"quantity"`
+	Reason      ReturnReason `json:"reason" example:"damaged"`
+	Comment     string       `json:"comment,omitempty"`
+}
+
+func (r *CreateReturnRequest) Validate() error {
+	if r.OrderItemID <= 0 {
+		return fmt.Errorf("%w: order_item_id is required", ErrInvalidReturn)
+	}
+	if r.Quantity <= 0 {
+		return fmt.Errorf("%w: quantity must be positive", ErrInvalidReturn)
+	}
+	if !r.Reason.Valid() {
+		return fmt.Errorf("%w: unknown reason %q", ErrInvalidReturn, r.Reason)
+	}
+	if r.Reason == ReturnReasonOther && r.Comment == "" {
+		return fmt.Errorf("%w: comment is required for reason %q", ErrInvalidReturn, r.Reason)
+	}
+	return nil
+}
+
+// ReturnApprovalRequest approves a return. With Restock the goods are put
+// back into WarehouseID, or the highest-priority active warehouse.
+type ReturnApprovalRequest struct {
+	Restock     bool   `json:"restock"`
+	WarehouseID *int   `json:"warehouse_id,omitempty"`
+	Note        string `json:"note,omitempty"`
+}
+
+type ReturnRejectionRequest struct {
+	Note string `json:"note"`
+}
+
+// ReturnRefundRequest refunds Amount, or the full value of the returned
+// quantity when Amount is omitted.
+type ReturnRefundRequest struct {
+	Amount *Money `json:"amount,omitempty" swaggertype:"string" example:"1299.99"`
+}
+
+type ReturnStatusChange struct {
+	FromStatus ReturnStatus `json:"from_status,omitempty"`
+	ToStatus   ReturnStatus `json:"to_status"`
+	Note       string       `json:"note,omitempty"`
+	CreatedAt  *time.Time   `json:"created_at,omitempty"`
+}
+
+type Return struct {
+	ID           int                  `json:"id"`
+	OrderID      int                  `json:"order_id"`
+	OrderItemID  int                  `json:"order_item_id"`
+	Quantity     int                  `json:"quantity"`
+	Reason       ReturnReason         `json:"reason"`
+	Comment      string               `json:"comment,omitempty"`
+	Status       ReturnStatus         `json:"status"`
+	Restocked    bool                 `json:"restocked"`
+	RefundAmount *Money               `json:"refund_amount,omitempty" swaggertype:"string" example:"1299.99"`
+	PaymentID    *int                 `json:"payment_id,omitempty"`
+	History      []ReturnStatusChange `json:"history,omitempty"`
+	CreatedAt    *time.Time           `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time           `json:"updated_at,omitempty"`
+}
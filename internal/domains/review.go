@@ -0,0 +1,70 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var ErrInvalidReview = errors.New("invalid review")
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+type ReviewRequest struct {
+	UserID     int    `json:"user_id"`
+	SkinTypeID *int   `json:"skin_type_id,omitempty"`
+	Rating     int    `json:"rating" example:"5"`
+	Title      string `json:"title,omitempty" example:"Finally a cream that does not clog pores"`
+	Body       string `json:"body,omitempty"`
+}
+
+func (r *ReviewRequest) Validate() error {
+	if r.UserID <= 0 {
+		return fmt.Errorf("%w: user_id is required", ErrInvalidReview)
+	}
+	if r.Rating < MinRating || r.Rating > MaxRating {
+		return fmt.Errorf("%w: rating must be between %d and %d", ErrInvalidReview, MinRating, MaxRating)
+	}
+	r.Title = strings.TrimSpace(r.Title)
+	r.Body = strings.TrimSpace(r.Body)
+	if utf8.RuneCountInString(r.Title) > 255 {
+		return fmt.Errorf("%w: title must be at most 255 characters", ErrInvalidReview)
+	}
+	return nil
+}
+
+type Review struct {
+	ID             int              `json:"id"`
+	ProductID      int              `json:"product_id"`
+	UserID         int              `json:"user_id"`
+	SkinType       *SkinType        `json:"skin_type,omitempty"`
+	Rating         int              `json:"rating"`
+	Title          string           `json:"title,omitempty"`
+	Body           string           `json:"body,omitempty"`
+	Status         ModerationStatus `json:"status"`
+	ModerationNote string           `json:"moderation_note,omitempty"`
+	ModeratedAt    *time.Time       `json:"moderated_at,omitempty"`
+	CreatedAt      *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time       `json:"updated_at,omitempty"`
+}
+
+// ReviewFilter selects reviews for listing. Zero values are ignored.
+type ReviewFilter struct {
+	ProductID  int
+	SkinTypeID int
+	Status     ModerationStatus
+}
+
+// SkinTypeRating is the rating of a product among reviewers of one skin
+// type.
+type SkinTypeRating struct {
+	SkinTypeID   int     `json:"skin_type_id"`
+	SkinTypeName string  `json:"skin_type_name"`
+	Average      float64 `json:"average" example:"4.5"`
+	Count        int     `json:"count"`
+}
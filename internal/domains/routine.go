@@ -0,0 +1,140 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRoutine = errors.New("invalid routine")
+
+// MaxRoutineProducts caps how many products a single routine check accepts.
+const MaxRoutineProducts = 20
+
+// ConflictSeverity grades how harmful combining two ingredients is.
+type ConflictSeverity string
+
+const (
+	SeverityLow    ConflictSeverity = "low"
+	SeverityMedium ConflictSeverity = "medium"
+	SeverityHigh   ConflictSeverity = "high"
+)
+
+func (s ConflictSeverity) Valid() bool {
+	return s.Rank() > 0
+}
+
+// Rank orders severities from low (1) to high (3); unknown values rank 0.
+func (s ConflictSeverity) Rank() int {
+	switch s {
+	case SeverityLow:
+		return 1
+	case SeverityMedium:
+		return 2
+	case SeverityHigh:
+		return 3
+	}
+	return 0
+}
+
+// IngredientConflictRequest creates or replaces a conflict rule between two
+// ingredients. The pair is unordered.
+type IngredientConflictRequest struct {
+	IngredientID            int              `json:"ingredient_id"`
+	ConflictingIngredientID int              `json:"conflicting_ingredient_id"`
+	Severity                ConflictSeverity `json:"severity" example:"high"`
+	Explanation             string           `json:"explanation" example:"Both exfoliate and together irritate the skin barrier."`
+}
+
+// Validate checks the request and puts the lower ingredient id first, the
+// way pairs are stored.
+func (r *IngredientConflictRequest) Validate() error {
+	if r.IngredientID <= 0 || r.ConflictingIngredientID <= 0 {
+		return fmt.Errorf("%w: ingredient_id and conflicting_ingredient_id are required", ErrInvalidRoutine)
+	}
+	if r.IngredientID == r.ConflictingIngredientID {
+		return fmt.Errorf("%w: an ingredient cannot conflict with itself", ErrInvalidRoutine)
+	}
+	if !r.Severity.Valid() {
+		return fmt.Errorf("%w: severity must be %s, %s or %s", ErrInvalidRoutine, SeverityLow, SeverityMedium, SeverityHigh)
+	}
+	r.Explanation = strings.TrimSpace(r.Explanation)
+	if r.Explanation == "" {
+		return fmt.Errorf("%w: explanation is required", ErrInvalidRoutine)
+	}
+	if r.IngredientID > r.ConflictingIngredientID {
+		r.IngredientID, r.ConflictingIngredientID = r.ConflictingIngredientID, r.IngredientID
+	}
+	return nil
+}
+
+type IngredientConflict struct {
+	ID                        int              `json:"id"`
+	IngredientID              int              `json:"ingredient_id"`
+	IngredientName            string           `json:"ingredient_name"`
+	ConflictingIngredientID   int              `json:"conflicting_ingredient_id"`
+	ConflictingIngredientName string           `json:"conflicting_ingredient_name"`
+	Severity                  ConflictSeverity `json:"severity"`
+	Explanation               string           `json:"explanation"`
+	CreatedAt                 *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt                 *time.Time       `json:"updated_at,omitempty"`
+}
+
+type IngredientConflictFilter struct {
+	IngredientID int
+}
+
+// RoutineCheckRequest lists the products a customer uses together.
+type RoutineCheckRequest struct {
+	ProductIDs []int `json:"product_ids"`
+}
+
+func (r *RoutineCheckRequest) Validate() error {
+	if len(r.ProductIDs) == 0 {
+		return fmt.Errorf("%w: product_ids is required", ErrInvalidRoutine)
+	}
+	if len(r.ProductIDs) > MaxRoutineProducts {
+		return fmt.Errorf("%w: at most %d products can be checked at once", ErrInvalidRoutine, MaxRoutineProducts)
+	}
+	seen := make(map[int]bool, len(r.ProductIDs))
+	for _, id := range r.ProductIDs {
+		if id <= 0 {
+			return fmt.Errorf("%w: invalid product id %d", ErrInvalidRoutine, id)
+		}
+		if seen[id] {
+			return fmt.Errorf("%w: duplicate product id %d", ErrInvalidRoutine, id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+type RoutineProduct struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// RoutineConflict is a conflict rule matched by two products of a routine,
+// or by a single product when ConflictingProductID equals ProductID.
+// Separable is false when the AM/PM schedule cannot keep the pair apart.
+type RoutineConflict struct {
+	ProductID              int              `json:"product_id"`
+	ProductName            string           `json:"product_name"`
+	Ingredient             string           `json:"ingredient"`
+	ConflictingProductID   int              `json:"conflicting_product_id"`
+	ConflictingProductName string           `json:"conflicting_product_name"`
+	ConflictingIngredient  string           `json:"conflicting_ingredient"`
+	Severity               ConflictSeverity `json:"severity"`
+	Explanation            string           `json:"explanation"`
+	Separable              bool             `json:"separable"`
+}
+
+// RoutineCheck reports the conflicts of a routine and suggests which
+// products to use in the morning and which in the evening. Products without
+// conflicts appear in both.
+type RoutineCheck struct {
+	Conflicts []RoutineConflict `json:"conflicts"`
+	AM        []RoutineProduct  `json:"am"`
+	PM        []RoutineProduct  `json:"pm"`
+}
@@ -0,0 +1,261 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var ErrInvalidShipping = errors.New("invalid shipping settings")
+
+type ShippingMethodType string
+
+const (
+	ShippingFlat   ShippingMethodType = "flat"
+	ShippingWeight ShippingMethodType = "weight"
+	ShippingFree   ShippingMethodType = "free"
+	ShippingPickup ShippingMethodType = "pickup"
+)
+
+func (t ShippingMethodType) Valid() bool {
+	switch t {
+	case ShippingFlat, ShippingWeight, ShippingFree, ShippingPickup:
+		return true
+	}
+	return false
+}
+
+// NormalizePostalCode upper-cases a postal code and drops spaces and dashes
+// so "sw1a 1aa" matches the prefix "SW1A".
+func NormalizePostalCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
+
+type ShippingZoneRequest struct {
+	Name           string   `json:"name" example:"Moscow"`
+	Countries      []string `json:"countries" example:"RU"`
+	PostalPrefixes []string `json:"postal_prefixes,omitempty" example:"101,102"`
+	Priority       int      `json:"priority"`
+}
+
+// Validate checks the request and normalizes the countries and prefixes.
+func (r *ShippingZoneRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidShipping)
+	}
+	if len(r.Countries) == 0 {
+		return fmt.Errorf("%w: at least one country is required", ErrInvalidShipping)
+	}
+	for i, country := range r.Countries {
+		code, err := NormalizeCountry(country)
+		if err != nil {
+			return fmt.Errorf("%w: %q is not an ISO 3166-1 country code", ErrInvalidShipping, country)
+		}
+		r.Countries[i] = code
+	}
+	for i, prefix := range r.PostalPrefixes {
+		r.PostalPrefixes[i] = NormalizePostalCode(prefix)
+		if r.PostalPrefixes[i] == "" {
+			return fmt.Errorf("%w: postal prefixes must not be empty", ErrInvalidShipping)
+		}
+	}
+	return nil
+}
+
+type ShippingZone struct {
+	ID             int        `json:"id"`
+	Name           string     `json:"name"`
+	Countries      []string   `json:"countries"`
+	PostalPrefixes []string   `json:"postal_prefixes,omitempty"`
+	Priority       int        `json:"priority"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+// Matches reports whether the address lies in the zone.
+func (z *ShippingZone) Matches(address *ShippingAddress) bool {
+	if !slices.Contains(z.Countries, address.Country) {
+		return false
+	}
+	if len(z.PostalPrefixes) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(z.PostalPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(address.PostalCode, prefix)
+	})
+}
+
+// WeightTier prices parcels up to MaxWeightGrams.
+type WeightTier struct {
+	MaxWeightGrams float64 `json:"max_weight_grams" example:"1000"`
+	Price          Money   `json:"price" swaggertype:"string" example:"350.00"`
+}
+
+// ShippingMethodRequest creates or replaces a shipping method. Flat and
+// pickup methods cost Price, weight methods the first tier that fits the
+// parcel, and free methods nothing once the cart subtotal reaches
+// Threshold.
+type ShippingMethodRequest struct {
+	ZoneID        int                `json:"zone_id"`
+	Name          string             `json:"name" example:"Courier"`
+	Type          ShippingMethodType `json:"type" example:"flat"`
+	Price         Money              `json:"price" swaggertype:"string" example:"350.00"`
+	Threshold     *Money             `json:"threshold,omitempty" swaggertype:"string" example:"5000.00"`
+	WeightTiers   []WeightTier       `json:"weight_tiers,omitempty"`
+	PickupAddress string             `json:"pickup_address,omitempty"`
+	EstimatedDays *int               `json:"estimated_days,omitempty"`
+	IsActive      *bool              `json:"is_active,omitempty"`
+}
+
+// Validate checks the settings the method type needs and sorts the weight
+// tiers.
+func (r *ShippingMethodRequest) Validate() error {
+	if r.ZoneID <= 0 {
+		return fmt.Errorf("%w: zone_id is required", ErrInvalidShipping)
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidShipping)
+	}
+	if r.Price < 0 || r.Price > MaxPrice {
+		return fmt.Errorf("%w: price must be between 0 and %s", ErrInvalidShipping, MaxPrice)
+	}
+	if r.EstimatedDays != nil && *r.EstimatedDays < 0 {
+		return fmt.Errorf("%w: estimated_days must not be negative", ErrInvalidShipping)
+	}
+
+	switch r.Type {
+	case ShippingFlat:
+	case ShippingWeight:
+		if len(r.WeightTiers) == 0 {
+			return fmt.Errorf("%w: weight methods need at least one weight tier", ErrInvalidShipping)
+		}
+		slices.SortFunc(r.WeightTiers, func(a, b WeightTier) int {
+			switch {
+			case a.MaxWeightGrams < b.MaxWeightGrams:
+				return -1
+			case a.MaxWeightGrams > b.MaxWeightGrams:
+				return 1
+			}
+			return 0
+		})
+		for i, tier := range r.WeightTiers {
+			if tier.MaxWeightGrams <= 0 || tier.Price < 0 || tier.Price > MaxPrice {
+				return fmt.Errorf("%w: weight tiers need a positive max_weight_grams and a valid price", ErrInvalidShipping)
+			}
+			if i > 0 && tier.MaxWeightGrams == r.WeightTiers[i-1].MaxWeightGrams {
+				return fmt.Errorf("%w: weight tiers must not repeat max_weight_grams", ErrInvalidShipping)
+			}
+		}
+	case ShippingFree:
+		if r.Threshold == nil || *r.Threshold <= 0 {
+			return fmt.Errorf("%w: free methods need a positive threshold", ErrInvalidShipping)
+		}
+		if r.Price != 0 {
+			return fmt.Errorf("%w: free methods must not have a price", ErrInvalidShipping)
+		}
+	case ShippingPickup:
+		if strings.TrimSpace(r.PickupAddress) == "" {
+			return fmt.Errorf("%w: pickup methods need a pickup_address", ErrInvalidShipping)
+		}
+	default:
+		return fmt.Errorf("%w: unknown method type %q", ErrInvalidShipping, r.Type)
+	}
+
+	if r.Type != ShippingWeight && len(r.WeightTiers) > 0 {
+		return fmt.Errorf("%w: weight_tiers are only allowed for weight methods", ErrInvalidShipping)
+	}
+	if r.Type != ShippingFree && r.Threshold != nil {
+		return fmt.Errorf("%w: threshold is only allowed for free methods", ErrInvalidShipping)
+	}
+	return nil
+}
+
+type ShippingMethod struct {
+	ID            int                `json:"id"`
+	ZoneID        int                `json:"zone_id"`
+	Name          string             `json:"name"`
+	Type          ShippingMethodType `json:"type"`
+	Price         Money              `json:"price" swaggertype:"string" example:"350.00"`
+	Threshold     *Money             `json:"threshold,omitempty" swaggertype:"string" example:"5000.00"`
+	WeightTiers   []WeightTier       `json:"weight_tiers,omitempty"`
+	PickupAddress string             `json:"pickup_address,omitempty"`
+	EstimatedDays *int               `json:"estimated_days,omitempty"`
+	IsActive      bool               `json:"is_active"`
+	CreatedAt     *time.Time         `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time         `json:"updated_at,omitempty"`
+}
+
+// Quote prices the method for a parcel. ok is false when the method cannot
+// ship it: the parcel is heavier than every tier or the subtotal is below
+// the free shipping threshold.
+func (m *ShippingMethod) Quote(subtotal Money, weightGrams float64) (price Money, ok bool) {
+	switch m.Type {
+	case ShippingWeight:
+		for _, tier := range m.WeightTiers {
+			if weightGrams <= tier.MaxWeightGrams {
+				return tier.Price, true
+			}
+		}
+		return 0, false
+	case ShippingFree:
+		return 0, m.Threshold != nil && subtotal >= *m.Threshold
+	default:
+		return m.Price, true
+	}
+}
+
+// ShippingMethodFilter selects shipping methods for listing. Zero values are
+// ignored.
+type ShippingMethodFilter struct {
+	ZoneID     int
+	ActiveOnly bool
+}
+
+type ShippingAddress struct {
+	Country    string `json:"country" example:"RU"`
+	PostalCode string `json:"postal_code,omitempty" example:"101000"`
+}
+
+// ShippingQuoteRequest asks for the shipping options of a cart. Exactly one
+// of UserID and CartToken selects the cart.
+type ShippingQuoteRequest struct {
+	UserID    *int            `json:"user_id,omitempty"`
+	CartToken string          `json:"cart_token,omitempty"`
+	Address   ShippingAddress `json:"address"`
+}
+
+// Validate checks the request and normalizes the address.
+func (r *ShippingQuoteRequest) Validate() error {
+	if (r.UserID == nil) == (r.CartToken == "") {
+		return fmt.Errorf("%w: exactly one of user_id and cart_token is required", ErrInvalidShipping)
+	}
+	country, err := NormalizeCountry(r.Address.Country)
+	if err != nil {
+		return fmt.Errorf("%w: %q is not an ISO 3166-1 country code", ErrInvalidShipping, r.Address.Country)
+	}
+	r.Address.Country = country
+	r.Address.PostalCode = NormalizePostalCode(r.Address.PostalCode)
+	return nil
+}
+
+type ShippingOption struct {
+	MethodID      int                `json:"method_id"`
+	Name          string             `json:"name"`
+	Type          ShippingMethodType `json:"type"`
+	Price         Money              `json:"price" swaggertype:"string" example:"350.00"`
+	PickupAddress string             `json:"pickup_address,omitempty"`
+	EstimatedDays *int               `json:"estimated_days,omitempty"`
+}
+
+// ShippingQuote lists the methods that can ship the cart to the address,
+// cheapest first. Options is empty when no zone covers the address.
+type ShippingQuote struct {
+	Address     ShippingAddress  `json:"address"`
+	ZoneID      *int             `json:"zone_id,omitempty"`
+	Subtotal    Money            `json:"subtotal" swaggertype:"string" example:"2599.98"`
+	WeightGrams float64          `json:"weight_grams"`
+	Currency    string           `json:"currency"`
+	Options     []ShippingOption `json:"options"`
+}
@@ -0,0 +1,243 @@
+package domains
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidTax = errors.New("invalid tax settings")
+
+// TaxMode tells whether catalog prices already include tax (inclusive) or
+// tax is added on top of them (exclusive).
+type TaxMode string
+
+const (
+	TaxInclusive TaxMode = "inclusive"
+	TaxExclusive TaxMode = "exclusive"
+)
+
+func (m TaxMode) Valid() bool {
+	return m == TaxInclusive || m == TaxExclusive
+}
+
+// NormalizeCountry upper-cases an ISO 3166-1 alpha-2 code and checks its
+// shape.
+func NormalizeCountry(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 2 || strings.Trim(code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", fmt.Errorf("%w: %q is not an ISO 3166-1 country code", ErrInvalidTax, code)
+	}
+	return code, nil
+}
+
+// NormalizeRegion upper-cases a subdivision code such as "CA" or "MOW".
+func NormalizeRegion(region string) string {
+	return strings.ToUpper(strings.TrimSpace(region))
+}
+
+// TaxClassRequest creates or replaces a tax class. Making a class the
+// default takes the flag away from the previous default.
+type TaxClassRequest struct {
+	Name        string `json:"name" example:"standard"`
+	Description string `json:"description,omitempty"`
+	IsDefault   bool   `json:"is_default"`
+}
+
+func (r *TaxClassRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTax)
+	}
+	return nil
+}
+
+// TaxClass groups products taxed alike. A product uses its own class, then
+// its category's, then the default class; without any it is not taxed.
+type TaxClass struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	IsDefault   bool       `json:"is_default"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// TaxPercent is a tax rate as a percentage with four decimal places, in
+// units of 0.0001%: 200000 is 20% and 88750 is 8.875%. It is written to JSON
+// as a number, read exactly from a JSON number or string, and stored in
+// NUMERIC(7,4) columns.
+type TaxPercent int64
+
+const (
+	// TaxPercentScale is the number of decimal places TaxPercent keeps.
+	TaxPercentScale = 4
+
+	// MaxTaxRate is 100%.
+	MaxTaxRate TaxPercent = 1_000_000
+)
+
+// ParseTaxPercent parses a percentage such as "20" or "8.875". Digits past
+// the fourth decimal place must be zero; rates are never rounded.
+func ParseTaxPercent(s string) (TaxPercent, error) {
+	whole, fraction, _ := strings.Cut(strings.TrimSpace(s), ".")
+	if whole == "" || !isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("%w: %q is not a percentage", ErrInvalidTax, s)
+	}
+	if len(fraction) > TaxPercentScale {
+		if strings.Trim(fraction[TaxPercentScale:], "0") != "" {
+			return 0, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidTax, s, TaxPercentScale)
+		}
+		fraction = fraction[:TaxPercentScale]
+	}
+	fraction += strings.Repeat("0", TaxPercentScale-len(fraction))
+
+	whole = strings.TrimLeft(whole, "0")
+	if len(whole) > 3 {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidTax, s)
+	}
+	units, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidTax, s)
+	}
+	return TaxPercent(units), nil
+}
+
+// String formats the rate without trailing zeros, e.g. "20" or "8.875".
+func (p TaxPercent) String() string {
+	units := int64(p)
+	sign := ""
+	if units < 0 {
+		sign, units = "-", -units
+	}
+	text := fmt.Sprintf("%s%d.%04d", sign, units/10000, units%10000)
+	return strings.TrimSuffix(strings.TrimRight(text, "0"), ".")
+}
+
+func (p TaxPercent) MarshalJSON() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *TaxPercent) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	if strings.ContainsAny(text, "eE") {
+		return fmt.Errorf("%w: %q must not use an exponent", ErrInvalidTax, text)
+	}
+
+	rate, err := ParseTaxPercent(text)
+	if err != nil {
+		return err
+	}
+	*p = rate
+	return nil
+}
+
+// Scan reads a NUMERIC column, which pgx hands over as a decimal string.
+func (p *TaxPercent) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		rate, err := ParseTaxPercent(v)
+		if err != nil {
+			return err
+		}
+		*p = rate
+	case []byte:
+		return p.Scan(string(v))
+	case nil:
+		return fmt.Errorf("%w: cannot scan NULL", ErrInvalidTax)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidTax, src)
+	}
+	return nil
+}
+
+func (p TaxPercent) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// TaxRateRequest creates or replaces the rate of a tax class in a country
+// or, with Region, in one of its regions. Rate is a percentage.
+type TaxRateRequest struct {
+	TaxClassID int        `json:"tax_class_id"`
+	Country    string     `json:"country" example:"RU"`
+	Region     string     `json:"region,omitempty"`
+	Rate       TaxPercent `json:"rate" swaggertype:"number" example:"20"`
+	Name       string     `json:"name,omitempty" example:"VAT"`
+}
+
+// Validate checks the request and normalizes the country and region.
+func (r *TaxRateRequest) Validate() error {
+	if r.TaxClassID <= 0 {
+		return fmt.Errorf("%w: tax_class_id is required", ErrInvalidTax)
+	}
+	country, err := NormalizeCountry(r.Country)
+	if err != nil {
+		return err
+	}
+	r.Country = country
+	r.Region = NormalizeRegion(r.Region)
+	if r.Rate < 0 || r.Rate > MaxTaxRate {
+		return fmt.Errorf("%w: rate must be between 0 and 100", ErrInvalidTax)
+	}
+	return nil
+}
+
+type TaxRate struct {
+	ID         int        `json:"id"`
+	TaxClassID int        `json:"tax_class_id"`
+	Country    string     `json:"country"`
+	Region     string     `json:"region,omitempty"`
+	Rate       TaxPercent `json:"rate" swaggertype:"number" example:"20"`
+	Name       string     `json:"name,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// TaxRateFilter selects tax rates for listing. Zero values are ignored.
+type TaxRateFilter struct {
+	TaxClassID int
+	Country    string
+}
+
+// TaxAddress is the destination tax is calculated for. A region without a
+// rate of its own falls back to the country-wide rate.
+type TaxAddress struct {
+	Country string `json:"country" example:"RU"`
+	Region  string `json:"region,omitempty"`
+}
+
+// TaxableLine is an amount to tax, normally a cart line after discounts.
+type TaxableLine struct {
+	ItemID    int
+	ProductID int
+	Amount    Money
+}
+
+type TaxLine struct {
+	ItemID     int        `json:"item_id"`
+	ProductID  int        `json:"product_id"`
+	TaxClassID *int       `json:"tax_class_id,omitempty"`
+	Rate       TaxPercent `json:"rate" swaggertype:"number" example:"20"`
+	Taxable    Money      `json:"taxable" swaggertype:"string" example:"2339.98"`
+	Tax        Money      `json:"tax" swaggertype:"string" example:"390.00"`
+}
+
+// TaxResult is the tax on a set of lines. Total is what the customer pays:
+// the taxable amounts, plus Tax in exclusive mode.
+type TaxResult struct {
+	Mode    TaxMode   `json:"mode"`
+	Country string    `json:"country"`
+	Region  string    `json:"region,omitempty"`
+	Lines   []TaxLine `json:"lines"`
+	Tax     Money     `json:"tax" swaggertype:"string" example:"390.00"`
+	Total   Money     `json:"total" swaggertype:"string" example:"2339.98"`
+}
@@ -0,0 +1,49 @@
+package domains
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseTaxPercent(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    TaxPercent
+		wantStr string
+		wantErr bool
+	}{
+		{name: "whole percent", input: "20", want: 200000, wantStr: "20"},
+		{name: "four decimal places", input: "8.875", want: 88750, wantStr: "8.875"},
+		{name: "smallest step", input: "0.0001", want: 1, wantStr: "0.0001"},
+		{name: "zeros past the scale", input: "20.000000", want: 200000, wantStr: "20"},
+		{name: "hundred percent", input: "100", want: MaxTaxRate, wantStr: "100"},
+		{name: "zero", input: "0", want: 0, wantStr: "0"},
+		{name: "digits past the scale", input: "20.00001", wantErr: true},
+		{name: "negative", input: "-1", wantErr: true},
+		{name: "exponent", input: "2e1", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+		{name: "too many digits", input: "1000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTaxPercent(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTax) {
+					t.Fatalf("ParseTaxPercent(%q) error = %v, want %v", tt.input, err, ErrInvalidTax)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTaxPercent(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTaxPercent(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+			if got.String() != tt.wantStr {
+				t.Errorf("TaxPercent(%d).String() = %q, want %q", got, got.String(), tt.wantStr)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidVariant = errors.New("invalid product variant")
+
+type ProductVariantRequest struct {
+	SKU         string   `json:"sku"`
+	Barcode     string   `json:"barcode,omitempty"`
+	Volume      *float64 `json:"volume,omitempty"`
+	VolumeUnit  string   `json:"volume_unit,omitempty"`
+	Shade       string   `json:"shade,omitempty"`
+	Price       Money    `json:"price" swaggertype:"string" example:"1299.99"`
+	WeightGrams *float64 `json:"weight_grams,omitempty"`
+}
+
+type ProductVariant struct {
+	ID            int        `json:"id"`
+	ProductID     int        `json:"product_id"`
+	SKU           string     `json:"sku"`
+	Barcode       string     `json:"barcode,omitempty"`
+	Volume        *float64   `json:"volume,omitempty"`
+	VolumeUnit    string     `json:"volume_unit,omitempty"`
+	Shade         string     `json:"shade,omitempty"`
+	Price         Money      `json:"price" swaggertype:"string" example:"1299.99"`
+	Currency      string     `json:"currency"`
+	WeightGrams   *float64   `json:"weight_grams,omitempty"`
+	InStock       bool       `json:"in_stock"`
+	StockQuantity int        `json:"stock_quantity"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+// Validate checks required fields and that the barcode is a GTIN (EAN-8,
+// UPC-A, EAN-13 or GTIN-14) with a correct check digit.
+func (r *ProductVariantRequest) Validate() error {
+	if r.SKU == "" {
+		return fmt.Errorf("%w: sku is required", ErrInvalidVariant)
+	}
+	if r.Price < 0 || r.Price > MaxPrice {
+		return fmt.Errorf("%w: price must be between 0 and %s", ErrInvalidVariant, MaxPrice)
+	}
+	if r.Volume != nil {
+		if *r.Volume <= 0 {
+			return fmt.Errorf("%w: volume must be positive", ErrInvalidVariant)
+		}
+		if r.VolumeUnit == "" {
+			return fmt.Errorf("%w: volume_unit is required with volume", ErrInvalidVariant)
+		}
+	}
+	if r.WeightGrams != nil && *r.WeightGrams <= 0 {
+		return fmt.Errorf("%w: weight_grams must be positive", ErrInvalidVariant)
+	}
+	if r.Barcode != "" && !validGTIN(r.Barcode) {
+		return fmt.Errorf("%w: barcode %q is not a valid EAN/UPC code", ErrInvalidVariant, r.Barcode)
+	}
+	return nil
+}
+
+func validGTIN(code string) bool {
+	switch len(code) {
+	case 8, 12, 13, 14:
+	default:
+		return false
+	}
+
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		c := code[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		digit := int(c - '0')
+		if (len(code)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	check := code[len(code)-1]
+	if check < '0' || check > '9' {
+		return false
+	}
+	return (10-sum%10)%10 == int(check-'0')
+}
@@ -0,0 +1,20 @@
+package domains
+
+type WarehouseRequest struct {
+	Code     string `json:"code" example:"MAIN"`
+	Name     string `json:"name"`
+	Address  string `json:"address,omitempty"`
+	Priority int    `json:"priority"`
+	IsActive *bool  `json:"is_active,omitempty"`
+}
+
+// Warehouse is a stock location. Lower priority values are preferred when
+// allocating order lines.
+type Warehouse struct {
+	ID       int    `json:"id"`
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Address  string `json:"address,omitempty"`
+	Priority int    `json:"priority"`
+	IsActive bool   `json:"is_active"`
+}
@@ -0,0 +1,43 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidWishlist = errors.New("invalid wishlist")
+
+type WishlistItemRequest struct {
+	ProductID int `json:"product_id"`
+}
+
+func (r *WishlistItemRequest) Validate() error {
+	if r.ProductID <= 0 {
+		return fmt.Errorf("%w: product_id is required", ErrInvalidWishlist)
+	}
+	return nil
+}
+
+// WishlistItem is a saved product with its current price and stock. Price
+// includes a running sale; OriginalPrice is the regular price.
+type WishlistItem struct {
+	ProductID     int        `json:"product_id"`
+	Name          string     `json:"name"`
+	Price         Money      `json:"price" swaggertype:"string" example:"999.99"`
+	OriginalPrice Money      `json:"original_price" swaggertype:"string" example:"1299.99"`
+	InStock       bool       `json:"in_stock"`
+	StockQuantity int        `json:"stock_quantity"`
+	AddedAt       *time.Time `json:"added_at,omitempty"`
+}
+
+// Wishlist is a customer's saved products, most recently added first, priced
+// in the base currency. Shared wishlists omit UserID and ShareToken.
+type Wishlist struct {
+	UserID     *int           `json:"user_id,omitempty"`
+	ShareToken string         `json:"share_token,omitempty"`
+	Items      []WishlistItem `json:"items"`
+	ItemCount  int            `json:"item_count"`
+	Currency   string         `json:"currency"`
+	UpdatedAt  *time.Time     `json:"updated_at,omitempty"`
+}
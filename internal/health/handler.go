@@ -0,0 +1,53 @@
+package health
+
+import (
+	"net/http"
+
+	"e-commerce/internal/cache"
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type HealthHandler struct {
+	db    *pgxpool.Pool
+	cache *cache.Cache
+}
+
+func NewHealthHandler(db *pgxpool.Pool, cache *cache.Cache) *HealthHandler {
+	return &HealthHandler{db: db, cache: cache}
+}
+
+func (h *HealthHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/health", h.GetHealth)
+}
+
+// @Summary Health check
+// @Description Report database and cache availability. The service stays up with the cache disabled while Redis is down.
+// @Tags health
+// @Produce json
+// @Success 200 {object} domains.HealthResponse
+// @Failure 503 {object} domains.HealthResponse
+// @Router /health [get]
+func (h *HealthHandler) GetHealth(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	resp := domains.HealthResponse{
+		Status:   "ok",
+		Database: "up",
+		Cache:    h.cache.Status(ctx),
+	}
+	if resp.Cache.Status != "up" {
+		resp.Status = "degraded"
+	}
+
+	if err := h.db.Ping(ctx); err != nil {
+		resp.Status = "down"
+		resp.Database = "down"
+		c.JSON(http.StatusServiceUnavailable, resp)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,176 @@
+package ingredient
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type IngredientHandler struct {
+	service IngredientService
+}
+
+func NewIngredientHandler(service IngredientService) *IngredientHandler {
+	return &IngredientHandler{service: service}
+}
+
+func (h *IngredientHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/ingredients", h.CreateIngredient)
+	router.GET("/ingredients/:id", h.GetIngredientByID)
+	router.PUT("/ingredients/:id", h.UpdateIngredient)
+	router.DELETE("/ingredients/:id", h.DeleteIngredient)
+	router.GET("/ingredients", h.GetAllIngredients)
+}
+
+// @Summary Create a new ingredient
+// @Description Create a new ingredient with the provided INCI details
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param ingredient body domains.Ingredient true "Ingredient object"
+// @Success 201 {object} domains.Ingredient
+// @Failure 400 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /ingredients [post]
+func (h *IngredientHandler) CreateIngredient(c *gin.Context) {
+	var ingredient domains.Ingredient
+	if err := c.ShouldBindJSON(&ingredient); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdIngredient, err := h.service.CreateIngredient(c.Request.Context(), &ingredient)
+	if err != nil {
+		c.JSON(ingredientErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdIngredient)
+}
+
+// @Summary Get ingredient by ID
+// @Description Get an ingredient by its ID
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param id path int true "Ingredient ID"
+// @Success 200 {object} domains.Ingredient
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /ingredients/{id} [get]
+func (h *IngredientHandler) GetIngredientByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ingredient id"})
+		return
+	}
+
+	ingredient, err := h.service.GetIngredientByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(ingredientErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ingredient)
+}
+
+// @Summary Update ingredient
+// @Description Update an existing ingredient
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param id path int true "Ingredient ID"
+// @Param ingredient body domains.Ingredient true "Ingredient object"
+// @Success 200 {object} domains.Ingredient
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Router /ingredients/{id} [put]
+func (h *IngredientHandler) UpdateIngredient(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ingredient id"})
+		return
+	}
+
+	var ingredient domains.Ingredient
+	if err := c.ShouldBindJSON(&ingredient); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedIngredient, err := h.service.UpdateIngredient(c.Request.Context(), id, &ingredient)
+	if err != nil {
+		c.JSON(ingredientErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedIngredient)
+}
+
+// @Summary Delete ingredient
+// @Description Delete an ingredient by its ID and remove it from every product
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param id path int true "Ingredient ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /ingredients/{id} [delete]
+func (h *IngredientHandler) DeleteIngredient(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ingredient id"})
+		return
+	}
+
+	if err := h.service.DeleteIngredient(c.Request.Context(), id); err != nil {
+		c.JSON(ingredientErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get all ingredients
+// @Description Get a list of all ingredients ordered by INCI name
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Success 200 {array} domains.Ingredient
+// @Failure 500 {object} domains.Error
+// @Router /ingredients [get]
+func (h *IngredientHandler) GetAllIngredients(c *gin.Context) {
+	ingredients, err := h.service.GetAllIngredients(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ingredients)
+}
+
+func ingredientErrorStatus(err error) int {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, domains.ErrInvalidIngredient):
+		return http.StatusBadRequest
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,277 @@
+package ingredient
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/cache"
+	"e-commerce/internal/domains"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+type IngredientRepository interface {
+	Create(ctx context.Context, ingredient *domains.Ingredient) (*domains.Ingredient, error)
+	GetByID(ctx context.Context, id int) (*domains.Ingredient, error)
+	Update(ctx context.Context, id int, ingredient *domains.Ingredient) (*domains.Ingredient, error)
+	Delete(ctx context.Context, id int) error
+	GetAll(ctx context.Context) ([]*domains.Ingredient, error)
+}
+
+type ingredientRepository struct {
+	db           *pgxpool.Pool
+	cache        cache.CacheRepository[domains.Ingredient]
+	productCache cache.CacheRepository[domains.ProductResponse]
+}
+
+func NewIngredientRepository(db *pgxpool.Pool, cacheClient *cache.Cache) IngredientRepository {
+	return &ingredientRepository{
+		db:           db,
+		cache:        cache.NewCacheRepository[domains.Ingredient](cacheClient, "ingredient"),
+		productCache: cache.NewCacheRepository[domains.ProductResponse](cacheClient, "product"),
+	}
+}
+
+const ingredientColumns = `
+        id, inci_name, common_names, COALESCE(function, ''), comedogenic_rating, is_allergen`
+
+func scanIngredient(row pgx.Row) (*domains.Ingredient, error) {
+	ingredient := &domains.Ingredient{}
+	err := row.Scan(
+		&ingredient.ID,
+		&ingredient.INCIName,
+		&ingredient.CommonNames,
+		&ingredient.Function,
+		&ingredient.ComedogenicRating,
+		&ingredient.IsAllergen,
+	)
+	return ingredient, err
+}
+
+// commonNames keeps a missing list from being stored as NULL.
+func commonNames(ingredient *domains.Ingredient) []string {
+	if ingredient.CommonNames == nil {
+		return []string{}
+	}
+	return ingredient.CommonNames
+}
+
+func (r *ingredientRepository) Create(ctx context.Context, ingredient *domains.Ingredient) (*domains.Ingredient, error) {
+	const insertQuery = `
+        INSERT INTO ingredients (inci_name, common_names, function, comedogenic_rating, is_allergen)
+        VALUES ($1, $2, NULLIF($3, ''), $4, $5)
+        RETURNING` + ingredientColumns
+
+	createdIngredient, err := scanIngredient(r.db.QueryRow(ctx, insertQuery,
+		ingredient.INCIName,
+		commonNames(ingredient),
+		ingredient.Function,
+		ingredient.ComedogenicRating,
+		ingredient.IsAllergen,
+	))
+	if err != nil {
+		logrus.WithError(err).WithField("ingredient", ingredient).Error("Failed to insert ingredient")
+		return nil, err
+	}
+
+	if err := r.cache.DeleteAll(ctx); err != nil {
+		logrus.Warnf("Failed to clear ingredient cache after creation (ID: %d): %v", createdIngredient.ID, err)
+	}
+	go func(i *domains.Ingredient) {
+		if err := r.cache.SetByID(context.Background(), i.ID, i); err != nil {
+			logrus.Warnf("Failed to cache created ingredient asynchronously (ID: %d): %v", i.ID, err)
+		} else {
+			logrus.Debugf("Successfully cached created ingredient asynchronously (ID: %d)", i.ID)
+		}
+	}(createdIngredient)
+
+	logrus.Debugf("Ingredient created successfully (ID: %d)", createdIngredient.ID)
+	return createdIngredient, nil
+}
+
+func (r *ingredientRepository) GetByID(ctx context.Context, id int) (*domains.Ingredient, error) {
+	ingredient, err := r.cache.GetByID(ctx, id)
+	if err == nil {
+		logrus.Debugf("Cache hit for ingredient (ID: %d)", id)
+		return ingredient, nil
+	}
+	if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
+		logrus.Errorf("Cache lookup failed for ingredient (ID: %d): %v", id, err)
+	}
+
+	const getQuery = `SELECT` + ingredientColumns + ` FROM ingredients WHERE id = $1`
+	ingredient, err = scanIngredient(r.db.QueryRow(ctx, getQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Ingredient not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get ingredient (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	go func(i *domains.Ingredient) {
+		if err := r.cache.SetByID(context.Background(), i.ID, i); err != nil {
+			logrus.Warnf("Failed to cache ingredient asynchronously (ID: %d): %v", i.ID, err)
+		} else {
+			logrus.Debugf("Successfully cached ingredient asynchronously (ID: %d)", i.ID)
+		}
+	}(ingredient)
+
+	logrus.Debugf("Ingredient retrieved successfully (ID: %d)", ingredient.ID)
+	return ingredient, nil
+}
+
+func (r *ingredientRepository) Update(ctx context.Context, id int, ingredient *domains.Ingredient) (*domains.Ingredient, error) {
+	const updateQuery = `
+        UPDATE ingredients
+        SET inci_name = $1, common_names = $2, function = NULLIF($3, ''), comedogenic_rating = $4, is_allergen = $5
+        WHERE id = $6
+        RETURNING` + ingredientColumns
+
+	updatedIngredient, err := scanIngredient(r.db.QueryRow(ctx, updateQuery,
+		ingredient.INCIName,
+		commonNames(ingredient),
+		ingredient.Function,
+		ingredient.ComedogenicRating,
+		ingredient.IsAllergen,
+		id,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to update non-existent ingredient (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to update ingredient (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	if err := r.cache.DeleteAll(ctx); err != nil {
+		logrus.Warnf("Failed to clear ingredient cache after update (ID: %d): %v", id, err)
+	}
+	go func(i *domains.Ingredient) {
+		if err := r.cache.SetByID(context.Background(), i.ID, i); err != nil {
+			logrus.Warnf("Failed to cache updated ingredient asynchronously (ID: %d): %v", i.ID, err)
+		} else {
+			logrus.Debugf("Successfully cached updated ingredient asynchronously (ID: %d)", i.ID)
+		}
+	}(updatedIngredient)
+	r.invalidateProducts(ctx, id)
+
+	logrus.Debugf("Ingredient updated successfully (ID: %d)", updatedIngredient.ID)
+	return updatedIngredient, nil
+}
+
+func (r *ingredientRepository) Delete(ctx context.Context, id int) error {
+	// Look up the products before the cascade removes their links.
+	productIDs, err := r.productIDs(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	const deleteQuery = `DELETE FROM ingredients WHERE id = $1 RETURNING id`
+
+	var deletedID int
+	err = r.db.QueryRow(ctx, deleteQuery, id).Scan(&deletedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to delete non-existent ingredient (ID: %d)", id)
+			return sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to delete ingredient (ID: %d): %v", id, err)
+		return err
+	}
+
+	if err := r.cache.Delete(ctx, id); err != nil {
+		logrus.Warnf("Failed to remove ingredient from cache (ID: %d): %v", id, err)
+	}
+	if err := r.cache.DeleteAll(ctx); err != nil {
+		logrus.Warnf("Failed to clear all ingredients cache after deletion (ID: %d): %v", id, err)
+	}
+	r.dropProducts(ctx, productIDs)
+
+	logrus.Debugf("Ingredient deleted successfully (ID: %d)", deletedID)
+	return nil
+}
+
+func (r *ingredientRepository) GetAll(ctx context.Context) ([]*domains.Ingredient, error) {
+	ingredients, err := r.cache.GetAll(ctx)
+	if err == nil {
+		logrus.Debug("Cache hit for all ingredients")
+		return ingredients, nil
+	}
+	if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
+		logrus.Errorf("Cache lookup failed for all ingredients: %v", err)
+	}
+
+	const getAllQuery = `SELECT` + ingredientColumns + ` FROM ingredients ORDER BY inci_name`
+	rows, err := r.db.Query(ctx, getAllQuery)
+	if err != nil {
+		logrus.Errorf("Failed to get all ingredients: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ingredientsList []*domains.Ingredient
+	for rows.Next() {
+		ingredient, err := scanIngredient(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan ingredient record: %v", err)
+			return nil, err
+		}
+		ingredientsList = append(ingredientsList, ingredient)
+	}
+	if rows.Err() != nil {
+		logrus.Errorf("Error occurred during iteration of rows: %v", rows.Err())
+		return nil, rows.Err()
+	}
+
+	go func(il []*domains.Ingredient) {
+		if err := r.cache.SetAll(context.Background(), il); err != nil {
+			logrus.Warnf("Failed to cache all ingredients asynchronously: %v", err)
+		} else {
+			logrus.Debugf("Successfully cached all ingredients asynchronously (Count: %d)", len(il))
+		}
+	}(ingredientsList)
+
+	logrus.Debugf("All ingredients retrieved successfully (Count: %d)", len(ingredientsList))
+	return ingredientsList, nil
+}
+
+func (r *ingredientRepository) productIDs(ctx context.Context, ingredientID int) ([]int, error) {
+	const productsQuery = `SELECT product_id FROM product_ingredients WHERE ingredient_id = $1`
+
+	rows, err := r.db.Query(ctx, productsQuery, ingredientID)
+	if err != nil {
+		logrus.Errorf("Failed to query products of ingredient (ID: %d): %v", ingredientID, err)
+		return nil, err
+	}
+	productIDs, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		logrus.Errorf("Failed to scan products of ingredient (ID: %d): %v", ingredientID, err)
+		return nil, err
+	}
+	return productIDs, nil
+}
+
+// invalidateProducts drops the cached products listing the ingredient so
+// they show its new details.
+func (r *ingredientRepository) invalidateProducts(ctx context.Context, ingredientID int) {
+	productIDs, err := r.productIDs(ctx, ingredientID)
+	if err != nil {
+		logrus.Warnf("Failed to invalidate products after ingredient change (ID: %d): %v", ingredientID, err)
+		return
+	}
+	r.dropProducts(ctx, productIDs)
+}
+
+func (r *ingredientRepository) dropProducts(ctx context.Context, productIDs []int) {
+	for _, productID := range productIDs {
+		if err := r.productCache.Delete(ctx, productID); err != nil {
+			logrus.Warnf("Failed to remove product from cache after ingredient change (ID: %d): %v", productID, err)
+		}
+	}
+}
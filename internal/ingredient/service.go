@@ -0,0 +1,48 @@
+package ingredient
+
+import (
+	"context"
+	"e-commerce/internal/domains"
+)
+
+type IngredientService interface {
+	CreateIngredient(ctx context.Context, ingredient *domains.Ingredient) (*domains.Ingredient, error)
+	GetIngredientByID(ctx context.Context, id int) (*domains.Ingredient, error)
+	UpdateIngredient(ctx context.Context, id int, ingredient *domains.Ingredient) (*domains.Ingredient, error)
+	DeleteIngredient(ctx context.Context, id int) error
+	GetAllIngredients(ctx context.Context) ([]*domains.Ingredient, error)
+}
+
+type ingredientService struct {
+	repo IngredientRepository
+}
+
+func NewIngredientService(repo IngredientRepository) IngredientService {
+	return &ingredientService{repo: repo}
+}
+
+func (s *ingredientService) CreateIngredient(ctx context.Context, ingredient *domains.Ingredient) (*domains.Ingredient, error) {
+	if err := ingredient.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, ingredient)
+}
+
+func (s *ingredientService) GetIngredientByID(ctx context.Context, id int) (*domains.Ingredient, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *ingredientService) UpdateIngredient(ctx context.Context, id int, ingredient *domains.Ingredient) (*domains.Ingredient, error) {
+	if err := ingredient.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.Update(ctx, id, ingredient)
+}
+
+func (s *ingredientService) DeleteIngredient(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *ingredientService) GetAllIngredients(ctx context.Context) ([]*domains.Ingredient, error) {
+	return s.repo.GetAll(ctx)
+}
@@ -0,0 +1,50 @@
+package inventory
+
+import (
+	"e-commerce/internal/domains"
+	"fmt"
+	"sort"
+)
+
+// Allocate chooses warehouses for quantity units from candidates. The
+// highest-priority warehouse that can ship the whole line wins; otherwise the
+// line is split across warehouses in priority order when allowSplit is set.
+func Allocate(candidates []domains.WarehouseStock, quantity int, allowSplit bool) ([]domains.AllocationLine, error) {
+	if quantity <= 0 {
+		return nil, fmt.Errorf("%w: quantity must be positive", domains.ErrInvalidAdjustment)
+	}
+
+	sorted := make([]domains.WarehouseStock, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.Quantity > 0 {
+			sorted = append(sorted, candidate)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority < sorted[j].Priority
+		}
+		return sorted[i].WarehouseID < sorted[j].WarehouseID
+	})
+
+	for _, candidate := range sorted {
+		if candidate.Quantity >= quantity {
+			return []domains.AllocationLine{{WarehouseID: candidate.WarehouseID, Quantity: quantity}}, nil
+		}
+	}
+	if !allowSplit {
+		return nil, domains.ErrInsufficientStock
+	}
+
+	var lines []domains.AllocationLine
+	remaining := quantity
+	for _, candidate := range sorted {
+		take := min(candidate.Quantity, remaining)
+		lines = append(lines, domains.AllocationLine{WarehouseID: candidate.WarehouseID, Quantity: take})
+		remaining -= take
+		if remaining == 0 {
+			return lines, nil
+		}
+	}
+	return nil, domains.ErrInsufficientStock
+}
@@ -0,0 +1,95 @@
+package inventory
+
+import (
+	"e-commerce/internal/domains"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAllocate(t *testing.T) {
+	stock := []domains.WarehouseStock{
+		{WarehouseID: 3, Priority: 2, Quantity: 4},
+		{WarehouseID: 1, Priority: 1, Quantity: 3},
+		{WarehouseID: 2, Priority: 1, Quantity: 2},
+		{WarehouseID: 4, Priority: 0, Quantity: 0},
+	}
+
+	tests := []struct {
+		name       string
+		candidates []domains.WarehouseStock
+		quantity   int
+		allowSplit bool
+		want       []domains.AllocationLine
+		wantErr    error
+	}{
+		{
+			name:       "highest priority warehouse covers the order",
+			candidates: stock,
+			quantity:   3,
+			want:       []domains.AllocationLine{{WarehouseID: 1, Quantity: 3}},
+		},
+		{
+			name:       "lower priority warehouse covers what higher ones cannot",
+			candidates: stock,
+			quantity:   4,
+			want:       []domains.AllocationLine{{WarehouseID: 3, Quantity: 4}},
+		},
+		{
+			name:       "split needed but not allowed",
+			candidates: stock,
+			quantity:   6,
+			wantErr:    domains.ErrInsufficientStock,
+		},
+		{
+			name:       "split follows priority then warehouse id",
+			candidates: stock,
+			quantity:   6,
+			allowSplit: true,
+			want: []domains.AllocationLine{
+				{WarehouseID: 1, Quantity: 3},
+				{WarehouseID: 2, Quantity: 2},
+				{WarehouseID: 3, Quantity: 1},
+			},
+		},
+		{
+			name:       "short stock",
+			candidates: stock,
+			quantity:   10,
+			allowSplit: true,
+			wantErr:    domains.ErrInsufficientStock,
+		},
+		{
+			name:       "no stock",
+			candidates: nil,
+			quantity:   1,
+			allowSplit: true,
+			wantErr:    domains.ErrInsufficientStock,
+		},
+		{
+			name:       "zero quantity",
+			candidates: stock,
+			quantity:   0,
+			wantErr:    domains.ErrInvalidAdjustment,
+		},
+		{
+			name:       "negative quantity",
+			candidates: stock,
+			quantity:   -2,
+			allowSplit: true,
+			wantErr:    domains.ErrInvalidAdjustment,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Allocate(tt.candidates, tt.quantity, tt.allowSplit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Allocate() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Allocate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package inventory
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type InventoryHandler struct {
+	service InventoryService
+}
+
+func NewInventoryHandler(service InventoryService) *InventoryHandler {
+	return &InventoryHandler{service: service}
+}
+
+func (h *InventoryHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/inventory/adjustments", h.AdjustStock)
+	router.POST("/inventory/allocations", h.AllocateStock)
+	router.GET("/inventory/products/:id", h.GetStockLevels)
+	router.GET("/inventory/products/:id/movements", h.GetStockMovements)
+}
+
+// @Summary Adjust stock
+// @Description Record a receipt, sale, return or manual adjustment and update the stock level
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param adjustment body domains.StockAdjustmentRequest true "Stock adjustment"
+// @Success 201 {object} domains.StockAdjustmentResponse
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /inventory/adjustments [post]
+func (h *InventoryHandler) AdjustStock(c *gin.Context) {
+	var req domains.StockAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.service.AdjustStock(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// @Summary Allocate stock
+// @Description Choose warehouses for an order line by priority and availability without reserving stock
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param allocation body domains.AllocationRequest true "Order line"
+// @Success 200 {object} domains.Allocation
+// @Failure 400 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /inventory/allocations [post]
+func (h *InventoryHandler) AllocateStock(c *gin.Context) {
+	var req domains.AllocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	allocation, err := h.service.AllocateStock(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(inventoryErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, allocation)
+}
+
+// @Summary Get stock levels
+// @Description Get stock levels of a product and its variants in every warehouse
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {array} domains.StockLevel
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /inventory/products/{id} [get]
+func (h *InventoryHandler) GetStockLevels(c *gin.Context) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	levels, err := h.service.GetStockLevels(c.Request.Context(), productID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, levels)
+}
+
+// @Summary Get stock movements
+// @Description Get the stock movement ledger of a product, newest first
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {array} domains.StockMovement
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /inventory/products/{id}/movements [get]
+func (h *InventoryHandler) GetStockMovements(c *gin.Context) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	movements, err := h.service.GetStockMovements(c.Request.Context(), productID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, movements)
+}
+
+func inventoryErrorStatus(err error) int {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, domains.ErrInvalidAdjustment):
+		return http.StatusBadRequest
+	case errors.Is(err, domains.ErrInsufficientStock):
+		return http.StatusConflict
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23503":
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
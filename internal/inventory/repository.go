@@ -0,0 +1,364 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/cache"
+	"e-commerce/internal/domains"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type InventoryRepository interface {
+	Adjust(ctx context.Context, req *domains.StockAdjustmentRequest, delta int) (*domains.StockAdjustmentResponse, error)
+	AdjustTx(ctx context.Context, tx pgx.Tx, req *domains.StockAdjustmentRequest, delta int) (*domains.StockAdjustmentResponse, bool, error)
+	SellTx(ctx context.Context, tx pgx.Tx, productID int, variantID *int, quantity int, reason string) (bool, error)
+	Invalidate(ctx context.Context, productID int, inStockChanged bool)
+	GetStockLevels(ctx context.Context, productID int) ([]*domains.StockLevel, error)
+	GetMovements(ctx context.Context, productID int) ([]*domains.StockMovement, error)
+	GetWarehouseStock(ctx context.Context, productID int, variantID *int) ([]domains.WarehouseStock, error)
+}
+
+type inventoryRepository struct {
+	db           *pgxpool.Pool
+	productCache cache.CacheRepository[domains.ProductResponse]
+}
+
+func NewInventoryRepository(db *pgxpool.Pool, cacheClient *cache.Cache) InventoryRepository {
+	return &inventoryRepository{
+		db:           db,
+		productCache: cache.NewCacheRepository[domains.ProductResponse](cacheClient, "product"),
+	}
+}
+
+// Adjust applies delta to the stock level and appends the movement to the
+// ledger in one transaction. Decrements are guarded in the UPDATE itself, so
+// concurrent sales can never take the level below zero.
+func (r *inventoryRepository) Adjust(ctx context.Context, req *domains.StockAdjustmentRequest, delta int) (*domains.StockAdjustmentResponse, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	resp, crossed, err := adjust(ctx, tx, req, delta)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+	r.Invalidate(ctx, req.ProductID, crossed)
+
+	logrus.Debugf("Stock adjusted successfully (product_id: %d, delta: %d, quantity: %d)", req.ProductID, delta, resp.Level.Quantity)
+	return resp, nil
+}
+
+// AdjustTx applies delta like Adjust but inside the caller's transaction,
+// so the movement commits or rolls back with the caller's own changes. The
+// caller invalidates the product with Invalidate after committing, passing
+// the returned flag.
+func (r *inventoryRepository) AdjustTx(ctx context.Context, tx pgx.Tx, req *domains.StockAdjustmentRequest, delta int) (*domains.StockAdjustmentResponse, bool, error) {
+	return adjust(ctx, tx, req, delta)
+}
+
+// SellTx takes quantity units of a product or variant out of stock inside
+// the caller's transaction, split across active warehouses in priority order.
+// The stock rows are locked while they are allocated. It reports whether the
+// product went out of stock; the caller passes that on to Invalidate after
+// committing.
+func (r *inventoryRepository) SellTx(ctx context.Context, tx pgx.Tx, productID int, variantID *int, quantity int, reason string) (bool, error) {
+	const stockQuery = `
+        SELECT w.id, w.priority, sl.quantity
+        FROM stock_levels sl
+        JOIN warehouses w ON w.id = sl.warehouse_id
+        WHERE w.is_active AND sl.product_id = $1 AND sl.variant_id IS NOT DISTINCT FROM $2
+        ORDER BY w.priority, w.id
+        FOR UPDATE OF sl`
+
+	rows, err := tx.Query(ctx, stockQuery, productID, variantID)
+	if err != nil {
+		logrus.Errorf("Failed to lock warehouse stock (product_id: %d): %v", productID, err)
+		return false, err
+	}
+	stock, err := pgx.CollectRows(rows, pgx.RowToStructByPos[domains.WarehouseStock])
+	if err != nil {
+		logrus.Errorf("Failed to scan warehouse stock (product_id: %d): %v", productID, err)
+		return false, err
+	}
+
+	lines, err := Allocate(stock, quantity, true)
+	if err != nil {
+		logrus.Infof("Insufficient stock for sale (product_id: %d, quantity: %d)", productID, quantity)
+		return false, err
+	}
+
+	var soldOut bool
+	for _, line := range lines {
+		req := &domains.StockAdjustmentRequest{
+			ProductID:   productID,
+			VariantID:   variantID,
+			WarehouseID: &line.WarehouseID,
+			Type:        domains.MovementSale,
+			Quantity:    line.Quantity,
+			Reason:      reason,
+		}
+		_, crossed, err := adjust(ctx, tx, req, -line.Quantity)
+		if err != nil {
+			return false, err
+		}
+		soldOut = soldOut || crossed
+	}
+	return soldOut, nil
+}
+
+// Invalidate drops the cached copies of a product after its stock changed.
+// When the product went in or out of stock, cached filter results are
+// dropped as well since in_stock filters depend on it.
+func (r *inventoryRepository) Invalidate(ctx context.Context, productID int, inStockChanged bool) {
+	if err := r.productCache.Delete(ctx, productID); err != nil {
+		logrus.Warnf("Failed to remove product from cache after stock adjustment (ID: %d): %v", productID, err)
+	}
+	if err := r.productCache.DeleteAll(ctx); err != nil {
+		logrus.Warnf("Failed to clear all products cache after stock adjustment (ID: %d): %v", productID, err)
+	}
+	if !inStockChanged {
+		return
+	}
+	if err := r.productCache.DeleteByPrefix(ctx, domains.ProductFilterKeyPrefix); err != nil {
+		logrus.Warnf("Failed to clear product filter cache after stock adjustment (ID: %d): %v", productID, err)
+	}
+}
+
+// adjust reports whether the product's stock across active warehouses
+// crossed zero.
+func adjust(ctx context.Context, tx pgx.Tx, req *domains.StockAdjustmentRequest, delta int) (*domains.StockAdjustmentResponse, bool, error) {
+	if req.VariantID != nil {
+		const variantQuery = `SELECT id FROM product_variants WHERE id = $1 AND product_id = $2`
+		var variantID int
+		if err := tx.QueryRow(ctx, variantQuery, *req.VariantID, req.ProductID).Scan(&variantID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				logrus.Infof("Variant not found for stock adjustment (ID: %d, product_id: %d)", *req.VariantID, req.ProductID)
+				return nil, false, sql.ErrNoRows
+			}
+			logrus.Errorf("Failed to look up variant for stock adjustment (ID: %d): %v", *req.VariantID, err)
+			return nil, false, err
+		}
+	}
+
+	var warehouseID int
+	if req.WarehouseID != nil {
+		warehouseID = *req.WarehouseID
+	} else {
+		const defaultWarehouseQuery = `SELECT id FROM warehouses WHERE is_active ORDER BY priority, id LIMIT 1`
+		if err := tx.QueryRow(ctx, defaultWarehouseQuery).Scan(&warehouseID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				logrus.Info("No active warehouse for stock adjustment")
+				return nil, false, sql.ErrNoRows
+			}
+			logrus.Errorf("Failed to look up default warehouse: %v", err)
+			return nil, false, err
+		}
+	}
+
+	before, err := productStock(ctx, tx, req.ProductID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	resp := &domains.StockAdjustmentResponse{}
+	if delta > 0 {
+		const incrementQuery = `
+            INSERT INTO stock_levels (warehouse_id, product_id, variant_id, quantity)
+            VALUES ($1, $2, $3, $4)
+            ON CONFLICT (warehouse_id, product_id, (COALESCE(variant_id, 0)))
+            DO UPDATE SET quantity = stock_levels.quantity + EXCLUDED.quantity, updated_at = CURRENT_TIMESTAMP
+            RETURNING warehouse_id, product_id, variant_id, quantity, updated_at`
+		err = tx.QueryRow(ctx, incrementQuery, warehouseID, req.ProductID, req.VariantID, delta).Scan(
+			&resp.Level.WarehouseID,
+			&resp.Level.ProductID,
+			&resp.Level.VariantID,
+			&resp.Level.Quantity,
+			&resp.Level.UpdatedAt,
+		)
+	} else {
+		const decrementQuery = `
+            UPDATE stock_levels
+            SET quantity = quantity + $4, updated_at = CURRENT_TIMESTAMP
+            WHERE warehouse_id = $1 AND product_id = $2 AND variant_id IS NOT DISTINCT FROM $3 AND quantity + $4 >= 0
+            RETURNING warehouse_id, product_id, variant_id, quantity, updated_at`
+		err = tx.QueryRow(ctx, decrementQuery, warehouseID, req.ProductID, req.VariantID, delta).Scan(
+			&resp.Level.WarehouseID,
+			&resp.Level.ProductID,
+			&resp.Level.VariantID,
+			&resp.Level.Quantity,
+			&resp.Level.UpdatedAt,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Insufficient stock for adjustment (warehouse_id: %d, product_id: %d, delta: %d)", warehouseID, req.ProductID, delta)
+			return nil, false, domains.ErrInsufficientStock
+		}
+	}
+	if err != nil {
+		logrus.WithError(err).WithField("req", req).Error("Failed to update stock level")
+		return nil, false, err
+	}
+
+	const insertMovementQuery = `
+        INSERT INTO stock_movements (warehouse_id, product_id, variant_id, movement_type, quantity, reason)
+        VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+        RETURNING id, warehouse_id, product_id, variant_id, movement_type, quantity, COALESCE(reason, ''), created_at`
+	err = tx.QueryRow(ctx, insertMovementQuery, warehouseID, req.ProductID, req.VariantID, req.Type, delta, req.Reason).Scan(
+		&resp.Movement.ID,
+		&resp.Movement.WarehouseID,
+		&resp.Movement.ProductID,
+		&resp.Movement.VariantID,
+		&resp.Movement.Type,
+		&resp.Movement.Quantity,
+		&resp.Movement.Reason,
+		&resp.Movement.CreatedAt,
+	)
+	if err != nil {
+		logrus.WithError(err).WithField("req", req).Error("Failed to insert stock movement")
+		return nil, false, err
+	}
+
+	after, err := productStock(ctx, tx, req.ProductID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return resp, (before > 0) != (after > 0), nil
+}
+
+// productStock sums the product's stock, variants included, in active
+// warehouses, the way product reads compute in_stock.
+func productStock(ctx context.Context, tx pgx.Tx, productID int) (int, error) {
+	const stockQuery = `
+        SELECT COALESCE(SUM(sl.quantity), 0)
+        FROM stock_levels sl
+        JOIN warehouses w ON w.id = sl.warehouse_id
+        WHERE sl.product_id = $1 AND w.is_active`
+
+	var quantity int
+	if err := tx.QueryRow(ctx, stockQuery, productID).Scan(&quantity); err != nil {
+		logrus.Errorf("Failed to sum product stock (product_id: %d): %v", productID, err)
+		return 0, err
+	}
+	return quantity, nil
+}
+
+func (r *inventoryRepository) GetStockLevels(ctx context.Context, productID int) ([]*domains.StockLevel, error) {
+	const levelsQuery = `
+        SELECT warehouse_id, product_id, variant_id, quantity, updated_at
+        FROM stock_levels
+        WHERE product_id = $1
+        ORDER BY variant_id NULLS FIRST, warehouse_id`
+
+	rows, err := r.db.Query(ctx, levelsQuery, productID)
+	if err != nil {
+		logrus.Errorf("Failed to query stock levels (product_id: %d): %v", productID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var levels []*domains.StockLevel
+	for rows.Next() {
+		level := &domains.StockLevel{}
+		if err := rows.Scan(&level.WarehouseID, &level.ProductID, &level.VariantID, &level.Quantity, &level.UpdatedAt); err != nil {
+			logrus.Errorf("Failed to scan stock level row: %v", err)
+			return nil, err
+		}
+		levels = append(levels, level)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating stock level rows: %v", err)
+		return nil, err
+	}
+
+	return levels, nil
+}
+
+func (r *inventoryRepository) GetMovements(ctx context.Context, productID int) ([]*domains.StockMovement, error) {
+	const movementsQuery = `
+        SELECT id, warehouse_id, product_id, variant_id, movement_type, quantity, COALESCE(reason, ''), created_at
+        FROM stock_movements
+        WHERE product_id = $1
+        ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.Query(ctx, movementsQuery, productID)
+	if err != nil {
+		logrus.Errorf("Failed to query stock movements (product_id: %d): %v", productID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movements []*domains.StockMovement
+	for rows.Next() {
+		movement := &domains.StockMovement{}
+		if err := rows.Scan(
+			&movement.ID,
+			&movement.WarehouseID,
+			&movement.ProductID,
+			&movement.VariantID,
+			&movement.Type,
+			&movement.Quantity,
+			&movement.Reason,
+			&movement.CreatedAt,
+		); err != nil {
+			logrus.Errorf("Failed to scan stock movement row: %v", err)
+			return nil, err
+		}
+		movements = append(movements, movement)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating stock movement rows: %v", err)
+		return nil, err
+	}
+
+	return movements, nil
+}
+
+// GetWarehouseStock returns on-hand stock of a product or variant in every
+// active warehouse, best priority first.
+func (r *inventoryRepository) GetWarehouseStock(ctx context.Context, productID int, variantID *int) ([]domains.WarehouseStock, error) {
+	const stockQuery = `
+        SELECT w.id, w.priority, sl.quantity
+        FROM stock_levels sl
+        JOIN warehouses w ON w.id = sl.warehouse_id
+        WHERE w.is_active AND sl.product_id = $1 AND sl.variant_id IS NOT DISTINCT FROM $2
+        ORDER BY w.priority, w.id`
+
+	rows, err := r.db.Query(ctx, stockQuery, productID, variantID)
+	if err != nil {
+		logrus.Errorf("Failed to query warehouse stock (product_id: %d): %v", productID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stock []domains.WarehouseStock
+	for rows.Next() {
+		var item domains.WarehouseStock
+		if err := rows.Scan(&item.WarehouseID, &item.Priority, &item.Quantity); err != nil {
+			logrus.Errorf("Failed to scan warehouse stock row: %v", err)
+			return nil, err
+		}
+		stock = append(stock, item)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating warehouse stock rows: %v", err)
+		return nil, err
+	}
+
+	return stock, nil
+}
@@ -0,0 +1,59 @@
+package inventory
+
+import (
+	"context"
+	"e-commerce/internal/domains"
+)
+
+type InventoryService interface {
+	AdjustStock(ctx context.Context, req *domains.StockAdjustmentRequest) (*domains.StockAdjustmentResponse, error)
+	GetStockLevels(ctx context.Context, productID int) ([]*domains.StockLevel, error)
+	GetStockMovements(ctx context.Context, productID int) ([]*domains.StockMovement, error)
+	AllocateStock(ctx context.Context, req *domains.AllocationRequest) (*domains.Allocation, error)
+}
+
+type inventoryService struct {
+	repo InventoryRepository
+}
+
+func NewInventoryService(repo InventoryRepository) InventoryService {
+	return &inventoryService{repo: repo}
+}
+
+func (s *inventoryService) AdjustStock(ctx context.Context, req *domains.StockAdjustmentRequest) (*domains.StockAdjustmentResponse, error) {
+	delta, err := req.Delta()
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Adjust(ctx, req, delta)
+}
+
+func (s *inventoryService) GetStockLevels(ctx context.Context, productID int) ([]*domains.StockLevel, error) {
+	return s.repo.GetStockLevels(ctx, productID)
+}
+
+func (s *inventoryService) GetStockMovements(ctx context.Context, productID int) ([]*domains.StockMovement, error) {
+	return s.repo.GetMovements(ctx, productID)
+}
+
+func (s *inventoryService) AllocateStock(ctx context.Context, req *domains.AllocationRequest) (*domains.Allocation, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	stock, err := s.repo.GetWarehouseStock(ctx, req.ProductID, req.VariantID)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := Allocate(stock, req.Quantity, req.AllowSplit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domains.Allocation{
+		ProductID: req.ProductID,
+		VariantID: req.VariantID,
+		Quantity:  req.Quantity,
+		Lines:     lines,
+	}, nil
+}
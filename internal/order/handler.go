@@ -0,0 +1,194 @@
+package order
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrderHandler struct {
+	service OrderService
+}
+
+func NewOrderHandler(service OrderService) *OrderHandler {
+	return &OrderHandler{service: service}
+}
+
+func (h *OrderHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/orders", h.Checkout)
+	router.GET("/orders", h.ListOrders)
+	router.GET("/orders/:id", h.GetOrderByID)
+	router.POST("/orders/:id/transitions", h.TransitionOrder)
+}
+
+// @Summary Checkout
+// @Description Convert a guest or customer cart into a pending order with snapshotted prices and promotions applied
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param checkout body domains.CheckoutRequest true "Cart to check out"
+// @Success 201 {object} domains.Order
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /orders [post]
+func (h *OrderHandler) Checkout(c *gin.Context) {
+	var req domains.CheckoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.service.Checkout(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(orderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// @Summary List orders
+// @Description List orders newest first, optionally by customer, status and creation date
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param user_id query int false "Customer ID"
+// @Param status query string false "Order status"
+// @Param from query string false "Created at or after (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Created before (RFC 3339 or YYYY-MM-DD)"
+// @Param limit query int false "Page size (default 50)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} domains.Order
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /orders [get]
+func (h *OrderHandler) ListOrders(c *gin.Context) {
+	filter := domains.OrderFilter{Status: domains.OrderStatus(c.Query("status"))}
+
+	if raw := c.Query("user_id"); raw != "" {
+		userID, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+			return
+		}
+		filter.UserID = &userID
+	}
+	for param, dest := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if raw := c.Query(param); raw != "" {
+			t, err := parseTime(raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param + " date"})
+				return
+			}
+			*dest = &t
+		}
+	}
+	for param, dest := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		if raw := c.Query(param); raw != "" {
+			value, err := strconv.Atoi(raw)
+			if err != nil || value < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param})
+				return
+			}
+			*dest = value
+		}
+	}
+
+	orders, err := h.service.ListOrders(c.Request.Context(), &filter)
+	if err != nil {
+		c.JSON(orderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+// @Summary Get order by ID
+// @Description Get an order with its lines and status history
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} domains.Order
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /orders/{id} [get]
+func (h *OrderHandler) GetOrderByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	order, err := h.service.GetOrderByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(orderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// @Summary Change order status
+// @Description Move an order along pending → paid → fulfilled → shipped → delivered, or to cancelled/refunded. Invalid transitions are rejected.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param transition body domains.OrderTransitionRequest true "Target status"
+// @Success 200 {object} domains.Order
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /orders/{id}/transitions [post]
+func (h *OrderHandler) TransitionOrder(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	var req domains.OrderTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.service.TransitionOrder(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(orderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+func orderErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domains.ErrInvalidOrder):
+		return http.StatusBadRequest
+	case errors.Is(err, domains.ErrInvalidTransition), errors.Is(err, domains.ErrCouponExhausted),
+		errors.Is(err, domains.ErrInsufficientStock), errors.Is(err, domains.ErrReservationInactive):
+		return http.StatusConflict
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,504 @@
+package order
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"e-commerce/internal/domains"
+	"e-commerce/internal/inventory"
+	"e-commerce/internal/reservation"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultListLimit = 50
+
+type OrderRepository interface {
+	Create(ctx context.Context, order *domains.Order, reservationIDs []int) (*domains.Order, error)
+	GetByID(ctx context.Context, id int) (*domains.Order, error)
+	List(ctx context.Context, filter *domains.OrderFilter) ([]*domains.Order, error)
+	Transition(ctx context.Context, id int, req *domains.OrderTransitionRequest) (*domains.Order, error)
+}
+
+type orderRepository struct {
+	db           *pgxpool.Pool
+	inventory    inventory.InventoryRepository
+	reservations reservation.ReservationRepository
+}
+
+func NewOrderRepository(db *pgxpool.Pool, inventoryRepo inventory.InventoryRepository, reservationRepo reservation.ReservationRepository) OrderRepository {
+	return &orderRepository{db: db, inventory: inventoryRepo, reservations: reservationRepo}
+}
+
+const orderColumns = `
+        id, user_id, COALESCE(email, ''), status, subtotal, discount_total, tax_total, tax_included,
+        COALESCE(tax_country, ''), COALESCE(tax_region, ''), total, COALESCE(currency, ''),
+        promotion_ids, created_at, updated_at`
+
+func scanOrder(row pgx.Row) (*domains.Order, error) {
+	order := &domains.Order{}
+	err := row.Scan(
+		&order.ID,
+		&order.UserID,
+		&order.Email,
+		&order.Status,
+		&order.Subtotal,
+		&order.Discount,
+		&order.Tax,
+		&order.TaxIncluded,
+		&order.TaxCountry,
+		&order.TaxRegion,
+		&order.Total,
+		&order.Currency,
+		&order.PromotionIDs,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	return order, err
+}
+
+// Create inserts the order, its lines and the initial history entry in one
+// transaction. The same transaction records the sale in the stock ledger and
+// releases the reservations that held the stock for the cart, so an order
+// either exists with its stock taken or not at all.
+func (r *orderRepository) Create(ctx context.Context, order *domains.Order, reservationIDs []int) (*domains.Order, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	insertOrderQuery := `
+        INSERT INTO orders (user_id, email, status, subtotal, discount_total, tax_total, tax_included,
+                            tax_country, tax_region, total, currency, promotion_ids)
+        VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, ''), $10, NULLIF($11, ''), $12)
+        RETURNING ` + orderColumns
+	promotionIDs := order.PromotionIDs
+	if promotionIDs == nil {
+		promotionIDs = []int{}
+	}
+	created, err := scanOrder(tx.QueryRow(ctx, insertOrderQuery,
+		order.UserID,
+		order.Email,
+		order.Status,
+		order.Subtotal,
+		order.Discount,
+		order.Tax,
+		order.TaxIncluded,
+		order.TaxCountry,
+		order.TaxRegion,
+		order.Total,
+		order.Currency,
+		promotionIDs,
+	))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to insert order")
+		return nil, err
+	}
+
+	const insertItemQuery = `
+        INSERT INTO order_items (order_id, product_id, variant_id, product_name, sku, quantity, unit_price, line_total,
+                                 discount, tax, tax_rate)
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9, $10, $11)
+        RETURNING id`
+	for _, item := range order.Items {
+		if err = tx.QueryRow(ctx, insertItemQuery,
+			created.ID,
+			item.ProductID,
+			item.VariantID,
+			item.ProductName,
+			item.SKU,
+			item.Quantity,
+			item.UnitPrice,
+			item.LineTotal,
+			item.Discount,
+			item.Tax,
+			item.TaxRate,
+		).Scan(&item.ID); err != nil {
+			logrus.Errorf("Failed to insert order item (order_id: %d): %v", created.ID, err)
+			return nil, err
+		}
+		created.Items = append(created.Items, item)
+	}
+
+	var stockChanged map[int]bool
+	if stockChanged, err = r.takeStock(ctx, tx, created, reservationIDs); err != nil {
+		return nil, err
+	}
+
+	const insertHistoryQuery = `
+        INSERT INTO order_status_history (order_id, to_status)
+        VALUES ($1, $2)
+        RETURNING created_at`
+	change := domains.OrderStatusChange{ToStatus: created.Status}
+	if err = tx.QueryRow(ctx, insertHistoryQuery, created.ID, created.Status).Scan(&change.CreatedAt); err != nil {
+		logrus.Errorf("Failed to insert order history (order_id: %d): %v", created.ID, err)
+		return nil, err
+	}
+	created.History = []domains.OrderStatusChange{change}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+
+	for productID, inStockChanged := range stockChanged {
+		r.inventory.Invalidate(ctx, productID, inStockChanged)
+	}
+
+	logrus.Debugf("Order created successfully (ID: %d, items: %d)", created.ID, len(created.Items))
+	return created, nil
+}
+
+// stockKey identifies a product or one of its variants.
+type stockKey struct {
+	productID int
+	variantID int
+}
+
+func newStockKey(productID int, variantID *int) stockKey {
+	key := stockKey{productID: productID}
+	if variantID != nil {
+		key.variantID = *variantID
+	}
+	return key
+}
+
+// takeStock consumes the reservations, which must all hold stock for lines of
+// the order, and records a sale movement for every line. Products are locked
+// in ID order like reservations lock them, and what the reservations did not
+// hold must be available beside other customers' holds. It returns the sold
+// products and whether each went out of stock.
+func (r *orderRepository) takeStock(ctx context.Context, tx pgx.Tx, order *domains.Order, reservationIDs []int) (map[int]bool, error) {
+	ordered := make(map[stockKey]int, len(order.Items))
+	for _, item := range order.Items {
+		if item.ProductID != nil {
+			ordered[newStockKey(*item.ProductID, item.VariantID)] += item.Quantity
+		}
+	}
+
+	if len(reservationIDs) > 0 {
+		reservations, err := r.reservations.ConsumeTx(ctx, tx, reservationIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, reservation := range reservations {
+			key := newStockKey(reservation.ProductID, reservation.VariantID)
+			if ordered[key] < reservation.Quantity {
+				logrus.Infof("Reservation does not match order lines (reservation ID: %d, order ID: %d)", reservation.ID, order.ID)
+				return nil, fmt.Errorf("%w: reservation %d holds more than the order takes", domains.ErrInvalidOrder, reservation.ID)
+			}
+			ordered[key] -= reservation.Quantity
+		}
+	}
+
+	keys := slices.SortedFunc(maps.Keys(ordered), func(a, b stockKey) int {
+		return cmp.Or(cmp.Compare(a.productID, b.productID), cmp.Compare(a.variantID, b.variantID))
+	})
+	for _, key := range keys {
+		var variantID *int
+		if key.variantID != 0 {
+			variantID = &key.variantID
+		}
+		available, err := r.reservations.AvailableTx(ctx, tx, key.productID, variantID)
+		if err != nil {
+			return nil, err
+		}
+		if unreserved := ordered[key]; unreserved > 0 && unreserved > available {
+			logrus.Infof("Insufficient unreserved stock for order (product_id: %d, available: %d, requested: %d)", key.productID, available, unreserved)
+			return nil, domains.ErrInsufficientStock
+		}
+	}
+
+	changed := make(map[int]bool, len(order.Items))
+	reason := fmt.Sprintf("order %d", order.ID)
+	for _, item := range order.Items {
+		if item.ProductID == nil {
+			continue
+		}
+		soldOut, err := r.inventory.SellTx(ctx, tx, *item.ProductID, item.VariantID, item.Quantity, reason)
+		if err != nil {
+			return nil, err
+		}
+		changed[*item.ProductID] = changed[*item.ProductID] || soldOut
+	}
+	return changed, nil
+}
+
+func (r *orderRepository) GetByID(ctx context.Context, id int) (*domains.Order, error) {
+	getQuery := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
+
+	order, err := scanOrder(r.db.QueryRow(ctx, getQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Order not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get order (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	if order.Items, err = r.getItems(ctx, id); err != nil {
+		return nil, err
+	}
+	if order.History, err = r.getHistory(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// List returns orders without lines and history, newest first.
+func (r *orderRepository) List(ctx context.Context, filter *domains.OrderFilter) ([]*domains.Order, error) {
+	var (
+		queryBuilder strings.Builder
+		args         []interface{}
+		conditions   []string
+	)
+
+	queryBuilder.WriteString("SELECT " + orderColumns + " FROM orders")
+
+	argPos := 1
+	if filter.UserID != nil {
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argPos))
+		args = append(args, *filter.UserID)
+		argPos++
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argPos))
+		args = append(args, filter.Status)
+		argPos++
+	}
+	if filter.From != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argPos))
+		args = append(args, *filter.From)
+		argPos++
+	}
+	if filter.To != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", argPos))
+		args = append(args, *filter.To)
+		argPos++
+	}
+	if len(conditions) > 0 {
+		queryBuilder.WriteString(" WHERE " + strings.Join(conditions, " AND "))
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	queryBuilder.WriteString(fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", argPos, argPos+1))
+	args = append(args, limit, filter.Offset)
+
+	query := queryBuilder.String()
+	logrus.Debugf("Order list query: %s, Args: %+v", query, args)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("Failed to list orders: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []*domains.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan order row: %v", err)
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating order rows: %v", err)
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// Transition moves an order to req.Status. The row is locked while the
+// transition is checked so concurrent updates cannot skip a state. Cancelling
+// an order puts its stock back in the same transaction.
+func (r *orderRepository) Transition(ctx context.Context, id int, req *domains.OrderTransitionRequest) (*domains.Order, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	var current domains.OrderStatus
+	if err = tx.QueryRow(ctx, `SELECT status FROM orders WHERE id = $1 FOR UPDATE`, id).Scan(&current); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to transition non-existent order (ID: %d)", id)
+			err = sql.ErrNoRows
+			return nil, err
+		}
+		logrus.Errorf("Failed to lock order (ID: %d): %v", id, err)
+		return nil, err
+	}
+	if !current.CanTransitionTo(req.Status) {
+		logrus.Infof("Rejected order transition (ID: %d, from: %s, to: %s)", id, current, req.Status)
+		err = fmt.Errorf("%w: %s -> %s", domains.ErrInvalidTransition, current, req.Status)
+		return nil, err
+	}
+
+	const updateQuery = `UPDATE orders SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+	if _, err = tx.Exec(ctx, updateQuery, req.Status, id); err != nil {
+		logrus.Errorf("Failed to update order status (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	const insertHistoryQuery = `
+        INSERT INTO order_status_history (order_id, from_status, to_status, note)
+        VALUES ($1, $2, $3, NULLIF($4, ''))`
+	if _, err = tx.Exec(ctx, insertHistoryQuery, id, current, req.Status, req.Note); err != nil {
+		logrus.Errorf("Failed to insert order history (order_id: %d): %v", id, err)
+		return nil, err
+	}
+
+	var stockChanged map[int]bool
+	if req.Status == domains.OrderCancelled {
+		if stockChanged, err = r.restock(ctx, tx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+	for productID, inStockChanged := range stockChanged {
+		r.inventory.Invalidate(ctx, productID, inStockChanged)
+	}
+
+	logrus.Debugf("Order transitioned successfully (ID: %d, from: %s, to: %s)", id, current, req.Status)
+	return r.GetByID(ctx, id)
+}
+
+// restock records a return movement for every line of a cancelled order
+// whose product still exists.
+func (r *orderRepository) restock(ctx context.Context, tx pgx.Tx, orderID int) (map[int]bool, error) {
+	const itemsQuery = `
+        SELECT product_id, variant_id, quantity
+        FROM order_items
+        WHERE order_id = $1 AND product_id IS NOT NULL
+        ORDER BY id`
+
+	rows, err := tx.Query(ctx, itemsQuery, orderID)
+	if err != nil {
+		logrus.Errorf("Failed to query order items for restock (order_id: %d): %v", orderID, err)
+		return nil, err
+	}
+	requests, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domains.StockAdjustmentRequest, error) {
+		req := &domains.StockAdjustmentRequest{Type: domains.MovementReturn, Reason: fmt.Sprintf("order %d cancelled", orderID)}
+		err := row.Scan(&req.ProductID, &req.VariantID, &req.Quantity)
+		return req, err
+	})
+	if err != nil {
+		logrus.Errorf("Failed to scan order items for restock (order_id: %d): %v", orderID, err)
+		return nil, err
+	}
+
+	changed := make(map[int]bool, len(requests))
+	for _, req := range requests {
+		_, inStockChanged, err := r.inventory.AdjustTx(ctx, tx, req, req.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		changed[req.ProductID] = changed[req.ProductID] || inStockChanged
+	}
+	return changed, nil
+}
+
+func (r *orderRepository) getItems(ctx context.Context, orderID int) ([]domains.OrderItem, error) {
+	const itemsQuery = `
+        SELECT id, product_id, variant_id, product_name, COALESCE(sku, ''), quantity, unit_price, line_total, discount,
+               tax, tax_rate
+        FROM order_items
+        WHERE order_id = $1
+        ORDER BY id`
+
+	rows, err := r.db.Query(ctx, itemsQuery, orderID)
+	if err != nil {
+		logrus.Errorf("Failed to query order items (order_id: %d): %v", orderID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domains.OrderItem
+	for rows.Next() {
+		var item domains.OrderItem
+		if err := rows.Scan(
+			&item.ID,
+			&item.ProductID,
+			&item.VariantID,
+			&item.ProductName,
+			&item.SKU,
+			&item.Quantity,
+			&item.UnitPrice,
+			&item.LineTotal,
+			&item.Discount,
+			&item.Tax,
+			&item.TaxRate,
+		); err != nil {
+			logrus.Errorf("Failed to scan order item row: %v", err)
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating order item rows: %v", err)
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *orderRepository) getHistory(ctx context.Context, orderID int) ([]domains.OrderStatusChange, error) {
+	const historyQuery = `
+        SELECT COALESCE(from_status, ''), to_status, COALESCE(note, ''), created_at
+        FROM order_status_history
+        WHERE order_id = $1
+        ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, historyQuery, orderID)
+	if err != nil {
+		logrus.Errorf("Failed to query order history (order_id: %d): %v", orderID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []domains.OrderStatusChange
+	for rows.Next() {
+		var change domains.OrderStatusChange
+		if err := rows.Scan(&change.FromStatus, &change.ToStatus, &change.Note, &change.CreatedAt); err != nil {
+			logrus.Errorf("Failed to scan order history row: %v", err)
+			return nil, err
+		}
+		history = append(history, change)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating order history rows: %v", err)
+		return nil, err
+	}
+
+	return history, nil
+}
@@ -0,0 +1,138 @@
+package order
+
+import (
+	"context"
+	"e-commerce/internal/cart"
+	"e-commerce/internal/domains"
+	"e-commerce/internal/tax"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+type OrderService interface {
+	Checkout(ctx context.Context, req *domains.CheckoutRequest) (*domains.Order, error)
+	GetOrderByID(ctx context.Context, id int) (*domains.Order, error)
+	ListOrders(ctx context.Context, filter *domains.OrderFilter) ([]*domains.Order, error)
+	TransitionOrder(ctx context.Context, id int, req *domains.OrderTransitionRequest) (*domains.Order, error)
+}
+
+// Pricer applies promotions to a cart at checkout and counts their use.
+type Pricer interface {
+	Apply(ctx context.Context, c *domains.Cart, couponCodes []string) (*domains.PricingResult, error)
+	Redeem(ctx context.Context, result *domains.PricingResult) error
+	Release(ctx context.Context, result *domains.PricingResult) error
+}
+
+type orderService struct {
+	repo   OrderRepository
+	carts  cart.CartService
+	pricer Pricer
+	taxes  tax.TaxService
+}
+
+func NewOrderService(repo OrderRepository, carts cart.CartService, pricer Pricer, taxes tax.TaxService) OrderService {
+	return &orderService{repo: repo, carts: carts, pricer: pricer, taxes: taxes}
+}
+
+// Checkout snapshots the priced, discounted and taxed cart into a pending
+// order, takes its stock and clears the cart. Promotions are redeemed before
+// the order is stored and released again if storing fails. A failure to
+// clear the cart does not fail the checkout.
+func (s *orderService) Checkout(ctx context.Context, req *domains.CheckoutRequest) (*domains.Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	ref := cart.CartRef{Token: req.CartToken}
+	if req.UserID != nil {
+		ref.UserID = *req.UserID
+	}
+	c, err := s.carts.GetCart(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Items) == 0 {
+		return nil, fmt.Errorf("%w: cart is empty", domains.ErrInvalidOrder)
+	}
+
+	pricing, err := s.pricer.Apply(ctx, c, req.CouponCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]domains.TaxableLine, 0, len(pricing.Lines))
+	for _, line := range pricing.Lines {
+		lines = append(lines, domains.TaxableLine{ItemID: line.ItemID, ProductID: line.ProductID, Amount: line.Total})
+	}
+	taxes, err := s.taxes.Calculate(ctx, lines, req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &domains.Order{
+		UserID:      req.UserID,
+		Email:       req.Email,
+		Status:      domains.OrderPending,
+		Subtotal:    pricing.Subtotal,
+		Discount:    pricing.Discount,
+		Tax:         taxes.Tax,
+		TaxIncluded: taxes.Mode == domains.TaxInclusive,
+		TaxCountry:  taxes.Country,
+		TaxRegion:   taxes.Region,
+		Total:       taxes.Total,
+		Currency:    c.Currency,
+	}
+	for _, applied := range pricing.AppliedPromotions {
+		order.PromotionIDs = append(order.PromotionIDs, applied.ID)
+	}
+	for i, item := range c.Items {
+		productID := item.ProductID
+		order.Items = append(order.Items, domains.OrderItem{
+			ProductID:   &productID,
+			VariantID:   item.VariantID,
+			ProductName: item.Name,
+			SKU:         item.SKU,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			LineTotal:   pricing.Lines[i].Subtotal,
+			Discount:    pricing.Lines[i].Discount,
+			Tax:         taxes.Lines[i].Tax,
+			TaxRate:     taxes.Lines[i].Rate,
+		})
+	}
+
+	if err := s.pricer.Redeem(ctx, pricing); err != nil {
+		return nil, err
+	}
+	created, err := s.repo.Create(ctx, order, req.ReservationIDs)
+	if err != nil {
+		if releaseErr := s.pricer.Release(ctx, pricing); releaseErr != nil {
+			logrus.Errorf("Failed to release promotions after failed checkout: %v", releaseErr)
+		}
+		return nil, err
+	}
+
+	if err := s.carts.ClearCart(ctx, ref); err != nil {
+		logrus.Warnf("Failed to clear cart after checkout (order ID: %d): %v", created.ID, err)
+	}
+	return created, nil
+}
+
+func (s *orderService) GetOrderByID(ctx context.Context, id int) (*domains.Order, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *orderService) ListOrders(ctx context.Context, filter *domains.OrderFilter) ([]*domains.Order, error) {
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, fmt.Errorf("%w: unknown status %q", domains.ErrInvalidOrder, filter.Status)
+	}
+	return s.repo.List(ctx, filter)
+}
+
+func (s *orderService) TransitionOrder(ctx context.Context, id int, req *domains.OrderTransitionRequest) (*domains.Order, error) {
+	if !req.Status.Valid() {
+		return nil, fmt.Errorf("%w: unknown status %q", domains.ErrInvalidOrder, req.Status)
+	}
+	return s.repo.Transition(ctx, id, req)
+}
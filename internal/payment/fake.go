@@ -0,0 +1,157 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"e-commerce/internal/domains"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// FakeDeclineMethod makes the fake provider decline an authorization.
+const FakeDeclineMethod = "fake_decline"
+
+type fakePayment struct {
+	amount   domains.Money
+	captured domains.Money
+	refunded domains.Money
+	status   domains.PaymentStatus
+}
+
+// FakeProvider is an in-process PaymentProvider for development. It keeps
+// payments in memory, enforces the same state rules as a real gateway and
+// signs webhooks with HMAC-SHA256 over the raw payload. State does not
+// survive a restart.
+type FakeProvider struct {
+	secret []byte
+
+	mu       sync.Mutex
+	payments map[string]*fakePayment
+}
+
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{
+		secret:   []byte(secret),
+		payments: make(map[string]*fakePayment),
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return ProviderFake
+}
+
+func (p *FakeProvider) Authorize(ctx context.Context, req *AuthorizeRequest) (string, error) {
+	if req.PaymentMethod == FakeDeclineMethod {
+		return "", fmt.Errorf("%w: card declined by fake provider", domains.ErrPaymentDeclined)
+	}
+
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	ref := "fake_" + hex.EncodeToString(buf)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.payments[ref] = &fakePayment{amount: req.Amount, status: domains.PaymentAuthorized}
+	return ref, nil
+}
+
+func (p *FakeProvider) Capture(ctx context.Context, ref string, amount domains.Money) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, err := p.lookup(ref)
+	if err != nil {
+		return err
+	}
+	if payment.status != domains.PaymentAuthorized {
+		return fmt.Errorf("%w: cannot capture a payment that is %s", domains.ErrPaymentState, payment.status)
+	}
+	if amount > payment.amount {
+		return fmt.Errorf("%w: capture exceeds authorized amount", domains.ErrInvalidPayment)
+	}
+
+	payment.captured = amount
+	payment.status = domains.PaymentCaptured
+	return nil
+}
+
+func (p *FakeProvider) Refund(ctx context.Context, ref string, amount domains.Money) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, err := p.lookup(ref)
+	if err != nil {
+		return err
+	}
+	if payment.status != domains.PaymentCaptured {
+		return fmt.Errorf("%w: cannot refund a payment that is %s", domains.ErrPaymentState, payment.status)
+	}
+	if payment.refunded+amount > payment.captured {
+		return fmt.Errorf("%w: refund exceeds captured amount", domains.ErrInvalidPayment)
+	}
+
+	payment.refunded += amount
+	if payment.refunded == payment.captured {
+		payment.status = domains.PaymentRefunded
+	}
+	return nil
+}
+
+func (p *FakeProvider) Void(ctx context.Context, ref string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, err := p.lookup(ref)
+	if err != nil {
+		return err
+	}
+	if payment.status != domains.PaymentAuthorized {
+		return fmt.Errorf("%w: cannot void a payment that is %s", domains.ErrPaymentState, payment.status)
+	}
+
+	payment.status = domains.PaymentVoided
+	return nil
+}
+
+func (p *FakeProvider) VerifyWebhook(payload []byte, signature string) (*domains.WebhookEvent, error) {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, p.sign(payload)) {
+		return nil, domains.ErrInvalidSignature
+	}
+
+	var event domains.WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", domains.ErrInvalidPayment, err)
+	}
+	if event.ID == "" || event.ProviderRef == "" {
+		return nil, fmt.Errorf("%w: webhook event id and provider_ref are required", domains.ErrInvalidPayment)
+	}
+	return &event, nil
+}
+
+// SignWebhook returns the signature VerifyWebhook expects for payload, so
+// webhook deliveries can be simulated during development.
+func (p *FakeProvider) SignWebhook(payload []byte) string {
+	return hex.EncodeToString(p.sign(payload))
+}
+
+func (p *FakeProvider) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func (p *FakeProvider) lookup(ref string) (*fakePayment, error) {
+	payment, ok := p.payments[ref]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown payment reference %q", domains.ErrInvalidPayment, ref)
+	}
+	return payment, nil
+}
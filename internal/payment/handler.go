@@ -0,0 +1,264 @@
+package payment
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+)
+
+const signatureHeader = "X-Signature"
+
+type PaymentHandler struct {
+	service PaymentService
+}
+
+func NewPaymentHandler(service PaymentService) *PaymentHandler {
+	return &PaymentHandler{service: service}
+}
+
+func (h *PaymentHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/orders/:id/payments", h.Authorize)
+	router.GET("/orders/:id/payments", h.GetOrderPayments)
+	router.GET("/payments/:id", h.GetPaymentByID)
+	router.POST("/payments/:id/capture", h.Capture)
+	router.POST("/payments/:id/refund", h.Refund)
+	router.POST("/payments/:id/void", h.Void)
+	router.POST("/webhooks/payments/:provider", h.HandleWebhook)
+}
+
+// @Summary Authorize payment
+// @Description Authorize the total of a pending order with the configured payment provider
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param payment body domains.PaymentRequest true "Payment method"
+// @Success 201 {object} domains.Payment
+// @Failure 400 {object} domains.Error
+// @Failure 402 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /orders/{id}/payments [post]
+func (h *PaymentHandler) Authorize(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	var req domains.PaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payment, err := h.service.Authorize(c.Request.Context(), orderID, &req)
+	if err != nil {
+		c.JSON(paymentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, payment)
+}
+
+// @Summary Get order payments
+// @Description Get all payment attempts of an order
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {array} domains.Payment
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /orders/{id}/payments [get]
+func (h *PaymentHandler) GetOrderPayments(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	payments, err := h.service.GetOrderPayments(c.Request.Context(), orderID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payments)
+}
+
+// @Summary Get payment by ID
+// @Description Get a payment by its ID
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment ID"
+// @Success 200 {object} domains.Payment
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /payments/{id} [get]
+func (h *PaymentHandler) GetPaymentByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payment id"})
+		return
+	}
+
+	payment, err := h.service.GetPaymentByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(paymentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payment)
+}
+
+// @Summary Capture payment
+// @Description Capture an authorized payment and mark its order as paid
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment ID"
+// @Success 200 {object} domains.Payment
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /payments/{id}/capture [post]
+func (h *PaymentHandler) Capture(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payment id"})
+		return
+	}
+
+	payment, err := h.service.Capture(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(paymentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payment)
+}
+
+// @Summary Refund payment
+// @Description Refund part or all of a captured payment. A full refund marks the order as refunded.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment ID"
+// @Param refund body domains.RefundRequest false "Refund amount"
+// @Success 200 {object} domains.Payment
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /payments/{id}/refund [post]
+func (h *PaymentHandler) Refund(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payment id"})
+		return
+	}
+
+	var req domains.RefundRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	payment, err := h.service.Refund(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(paymentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payment)
+}
+
+// @Summary Void payment
+// @Description Release an authorized payment and cancel its order
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment ID"
+// @Success 200 {object} domains.Payment
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /payments/{id}/void [post]
+func (h *PaymentHandler) Void(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payment id"})
+		return
+	}
+
+	payment, err := h.service.Void(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(paymentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payment)
+}
+
+// @Summary Payment provider webhook
+// @Description Receive a signed provider notification and update the payment and order. Redelivered events are acknowledged without being applied again.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Param X-Signature header string true "Hex-encoded HMAC-SHA256 of the raw body"
+// @Param event body domains.WebhookEvent true "Webhook event"
+// @Success 200 {object} domains.WebhookResponse
+// @Failure 400 {object} domains.Error
+// @Failure 401 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /webhooks/payments/{provider} [post]
+func (h *PaymentHandler) HandleWebhook(c *gin.Context) {
+	payload, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	processed, err := h.service.HandleWebhook(c.Request.Context(), c.Param("provider"), payload, c.GetHeader(signatureHeader))
+	if err != nil {
+		c.JSON(paymentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	status := "processed"
+	if !processed {
+		status = "duplicate"
+	}
+	c.JSON(http.StatusOK, domains.WebhookResponse{Status: status})
+}
+
+func paymentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domains.ErrInvalidPayment):
+		return http.StatusBadRequest
+	case errors.Is(err, domains.ErrInvalidSignature):
+		return http.StatusUnauthorized
+	case errors.Is(err, domains.ErrPaymentDeclined):
+		return http.StatusPaymentRequired
+	case errors.Is(err, domains.ErrPaymentState), errors.Is(err, domains.ErrInvalidTransition):
+		return http.StatusConflict
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,36 @@
+package payment
+
+import (
+	"context"
+	"e-commerce/internal/config"
+	"e-commerce/internal/domains"
+	"fmt"
+)
+
+const ProviderFake = "fake"
+
+type AuthorizeRequest struct {
+	OrderID       int
+	Amount        domains.Money
+	PaymentMethod string
+}
+
+// PaymentProvider is a payment gateway. Amounts are in the order currency.
+// Authorize returns the provider reference used by every later call.
+type PaymentProvider interface {
+	Name() string
+	Authorize(ctx context.Context, req *AuthorizeRequest) (string, error)
+	Capture(ctx context.Context, ref string, amount domains.Money) error
+	Refund(ctx context.Context, ref string, amount domains.Money) error
+	Void(ctx context.Context, ref string) error
+	VerifyWebhook(payload []byte, signature string) (*domains.WebhookEvent, error)
+}
+
+func NewProvider(cfg *config.PaymentConfig) (PaymentProvider, error) {
+	switch cfg.Provider {
+	case "", ProviderFake:
+		return NewFakeProvider(cfg.WebhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.Provider)
+	}
+}
@@ -0,0 +1,272 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/domains"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type PaymentRepository interface {
+	Create(ctx context.Context, payment *domains.Payment) (*domains.Payment, error)
+	GetByID(ctx context.Context, id int) (*domains.Payment, error)
+	GetByProviderRef(ctx context.Context, provider, ref string) (*domains.Payment, error)
+	GetByOrderID(ctx context.Context, orderID int) ([]*domains.Payment, error)
+	UpdateState(ctx context.Context, payment *domains.Payment, from domains.PaymentStatus) (*domains.Payment, error)
+	AddRefund(ctx context.Context, id int, amount domains.Money) (*domains.Payment, error)
+	RaiseRefunded(ctx context.Context, id int, refunded domains.Money) (*domains.Payment, error)
+	RecordEvent(ctx context.Context, provider string, event *domains.WebhookEvent, payload []byte) (bool, error)
+	DeleteEvent(ctx context.Context, provider, eventID string) error
+}
+
+type paymentRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPaymentRepository(db *pgxpool.Pool) PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+// livePaymentIndex allows one authorized or captured payment per order.
+const livePaymentIndex = "idx_payments_live_order"
+
+const paymentColumns = `
+        id, order_id, provider, COALESCE(provider_ref, ''), status, amount, captured_amount,
+        refunded_amount, COALESCE(failure_reason, ''), created_at, updated_at`
+
+func scanPayment(row pgx.Row) (*domains.Payment, error) {
+	payment := &domains.Payment{}
+	err := row.Scan(
+		&payment.ID,
+		&payment.OrderID,
+		&payment.Provider,
+		&payment.ProviderRef,
+		&payment.Status,
+		&payment.Amount,
+		&payment.CapturedAmount,
+		&payment.RefundedAmount,
+		&payment.FailureReason,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+	return payment, err
+}
+
+func (r *paymentRepository) Create(ctx context.Context, payment *domains.Payment) (*domains.Payment, error) {
+	insertQuery := `
+        INSERT INTO payments (order_id, provider, provider_ref, status, amount, failure_reason)
+        VALUES ($1, $2, NULLIF($3, ''), $4, $5, NULLIF($6, ''))
+        RETURNING ` + paymentColumns
+
+	created, err := scanPayment(r.db.QueryRow(ctx, insertQuery,
+		payment.OrderID,
+		payment.Provider,
+		payment.ProviderRef,
+		payment.Status,
+		payment.Amount,
+		payment.FailureReason,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == livePaymentIndex {
+			logrus.Infof("Rejected second live payment (order_id: %d)", payment.OrderID)
+			return nil, fmt.Errorf("%w: order already has a live payment", domains.ErrPaymentState)
+		}
+		logrus.WithError(err).WithField("payment", payment).Error("Failed to insert payment")
+		return nil, err
+	}
+
+	logrus.Debugf("Payment created successfully (ID: %d, order_id: %d, status: %s)", created.ID, created.OrderID, created.Status)
+	return created, nil
+}
+
+func (r *paymentRepository) GetByID(ctx context.Context, id int) (*domains.Payment, error) {
+	getQuery := `SELECT ` + paymentColumns + ` FROM payments WHERE id = $1`
+
+	payment, err := scanPayment(r.db.QueryRow(ctx, getQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Payment not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get payment (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (r *paymentRepository) GetByProviderRef(ctx context.Context, provider, ref string) (*domains.Payment, error) {
+	getQuery := `SELECT ` + paymentColumns + ` FROM payments WHERE provider = $1 AND provider_ref = $2`
+
+	payment, err := scanPayment(r.db.QueryRow(ctx, getQuery, provider, ref))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Payment not found (provider: %s, ref: %s)", provider, ref)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get payment (provider: %s, ref: %s): %v", provider, ref, err)
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (r *paymentRepository) GetByOrderID(ctx context.Context, orderID int) ([]*domains.Payment, error) {
+	listQuery := `SELECT ` + paymentColumns + ` FROM payments WHERE order_id = $1 ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, listQuery, orderID)
+	if err != nil {
+		logrus.Errorf("Failed to query payments (order_id: %d): %v", orderID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []*domains.Payment
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan payment row: %v", err)
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating payment rows: %v", err)
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+// UpdateState stores the payment's status and amounts if it is still in the
+// from status, so of two concurrent transitions only one takes effect; the
+// other fails with ErrPaymentState.
+func (r *paymentRepository) UpdateState(ctx context.Context, payment *domains.Payment, from domains.PaymentStatus) (*domains.Payment, error) {
+	updateQuery := `
+        UPDATE payments
+        SET status = $1, captured_amount = $2, refunded_amount = $3,
+            failure_reason = NULLIF($4, ''), updated_at = CURRENT_TIMESTAMP
+        WHERE id = $5 AND status = $6
+        RETURNING ` + paymentColumns
+
+	updated, err := scanPayment(r.db.QueryRow(ctx, updateQuery,
+		payment.Status,
+		payment.CapturedAmount,
+		payment.RefundedAmount,
+		payment.FailureReason,
+		payment.ID,
+		from,
+	))
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			logrus.Errorf("Failed to update payment (ID: %d): %v", payment.ID, err)
+			return nil, err
+		}
+		current, err := r.GetByID(ctx, payment.ID)
+		if err != nil {
+			return nil, err
+		}
+		logrus.Infof("Attempted to move payment from %s to %s while it is %s (ID: %d)", from, payment.Status, current.Status, payment.ID)
+		return nil, fmt.Errorf("%w: payment is already %s", domains.ErrPaymentState, current.Status)
+	}
+
+	logrus.Debugf("Payment updated successfully (ID: %d, status: %s)", updated.ID, updated.Status)
+	return updated, nil
+}
+
+// AddRefund adds amount to the refunded total in a single conditional
+// UPDATE, so concurrent refunds can never take it past the captured amount.
+// A negative amount takes back a refund the provider did not carry out. The
+// payment is refunded exactly while the whole captured amount is.
+func (r *paymentRepository) AddRefund(ctx context.Context, id int, amount domains.Money) (*domains.Payment, error) {
+	updateQuery := `
+        UPDATE payments
+        SET refunded_amount = refunded_amount + $1,
+            status = CASE WHEN refunded_amount + $1 = captured_amount THEN 'refunded' ELSE 'captured' END,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2 AND status IN ('captured', 'refunded')
+          AND refunded_amount + $1 BETWEEN 0 AND captured_amount
+        RETURNING ` + paymentColumns
+
+	updated, err := scanPayment(r.db.QueryRow(ctx, updateQuery, amount, id))
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			logrus.Errorf("Failed to add refund (ID: %d, amount: %s): %v", id, amount, err)
+			return nil, err
+		}
+		current, err := r.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if current.Status != domains.PaymentCaptured && current.Status != domains.PaymentRefunded {
+			logrus.Infof("Attempted to refund payment in state %s (ID: %d)", current.Status, id)
+			return nil, fmt.Errorf("%w: cannot refund a payment that is %s", domains.ErrPaymentState, current.Status)
+		}
+		logrus.Infof("Rejected refund beyond captured amount (ID: %d, amount: %s)", id, amount)
+		return nil, fmt.Errorf("%w: refund exceeds the remaining %s", domains.ErrInvalidPayment, current.CapturedAmount-current.RefundedAmount)
+	}
+
+	logrus.Debugf("Refund added successfully (ID: %d, amount: %s, refunded: %s)", updated.ID, amount, updated.RefundedAmount)
+	return updated, nil
+}
+
+// RaiseRefunded sets the refunded total reported by the provider. The total
+// only ever grows, so a stale report leaves the payment as it is.
+func (r *paymentRepository) RaiseRefunded(ctx context.Context, id int, refunded domains.Money) (*domains.Payment, error) {
+	updateQuery := `
+        UPDATE payments
+        SET refunded_amount = $1,
+            status = CASE WHEN $1 = captured_amount THEN 'refunded' ELSE 'captured' END,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2 AND status IN ('captured', 'refunded')
+          AND refunded_amount < $1 AND $1 <= captured_amount
+        RETURNING ` + paymentColumns
+
+	updated, err := scanPayment(r.db.QueryRow(ctx, updateQuery, refunded, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Refunded total not raised (ID: %d, refunded: %s)", id, refunded)
+			return r.GetByID(ctx, id)
+		}
+		logrus.Errorf("Failed to raise refunded total (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Refunded total raised successfully (ID: %d, refunded: %s)", updated.ID, updated.RefundedAmount)
+	return updated, nil
+}
+
+// RecordEvent stores a webhook delivery and reports whether it is new. An
+// event seen before is left untouched.
+func (r *paymentRepository) RecordEvent(ctx context.Context, provider string, event *domains.WebhookEvent, payload []byte) (bool, error) {
+	const insertQuery = `
+        INSERT INTO payment_webhook_events (provider, event_id, event_type, payload)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (provider, event_id) DO NOTHING`
+
+	tag, err := r.db.Exec(ctx, insertQuery, provider, event.ID, event.Type, payload)
+	if err != nil {
+		logrus.Errorf("Failed to record webhook event (provider: %s, ID: %s): %v", provider, event.ID, err)
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// DeleteEvent forgets a webhook delivery that could not be applied, so the
+// provider's retry is processed again.
+func (r *paymentRepository) DeleteEvent(ctx context.Context, provider, eventID string) error {
+	const deleteQuery = `DELETE FROM payment_webhook_events WHERE provider = $1 AND event_id = $2`
+
+	if _, err := r.db.Exec(ctx, deleteQuery, provider, eventID); err != nil {
+		logrus.Errorf("Failed to delete webhook event (provider: %s, ID: %s): %v", provider, eventID, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package payment
+
+import (
+	"context"
+	"e-commerce/internal/domains"
+	"e-commerce/internal/order"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+type PaymentService interface {
+	Authorize(ctx context.Context, orderID int, req *domains.PaymentRequest) (*domains.Payment, error)
+	GetPaymentByID(ctx context.Context, id int) (*domains.Payment, error)
+	GetOrderPayments(ctx context.Context, orderID int) ([]*domains.Payment, error)
+	Capture(ctx context.Context, id int) (*domains.Payment, error)
+	Refund(ctx context.Context, id int, req *domains.RefundRequest) (*domains.Payment, error)
+	Void(ctx context.Context, id int) (*domains.Payment, error)
+	HandleWebhook(ctx context.Context, provider string, payload []byte, signature string) (bool, error)
+}
+
+type paymentService struct {
+	repo     PaymentRepository
+	provider PaymentProvider
+	orders   order.OrderService
+}
+
+func NewPaymentService(repo PaymentRepository, provider PaymentProvider, orders order.OrderService) PaymentService {
+	return &paymentService{repo: repo, provider: provider, orders: orders}
+}
+
+// Authorize reserves the order total with the provider. Declined attempts are
+// recorded as failed payments and reported with ErrPaymentDeclined. An order
+// has at most one authorized or captured payment; an authorization that
+// loses a race for the order is voided again.
+func (s *paymentService) Authorize(ctx context.Context, orderID int, req *domains.PaymentRequest) (*domains.Payment, error) {
+	if req.PaymentMethod == "" {
+		return nil, fmt.Errorf("%w: payment_method is required", domains.ErrInvalidPayment)
+	}
+
+	o, err := s.orders.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.Status != domains.OrderPending {
+		return nil, fmt.Errorf("%w: order is %s", domains.ErrPaymentState, o.Status)
+	}
+	payments, err := s.repo.GetByOrderID(ctx, o.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range payments {
+		if existing.Status == domains.PaymentAuthorized || existing.Status == domains.PaymentCaptured {
+			return nil, fmt.Errorf("%w: order already has a live payment", domains.ErrPaymentState)
+		}
+	}
+
+	payment := &domains.Payment{
+		OrderID:  o.ID,
+		Provider: s.provider.Name(),
+		Status:   domains.PaymentAuthorized,
+		Amount:   o.Total,
+	}
+	ref, authErr := s.provider.Authorize(ctx, &AuthorizeRequest{
+		OrderID:       o.ID,
+		Amount:        o.Total,
+		PaymentMethod: req.PaymentMethod,
+	})
+	if authErr != nil {
+		if !errors.Is(authErr, domains.ErrPaymentDeclined) {
+			return nil, authErr
+		}
+		payment.Status = domains.PaymentFailed
+		payment.FailureReason = authErr.Error()
+	}
+	payment.ProviderRef = ref
+
+	created, err := s.repo.Create(ctx, payment)
+	if err != nil {
+		if authErr == nil && errors.Is(err, domains.ErrPaymentState) {
+			if voidErr := s.provider.Void(ctx, ref); voidErr != nil {
+				logrus.Errorf("Failed to void duplicate authorization (order ID: %d, ref: %s): %v", o.ID, ref, voidErr)
+			}
+		}
+		return nil, err
+	}
+	if authErr != nil {
+		return nil, authErr
+	}
+	return created, nil
+}
+
+func (s *paymentService) GetPaymentByID(ctx context.Context, id int) (*domains.Payment, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *paymentService) GetOrderPayments(ctx context.Context, orderID int) ([]*domains.Payment, error) {
+	return s.repo.GetByOrderID(ctx, orderID)
+}
+
+// Capture collects the authorized amount and marks the order as paid. An
+// order that is no longer pending, e.g. because it was cancelled, is not
+// charged.
+func (s *paymentService) Capture(ctx context.Context, id int) (*domains.Payment, error) {
+	payment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status != domains.PaymentAuthorized {
+		return nil, fmt.Errorf("%w: cannot capture a payment that is %s", domains.ErrPaymentState, payment.Status)
+	}
+	o, err := s.orders.GetOrderByID(ctx, payment.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.Status != domains.OrderPending {
+		return nil, fmt.Errorf("%w: order is %s", domains.ErrPaymentState, o.Status)
+	}
+
+	if err := s.provider.Capture(ctx, payment.ProviderRef, payment.Amount); err != nil {
+		return nil, err
+	}
+	return s.applyCapture(ctx, payment, payment.Amount)
+}
+
+// Refund returns part or all of the captured amount. A full refund moves the
+// order to refunded. The amount is added to the refunded total before the
+// provider is called, so concurrent refunds cannot both pass the remaining
+// check, and taken back if the provider fails.
+func (s *paymentService) Refund(ctx context.Context, id int, req *domains.RefundRequest) (*domains.Payment, error) {
+	payment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status != domains.PaymentCaptured {
+		return nil, fmt.Errorf("%w: cannot refund a payment that is %s", domains.ErrPaymentState, payment.Status)
+	}
+
+	remaining := payment.CapturedAmount - payment.RefundedAmount
+	amount := remaining
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+	if amount <= 0 || amount > remaining {
+		return nil, fmt.Errorf("%w: refund amount must be between 0.01 and %s", domains.ErrInvalidPayment, remaining)
+	}
+
+	claimed, err := s.repo.AddRefund(ctx, id, amount)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.provider.Refund(ctx, payment.ProviderRef, amount); err != nil {
+		if _, undoErr := s.repo.AddRefund(ctx, id, -amount); undoErr != nil {
+			logrus.Errorf("Failed to take back refund after provider error (ID: %d, amount: %s): %v", id, amount, undoErr)
+		}
+		return nil, err
+	}
+	return s.settleRefund(ctx, claimed)
+}
+
+// Void releases an authorization that will not be captured and cancels the
+// order.
+func (s *paymentService) Void(ctx context.Context, id int) (*domains.Payment, error) {
+	payment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status != domains.PaymentAuthorized {
+		return nil, fmt.Errorf("%w: cannot void a payment that is %s", domains.ErrPaymentState, payment.Status)
+	}
+
+	if err := s.provider.Void(ctx, payment.ProviderRef); err != nil {
+		return nil, err
+	}
+	return s.applyVoid(ctx, payment)
+}
+
+// HandleWebhook verifies and applies a provider notification. It reports
+// false for an event that was already processed. The event is recorded
+// first, so concurrent deliveries of the same event are applied only once;
+// if applying it fails the record is removed again and the provider's retry
+// is processed from scratch. Every step is idempotent.
+func (s *paymentService) HandleWebhook(ctx context.Context, provider string, payload []byte, signature string) (bool, error) {
+	if provider != s.provider.Name() {
+		return false, fmt.Errorf("%w: unknown provider %q", domains.ErrInvalidPayment, provider)
+	}
+
+	event, err := s.provider.VerifyWebhook(payload, signature)
+	if err != nil {
+		return false, err
+	}
+
+	recorded, err := s.repo.RecordEvent(ctx, provider, event, payload)
+	if err != nil {
+		return false, err
+	}
+	if !recorded {
+		logrus.Infof("Skipping duplicate webhook event (provider: %s, ID: %s)", provider, event.ID)
+		return false, nil
+	}
+
+	if err := s.applyEvent(ctx, provider, event); err != nil {
+		if deleteErr := s.repo.DeleteEvent(ctx, provider, event.ID); deleteErr != nil {
+			logrus.Errorf("Failed to forget unapplied webhook event (provider: %s, ID: %s): %v", provider, event.ID, deleteErr)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *paymentService) applyEvent(ctx context.Context, provider string, event *domains.WebhookEvent) error {
+	payment, err := s.repo.GetByProviderRef(ctx, provider, event.ProviderRef)
+	if err != nil {
+		return err
+	}
+
+	switch event.Type {
+	case domains.WebhookPaymentCaptured:
+		if payment.Status == domains.PaymentAuthorized {
+			amount := event.Amount
+			if amount == 0 {
+				amount = payment.Amount
+			}
+			_, err = s.applyCapture(ctx, payment, amount)
+		} else {
+			err = s.advanceOrder(ctx, payment.OrderID, domains.OrderPaid, "payment captured")
+		}
+	case domains.WebhookPaymentRefunded:
+		if event.Amount > payment.CapturedAmount {
+			return fmt.Errorf("%w: refund exceeds captured amount", domains.ErrInvalidPayment)
+		}
+		if event.Amount > payment.RefundedAmount {
+			var updated *domains.Payment
+			if updated, err = s.repo.RaiseRefunded(ctx, payment.ID, event.Amount); err == nil {
+				_, err = s.settleRefund(ctx, updated)
+			}
+		}
+	case domains.WebhookPaymentVoided:
+		if payment.Status == domains.PaymentAuthorized {
+			_, err = s.applyVoid(ctx, payment)
+		}
+	case domains.WebhookPaymentFailed:
+		if payment.Status == domains.PaymentAuthorized {
+			payment.Status = domains.PaymentFailed
+			payment.FailureReason = event.Reason
+			_, err = s.repo.UpdateState(ctx, payment, domains.PaymentAuthorized)
+		}
+	default:
+		logrus.Infof("Ignoring unsupported webhook event type (provider: %s, type: %s)", provider, event.Type)
+	}
+	return err
+}
+
+func (s *paymentService) applyCapture(ctx context.Context, payment *domains.Payment, amount domains.Money) (*domains.Payment, error) {
+	payment.Status = domains.PaymentCaptured
+	payment.CapturedAmount = amount
+	updated, err := s.repo.UpdateState(ctx, payment, domains.PaymentAuthorized)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.advanceOrder(ctx, payment.OrderID, domains.OrderPaid, "payment captured"); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// settleRefund moves the order to refunded once the whole captured amount
+// has been refunded.
+func (s *paymentService) settleRefund(ctx context.Context, payment *domains.Payment) (*domains.Payment, error) {
+	if payment.Status == domains.PaymentRefunded {
+		if err := s.advanceOrder(ctx, payment.OrderID, domains.OrderRefunded, "payment refunded"); err != nil {
+			return nil, err
+		}
+	}
+	return payment, nil
+}
+
+func (s *paymentService) applyVoid(ctx context.Context, payment *domains.Payment) (*domains.Payment, error) {
+	payment.Status = domains.PaymentVoided
+	updated, err := s.repo.UpdateState(ctx, payment, domains.PaymentAuthorized)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.advanceOrder(ctx, payment.OrderID, domains.OrderCancelled, "payment voided"); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// advanceOrder moves the order to status unless it is already there.
+func (s *paymentService) advanceOrder(ctx context.Context, orderID int, status domains.OrderStatus, note string) error {
+	o, err := s.orders.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if o.Status == status {
+		return nil
+	}
+
+	_, err = s.orders.TransitionOrder(ctx, orderID, &domains.OrderTransitionRequest{
+		Status: status,
+		Note:   note,
+	})
+	return err
+}
@@ -1,6 +1,8 @@
 package product
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -34,6 +36,8 @@ func (h *productHandler) RegisterRoutes(router *gin.Engine) {
 	router.POST("/products/:id/images", h.uploadProductImage)
 	router.DELETE("/products/images/:imageID", h.deleteProductImage)
 	router.GET("/products/:id/images", h.getProductImages)
+
+	router.GET("/products/:id/price-history", h.getPriceHistory)
 }
 
 // @Summary Create a new product
@@ -55,7 +59,7 @@ func (h *productHandler) createProduct(c *gin.Context) {
 
 	createdProduct, err := h.service.CreateProduct(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -68,6 +72,8 @@ func (h *productHandler) createProduct(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Product ID"
+// @Param currency query string false "Currency to show prices in (defaults to the base currency)"
+// @Param Accept-Currency header string false "Currency to show prices in, used when the query parameter is absent"
 // @Success 200 {object} domains.ProductResponse
 // @Failure 400 {object} domains.Error
 // @Failure 404 {object} domains.Error
@@ -80,9 +86,9 @@ func (h *productHandler) getProductByID(c *gin.Context) {
 		return
 	}
 
-	product, err := h.service.GetProductByID(c.Request.Context(), id)
+	product, err := h.service.GetProductByID(c.Request.Context(), id, requestCurrency(c))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -116,7 +122,7 @@ func (h *productHandler) updateProduct(c *gin.Context) {
 
 	updatedProduct, err := h.service.UpdateProduct(c.Request.Context(), id, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -154,13 +160,16 @@ func (h *productHandler) deleteProduct(c *gin.Context) {
 // @Tags products
 // @Accept json
 // @Produce json
+// @Param currency query string false "Currency to show prices in (defaults to the base currency)"
+// @Param Accept-Currency header string false "Currency to show prices in, used when the query parameter is absent"
 // @Success 200 {array} domains.ProductResponse
+// @Failure 400 {object} domains.Error
 // @Failure 500 {object} domains.Error
 // @Router /products [get]
 func (h *productHandler) getAllProducts(c *gin.Context) {
-	products, err := h.service.GetAllProducts(c.Request.Context())
+	products, err := h.service.GetAllProducts(c.Request.Context(), requestCurrency(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -175,31 +184,61 @@ func (h *productHandler) getAllProducts(c *gin.Context) {
 // @Param skin-type query string false "Comma-separated list of skin type IDs"
 // @Param brand query string false "Comma-separated list of brand IDs"
 // @Param category query string false "Comma-separated list of category IDs"
-// @Param min_price query number false "Minimum price"
-// @Param max_price query number false "Maximum price"
+// @Param min_price query string false "Minimum price in the requested currency, with at most two decimal places (matched against variant prices when the product has variants)"
+// @Param max_price query string false "Maximum price in the requested currency, with at most two decimal places (matched against variant prices when the product has variants)"
+// @Param in_stock query bool false "Only products with stock on hand"
+// @Param with_ingredient query string false "Comma-separated list of ingredient IDs the product must all contain"
+// @Param without_ingredient query string false "Comma-separated list of ingredient IDs the product must not contain, e.g. fragrance or alcohol"
+// @Param min_rating query number false "Minimum average rating from approved reviews, between 1 and 5"
+// @Param sort query string false "Sort order: rating sorts the best rated products first (defaults to product ID)" Enums(rating)
+// @Param currency query string false "Currency to show prices in (defaults to the base currency)"
+// @Param Accept-Currency header string false "Currency to show prices in, used when the query parameter is absent"
 // @Success 200 {array} domains.ProductResponse
+// @Failure 400 {object} domains.Error
 // @Failure 500 {object} domains.Error
 // @Router /products/filter [get]
 func (h *productHandler) getProductsByFilter(c *gin.Context) {
-	skinTypeIDs := parseIDs(c.Query("skin-type"))
-	brandIDs := parseIDs(c.Query("brand"))
-	categoryIDs := parseIDs(c.Query("category"))
+	filter := domains.ProductFilter{
+		SkinTypeIDs:          parseIDs(c.Query("skin-type")),
+		BrandIDs:             parseIDs(c.Query("brand")),
+		CategoryIDs:          parseIDs(c.Query("category")),
+		PriceRange:           &domains.PriceRange{},
+		InStock:              c.Query("in_stock") == "true",
+		WithIngredientIDs:    parseIDs(c.Query("with_ingredient")),
+		WithoutIngredientIDs: parseIDs(c.Query("without_ingredient")),
+		Sort:                 domains.ProductSort(c.Query("sort")),
+		Currency:             requestCurrency(c),
+	}
+
+	if minRating := c.Query("min_rating"); minRating != "" {
+		rating, err := strconv.ParseFloat(minRating, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid min_rating"})
+			return
+		}
+		filter.MinRating = &rating
+	}
 
-	var priceRange domains.PriceRange
 	if minPrice := c.Query("min_price"); minPrice != "" {
-		if price, err := strconv.ParseFloat(minPrice, 64); err == nil {
-			priceRange.MinPrice = &price
+		price, err := domains.ParseMoney(minPrice)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
+		filter.PriceRange.MinPrice = &price
 	}
 	if maxPrice := c.Query("max_price"); maxPrice != "" {
-		if price, err := strconv.ParseFloat(maxPrice, 64); err == nil {
-			priceRange.MaxPrice = &price
+		price, err := domains.ParseMoney(maxPrice)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
+		filter.PriceRange.MaxPrice = &price
 	}
 
-	products, err := h.service.GetProductsByFilter(c.Request.Context(), skinTypeIDs, brandIDs, categoryIDs, &priceRange)
+	products, err := h.service.GetProductsByFilter(c.Request.Context(), &filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -306,6 +345,54 @@ func (h *productHandler) getProductImages(c *gin.Context) {
 	c.JSON(http.StatusOK, images)
 }
 
+// @Summary Get product price history
+// @Description Get every price and sale a product has had, newest first
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {array} domains.ProductPrice
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /products/{id}/price-history [get]
+func (h *productHandler) getPriceHistory(c *gin.Context) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	history, err := h.service.GetPriceHistory(c.Request.Context(), productID)
+	if err != nil {
+		c.JSON(productErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+func productErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domains.ErrInvalidProduct), errors.Is(err, domains.ErrInvalidCurrency),
+		errors.Is(err, domains.ErrInvalidMoney):
+		return http.StatusBadRequest
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// requestCurrency returns the currency asked for by the ?currency= query
+// parameter or, failing that, the Accept-Currency header.
+func requestCurrency(c *gin.Context) string {
+	if code := c.Query("currency"); code != "" {
+		return code
+	}
+	return c.GetHeader("Accept-Currency")
+}
+
 func parseIDs(param string) []int {
 	var ids []int
 	if param == "" {
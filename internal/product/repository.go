@@ -6,6 +6,7 @@ import (
 	"e-commerce/internal/cache"
 	"e-commerce/internal/domains"
 	"e-commerce/internal/imagestorage"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -24,26 +25,64 @@ type ProductRepository interface {
 	Update(ctx context.Context, id int, req *domains.ProductRequest) (*domains.ProductResponse, error)
 	Delete(ctx context.Context, id int) error
 	GetAll(ctx context.Context) ([]*domains.ProductResponse, error)
-	GetByFilter(ctx context.Context, skinTypeIDs []int, brandIDs []int, categoryIDs []int, priceRange *domains.PriceRange) ([]*domains.ProductResponse, error)
+	GetByFilter(ctx context.Context, filter *domains.ProductFilter) ([]*domains.ProductResponse, error)
+	WarmFilter(ctx context.Context, filter *domains.ProductFilter) error
+	GetPopularFilters(ctx context.Context, limit int) ([]*domains.ProductFilter, error)
 	UploadImage(ctx context.Context, productID int, file io.Reader, isMain bool, altText string) (*domains.ProductImage, error)
 	DeleteImage(ctx context.Context, imageID int) error
 	GetProductImages(ctx context.Context, productID int) ([]*domains.ProductImage, error)
+	GetPriceHistory(ctx context.Context, productID int) ([]*domains.ProductPrice, error)
 }
 
 type productRepository struct {
 	db           *pgxpool.Pool
 	cache        cache.CacheRepository[domains.ProductResponse]
+	filterStats  cache.PopularityTracker
 	imageStorage imagestorage.ImageStorageRepository
 }
 
-func NewProductRepository(db *pgxpool.Pool, redisClient *redis.Client, minioClient *minio.Client) ProductRepository {
+func NewProductRepository(db *pgxpool.Pool, cacheClient *cache.Cache, minioClient *minio.Client) ProductRepository {
 	return &productRepository{
 		db:           db,
-		cache:        cache.NewCacheRepository[domains.ProductResponse](redisClient, "product"),
+		cache:        cache.NewCacheRepository[domains.ProductResponse](cacheClient, "product"),
+		filterStats:  cache.NewPopularityTracker(cacheClient, "product:filter"),
 		imageStorage: imagestorage.NewImageStorageRepository(minioClient, "products"),
 	}
 }
 
+const insertIngredientQuery = `
+    INSERT INTO product_ingredients (product_id, ingredient_id, position)
+    VALUES ($1, $2, $3)`
+
+// concerns keeps a missing list from being stored as NULL.
+func concerns(req *domains.ProductRequest) []domains.Concern {
+	if req.Concerns == nil {
+		return []domains.Concern{}
+	}
+	return req.Concerns
+}
+
+const currentPriceExpr = "product_current_price(p.price, p.sale_price, p.sale_starts_at, p.sale_ends_at)"
+
+// convertedPriceExpr converts the base-currency price expr into minor units
+// of another currency the way currency.Conversion.Amount does: in double
+// precision, rounded to a multiple of the increment. The rate and increment
+// are the parameters at ratePos and incrementPos.
+func convertedPriceExpr(expr, mode string, ratePos, incrementPos int) string {
+	steps := fmt.Sprintf("(%s * 100)::float8 * $%d::float8 / $%d::float8", expr, ratePos, incrementPos)
+	switch mode {
+	case domains.RoundUp:
+		steps = fmt.Sprintf("CEIL(%s - 1e-9)", steps)
+	case domains.RoundDown:
+		steps = fmt.Sprintf("FLOOR(%s + 1e-9)", steps)
+	default:
+		// ROUND on double precision rounds half to even, Amount half away
+		// from zero; prices are never negative.
+		steps = fmt.Sprintf("FLOOR(%s + 0.5)", steps)
+	}
+	return fmt.Sprintf("%s * $%d::float8", steps, incrementPos)
+}
+
 func (r *productRepository) Create(ctx context.Context, req *domains.ProductRequest) (*domains.ProductResponse, error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
@@ -57,8 +96,8 @@ func (r *productRepository) Create(ctx context.Context, req *domains.ProductRequ
 	}()
 
 	const insertProductQuery = `
-        INSERT INTO products (name, description, price, category_id, brand_id)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO products (name, description, price, sale_price, sale_starts_at, sale_ends_at, category_id, brand_id, tax_class_id, concerns)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
         RETURNING id`
 
 	var productID int
@@ -66,8 +105,13 @@ func (r *productRepository) Create(ctx context.Context, req *domains.ProductRequ
 		req.Name,
 		req.Description,
 		req.Price,
+		req.SalePrice,
+		req.SaleStartsAt,
+		req.SaleEndsAt,
 		req.CategoryID,
 		req.BrandID,
+		req.TaxClassID,
+		concerns(req),
 	).Scan(&productID)
 	if err != nil {
 		logrus.WithError(err).WithField("req", req).Error("Failed to insert product")
@@ -86,6 +130,13 @@ func (r *productRepository) Create(ctx context.Context, req *domains.ProductRequ
 		}
 	}
 
+	for i, ingredientID := range req.IngredientIDs {
+		if _, err = tx.Exec(ctx, insertIngredientQuery, productID, ingredientID, i+1); err != nil {
+			logrus.WithError(err).Errorf("Failed to insert product_ingredient (product_id: %d, ingredient_id: %d)", productID, ingredientID)
+			return nil, err
+		}
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		logrus.WithError(err).Error("Failed to commit transaction")
 		return nil, err
@@ -94,6 +145,9 @@ func (r *productRepository) Create(ctx context.Context, req *domains.ProductRequ
 	if err := r.cache.DeleteAll(ctx); err != nil {
 		logrus.Warnf("Failed to clear product cache after create (ID: %d): %v", productID, err)
 	}
+	if err := r.cache.DeleteByPrefix(ctx, domains.ProductFilterKeyPrefix); err != nil {
+		logrus.Warnf("Failed to clear product filter cache after create (ID: %d): %v", productID, err)
+	}
 
 	logrus.Debugf("Product created successfully (ID: %d)", productID)
 
@@ -111,16 +165,17 @@ func (r *productRepository) GetByID(ctx context.Context, id int) (*domains.Produ
 		logrus.Debugf("Cache hit for product (ID: %d)", id)
 		return prodResp, nil
 	}
-	if !errors.Is(err, redis.Nil) {
+	if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
 		logrus.Errorf("Cache lookup failed for product (ID: %d): %v", id, err)
 	}
 
 	const getQuery = `
         SELECT 
-            p.id, p.name, p.description, p.price, 
+            p.id, p.name, p.description, p.price, p.sale_price, p.sale_starts_at, p.sale_ends_at,
             c.id AS c_id, c.name AS c_name,
             b.id AS b_id, b.name AS b_name, 
-            p.created_at, p.updated_at,
+            p.tax_class_id, p.concerns, p.rating_average, p.rating_count, p.created_at, p.updated_at,
+            COALESCE((SELECT SUM(sl.quantity) FROM stock_levels sl JOIN warehouses w ON w.id = sl.warehouse_id WHERE sl.product_id = p.id AND w.is_active), 0) AS stock_quantity,
             COALESCE(ARRAY_AGG(st.id ORDER BY st.id) FILTER (WHERE st.id IS NOT NULL), '{}') AS skin_type_ids,
             COALESCE(ARRAY_AGG(st.name ORDER BY st.id) FILTER (WHERE st.name IS NOT NULL), '{}') AS skin_type_names
         FROM products p
@@ -144,13 +199,21 @@ func (r *productRepository) GetByID(ctx context.Context, id int) (*domains.Produ
 		&prodResp.ID,
 		&prodResp.Name,
 		&prodResp.Description,
-		&prodResp.Price,
+		&prodResp.OriginalPrice,
+		&prodResp.SalePrice,
+		&prodResp.SaleStartsAt,
+		&prodResp.SaleEndsAt,
 		&prodResp.Category.ID,
 		&prodResp.Category.Name,
 		&prodResp.Brand.ID,
 		&prodResp.Brand.Name,
+		&prodResp.TaxClassID,
+		&prodResp.Concerns,
+		&prodResp.RatingAverage,
+		&prodResp.RatingCount,
 		&prodResp.CreatedAt,
 		&prodResp.UpdatedAt,
+		&prodResp.StockQuantity,
 		&skinTypeIDs,
 		&skinTypeNames,
 	)
@@ -163,6 +226,8 @@ func (r *productRepository) GetByID(ctx context.Context, id int) (*domains.Produ
 		return nil, err
 	}
 
+	prodResp.InStock = prodResp.StockQuantity > 0
+
 	for i, stID := range skinTypeIDs {
 		prodResp.SkinTypes = append(prodResp.SkinTypes, domains.SkinType{
 			ID:   stID,
@@ -170,6 +235,16 @@ func (r *productRepository) GetByID(ctx context.Context, id int) (*domains.Produ
 		})
 	}
 
+	if prodResp.Variants, err = r.getVariants(ctx, id); err != nil {
+		return nil, err
+	}
+	if prodResp.SkinTypeRatings, err = r.getSkinTypeRatings(ctx, id); err != nil {
+		return nil, err
+	}
+	if prodResp.Ingredients, err = r.getIngredients(ctx, id); err != nil {
+		return nil, err
+	}
+
 	go func(p *domains.ProductResponse) {
 		if err := r.cache.SetByID(context.Background(), p.ID, p); err != nil {
 			logrus.Warnf("Failed to cache product asynchronously (ID: %d): %v", p.ID, err)
@@ -186,9 +261,11 @@ func (r *productRepository) Update(ctx context.Context, id int, req *domains.Pro
 	const updateQuery = `
         UPDATE products 
         SET name = $1, description = $2, price = $3, 
-            category_id = $4, brand_id = $5 
-        WHERE id = $6
-        RETURNING id, name, description, price, category_id, brand_id, created_at, updated_at`
+            sale_price = $4, sale_starts_at = $5, sale_ends_at = $6,
+            category_id = $7, brand_id = $8, tax_class_id = $9, concerns = $10
+        WHERE id = $11
+        RETURNING id, name, description, price, sale_price, sale_starts_at, sale_ends_at,
+                  category_id, brand_id, tax_class_id, concerns, created_at, updated_at`
 
 	var prodResp domains.ProductResponse
 	var tempCategoryID, tempBrandID sql.NullInt64
@@ -197,16 +274,26 @@ func (r *productRepository) Update(ctx context.Context, id int, req *domains.Pro
 		req.Name,
 		req.Description,
 		req.Price,
+		req.SalePrice,
+		req.SaleStartsAt,
+		req.SaleEndsAt,
 		req.CategoryID,
 		req.BrandID,
+		req.TaxClassID,
+		concerns(req),
 		id,
 	).Scan(
 		&prodResp.ID,
 		&prodResp.Name,
 		&prodResp.Description,
-		&prodResp.Price,
+		&prodResp.OriginalPrice,
+		&prodResp.SalePrice,
+		&prodResp.SaleStartsAt,
+		&prodResp.SaleEndsAt,
 		&tempCategoryID,
 		&tempBrandID,
+		&prodResp.TaxClassID,
+		&prodResp.Concerns,
 		&prodResp.CreatedAt,
 		&prodResp.UpdatedAt,
 	)
@@ -246,16 +333,30 @@ func (r *productRepository) Update(ctx context.Context, id int, req *domains.Pro
 		}
 	}
 
+	const deleteIngredientsQuery = `DELETE FROM product_ingredients WHERE product_id = $1`
+	if _, err = r.db.Exec(ctx, deleteIngredientsQuery, id); err != nil {
+		logrus.Errorf("Failed to delete old product_ingredients for product (ID: %d): %v", id, err)
+		return nil, err
+	}
+	for i, ingredientID := range req.IngredientIDs {
+		if _, err = r.db.Exec(ctx, insertIngredientQuery, id, ingredientID, i+1); err != nil {
+			logrus.WithError(err).Errorf("Failed to insert product_ingredient (product_id: %d, ingredient_id: %d)", id, ingredientID)
+			return nil, err
+		}
+		prodResp.Ingredients = append(prodResp.Ingredients, domains.Ingredient{ID: ingredientID})
+	}
+
+	// The update response lacks joined data such as variants, so drop the
+	// cached entry and let the next read load the full product.
+	if err := r.cache.Delete(ctx, id); err != nil {
+		logrus.Warnf("Failed to remove product from cache after update (ID: %d): %v", id, err)
+	}
 	if err := r.cache.DeleteAll(ctx); err != nil {
 		logrus.Warnf("Failed to clear product cache after update (ID: %d): %v", id, err)
 	}
-	go func(p *domains.ProductResponse) {
-		if err := r.cache.SetByID(context.Background(), p.ID, p); err != nil {
-			logrus.Warnf("Failed to cache updated product asynchronously (ID: %d): %v", p.ID, err)
-		} else {
-			logrus.Debugf("Successfully cached updated product asynchronously (ID: %d)", p.ID)
-		}
-	}(&prodResp)
+	if err := r.cache.DeleteByPrefix(ctx, domains.ProductFilterKeyPrefix); err != nil {
+		logrus.Warnf("Failed to clear product filter cache after update (ID: %d): %v", id, err)
+	}
 
 	logrus.Debugf("Product updated successfully (ID: %d)", prodResp.ID)
 	return &prodResp, nil
@@ -281,6 +382,9 @@ func (r *productRepository) Delete(ctx context.Context, id int) error {
 	if err := r.cache.DeleteAll(ctx); err != nil {
 		logrus.Warnf("Failed to clear all products cache after deletion (ID: %d): %v", id, err)
 	}
+	if err := r.cache.DeleteByPrefix(ctx, domains.ProductFilterKeyPrefix); err != nil {
+		logrus.Warnf("Failed to clear product filter cache after deletion (ID: %d): %v", id, err)
+	}
 
 	logrus.Debugf("Product deleted successfully (ID: %d)", deletedID)
 	return nil
@@ -292,14 +396,15 @@ func (r *productRepository) GetAll(ctx context.Context) ([]*domains.ProductRespo
 		logrus.Debug("Cache hit for all products")
 		return productsResp, nil
 	}
-	if !errors.Is(err, redis.Nil) {
+	if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
 		logrus.Errorf("Cache lookup failed for all products: %v", err)
 	}
 
 	const getAllQuery = `
-        SELECT id, name, price
-        FROM products
-        ORDER BY id`
+        SELECT p.id, p.name, p.price, p.sale_price, p.sale_starts_at, p.sale_ends_at, p.rating_average, p.rating_count,
+               COALESCE((SELECT SUM(sl.quantity) FROM stock_levels sl JOIN warehouses w ON w.id = sl.warehouse_id WHERE sl.product_id = p.id AND w.is_active), 0)
+        FROM products p
+        ORDER BY p.id`
 
 	rows, err := r.db.Query(ctx, getAllQuery)
 	if err != nil {
@@ -311,10 +416,21 @@ func (r *productRepository) GetAll(ctx context.Context) ([]*domains.ProductRespo
 	var productsList []*domains.ProductResponse
 	for rows.Next() {
 		prod := new(domains.ProductResponse)
-		if err := rows.Scan(&prod.ID, &prod.Name, &prod.Price); err != nil {
+		if err := rows.Scan(
+			&prod.ID,
+			&prod.Name,
+			&prod.OriginalPrice,
+			&prod.SalePrice,
+			&prod.SaleStartsAt,
+			&prod.SaleEndsAt,
+			&prod.RatingAverage,
+			&prod.RatingCount,
+			&prod.StockQuantity,
+		); err != nil {
 			logrus.Errorf("Failed to scan product row: %v", err)
 			return nil, err
 		}
+		prod.InStock = prod.StockQuantity > 0
 		productsList = append(productsList, prod)
 	}
 	if err := rows.Err(); err != nil {
@@ -334,15 +450,27 @@ func (r *productRepository) GetAll(ctx context.Context) ([]*domains.ProductRespo
 	return productsList, nil
 }
 
-func (r *productRepository) GetByFilter(ctx context.Context, skinTypeIDs []int, brandIDs []int, categoryIDs []int, priceRange *domains.PriceRange) ([]*domains.ProductResponse, error) {
-	filterKey := fmt.Sprintf("filter:skin=%v:brand=%v:category=%v:price=%v", skinTypeIDs, brandIDs, categoryIDs, priceRange)
+func (r *productRepository) GetByFilter(ctx context.Context, filter *domains.ProductFilter) ([]*domains.ProductResponse, error) {
+	go r.trackFilter(filter)
+	return r.getByFilter(ctx, filter)
+}
+
+// WarmFilter loads the filter's results into the cache like GetByFilter,
+// but does not record the request for popularity tracking.
+func (r *productRepository) WarmFilter(ctx context.Context, filter *domains.ProductFilter) error {
+	_, err := r.getByFilter(ctx, filter)
+	return err
+}
+
+func (r *productRepository) getByFilter(ctx context.Context, filter *domains.ProductFilter) ([]*domains.ProductResponse, error) {
+	filterKey := filter.CacheKey()
 
 	productsResp, err := r.cache.GetByKey(ctx, filterKey)
 	if err == nil {
 		logrus.Debugf("Cache hit for products by filter (key: %s)", filterKey)
 		return productsResp, nil
 	}
-	if !errors.Is(err, redis.Nil) {
+	if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
 		logrus.Errorf("Cache lookup failed for filter key %s: %v", filterKey, err)
 	}
 
@@ -352,43 +480,106 @@ func (r *productRepository) GetByFilter(ctx context.Context, skinTypeIDs []int,
 		conditions   []string
 	)
 
-	queryBuilder.WriteString("SELECT DISTINCT p.id, p.name, p.price FROM products p")
-	if len(skinTypeIDs) > 0 {
+	queryBuilder.WriteString("SELECT DISTINCT p.id, p.name, p.price, p.sale_price, p.sale_starts_at, p.sale_ends_at, p.rating_average, p.rating_count," +
+		" COALESCE((SELECT SUM(sl.quantity) FROM stock_levels sl JOIN warehouses w ON w.id = sl.warehouse_id WHERE sl.product_id = p.id AND w.is_active), 0)" +
+		" FROM products p")
+	if len(filter.SkinTypeIDs) > 0 {
 		queryBuilder.WriteString(" JOIN product_skin_types pst ON p.id = pst.product_id")
 	}
 
 	argPos := 1
-	if len(brandIDs) > 0 {
+	if len(filter.BrandIDs) > 0 {
 		conditions = append(conditions, fmt.Sprintf("p.brand_id = ANY($%d)", argPos))
-		args = append(args, brandIDs)
+		args = append(args, filter.BrandIDs)
 		argPos++
 	}
-	if len(categoryIDs) > 0 {
+	if len(filter.CategoryIDs) > 0 {
 		conditions = append(conditions, fmt.Sprintf("p.category_id = ANY($%d)", argPos))
-		args = append(args, categoryIDs)
+		args = append(args, filter.CategoryIDs)
 		argPos++
 	}
-	if len(skinTypeIDs) > 0 {
+	if len(filter.SkinTypeIDs) > 0 {
 		conditions = append(conditions, fmt.Sprintf("pst.skin_type_id = ANY($%d)", argPos))
-		args = append(args, skinTypeIDs)
+		args = append(args, filter.SkinTypeIDs)
 		argPos++
 	}
-	if priceRange != nil {
-		if priceRange.MinPrice != nil {
-			conditions = append(conditions, fmt.Sprintf("p.price >= $%d", argPos))
-			args = append(args, *priceRange.MinPrice)
+	if filter.PriceRange != nil {
+		// Products with variants match when any variant is in range; products
+		// without variants fall back to their own price, including a running
+		// sale. A range in another currency is compared with prices converted
+		// and rounded exactly like the prices shown in that currency.
+		variantPrice, productPrice := "pv.price", currentPriceExpr
+		var minPrice, maxPrice any
+		if filter.PriceRange.MinPrice != nil {
+			minPrice = *filter.PriceRange.MinPrice
+		}
+		if filter.PriceRange.MaxPrice != nil {
+			maxPrice = *filter.PriceRange.MaxPrice
+		}
+		if conversion := filter.Conversion; conversion != nil && (minPrice != nil || maxPrice != nil) {
+			variantPrice = convertedPriceExpr(variantPrice, conversion.Mode, argPos, argPos+1)
+			productPrice = convertedPriceExpr(productPrice, conversion.Mode, argPos, argPos+1)
+			args = append(args, conversion.Rate, float64(conversion.Increment))
+			argPos += 2
+			// Converted prices are compared in minor units.
+			if minPrice != nil {
+				minPrice = float64(*filter.PriceRange.MinPrice)
+			}
+			if maxPrice != nil {
+				maxPrice = float64(*filter.PriceRange.MaxPrice)
+			}
+		}
+		var variantConditions, productConditions []string
+		if minPrice != nil {
+			variantConditions = append(variantConditions, fmt.Sprintf("%s >= $%d", variantPrice, argPos))
+			productConditions = append(productConditions, fmt.Sprintf("%s >= $%d", productPrice, argPos))
+			args = append(args, minPrice)
 			argPos++
 		}
-		if priceRange.MaxPrice != nil {
-			conditions = append(conditions, fmt.Sprintf("p.price <= $%d", argPos))
-			args = append(args, *priceRange.MaxPrice)
+		if maxPrice != nil {
+			variantConditions = append(variantConditions, fmt.Sprintf("%s <= $%d", variantPrice, argPos))
+			productConditions = append(productConditions, fmt.Sprintf("%s <= $%d", productPrice, argPos))
+			args = append(args, maxPrice)
 			argPos++
 		}
+		if len(variantConditions) > 0 {
+			conditions = append(conditions, fmt.Sprintf(
+				"(EXISTS (SELECT 1 FROM product_variants pv WHERE pv.product_id = p.id AND %s)"+
+					" OR (NOT EXISTS (SELECT 1 FROM product_variants pv WHERE pv.product_id = p.id) AND %s))",
+				strings.Join(variantConditions, " AND "),
+				strings.Join(productConditions, " AND "),
+			))
+		}
+	}
+	if filter.InStock {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM stock_levels sl JOIN warehouses w ON w.id = sl.warehouse_id WHERE sl.product_id = p.id AND w.is_active AND sl.quantity > 0)")
+	}
+	if len(filter.WithIngredientIDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf(
+			"NOT EXISTS (SELECT 1 FROM unnest($%d::int[]) AS wi(id) WHERE NOT EXISTS"+
+				" (SELECT 1 FROM product_ingredients pi WHERE pi.product_id = p.id AND pi.ingredient_id = wi.id))", argPos))
+		args = append(args, filter.WithIngredientIDs)
+		argPos++
+	}
+	if len(filter.WithoutIngredientIDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf(
+			"NOT EXISTS (SELECT 1 FROM product_ingredients pi WHERE pi.product_id = p.id AND pi.ingredient_id = ANY($%d))", argPos))
+		args = append(args, filter.WithoutIngredientIDs)
+		argPos++
+	}
+	if filter.MinRating != nil {
+		conditions = append(conditions, fmt.Sprintf("p.rating_count > 0 AND p.rating_average >= $%d", argPos))
+		args = append(args, *filter.MinRating)
+		argPos++
 	}
 	if len(conditions) > 0 {
 		queryBuilder.WriteString(" WHERE " + strings.Join(conditions, " AND "))
 	}
-	queryBuilder.WriteString(" ORDER BY p.id")
+	if filter.Sort == domains.SortByRating {
+		queryBuilder.WriteString(" ORDER BY p.rating_average DESC, p.rating_count DESC, p.id")
+	} else {
+		queryBuilder.WriteString(" ORDER BY p.id")
+	}
 
 	query := queryBuilder.String()
 	logrus.Debugf("Filter Query: %s, Args: %+v", query, args)
@@ -403,10 +594,21 @@ func (r *productRepository) GetByFilter(ctx context.Context, skinTypeIDs []int,
 	var productsList []*domains.ProductResponse
 	for rows.Next() {
 		prod := new(domains.ProductResponse)
-		if err := rows.Scan(&prod.ID, &prod.Name, &prod.Price); err != nil {
+		if err := rows.Scan(
+			&prod.ID,
+			&prod.Name,
+			&prod.OriginalPrice,
+			&prod.SalePrice,
+			&prod.SaleStartsAt,
+			&prod.SaleEndsAt,
+			&prod.RatingAverage,
+			&prod.RatingCount,
+			&prod.StockQuantity,
+		); err != nil {
 			logrus.Errorf("Failed to scan product row in filter query: %v", err)
 			return nil, err
 		}
+		prod.InStock = prod.StockQuantity > 0
 		productsList = append(productsList, prod)
 	}
 	if err = rows.Err(); err != nil {
@@ -426,6 +628,163 @@ func (r *productRepository) GetByFilter(ctx context.Context, skinTypeIDs []int,
 	return productsList, nil
 }
 
+func (r *productRepository) getVariants(ctx context.Context, productID int) ([]domains.ProductVariant, error) {
+	const getVariantsQuery = `
+        SELECT pv.id, pv.product_id, pv.sku, COALESCE(pv.barcode, ''), pv.volume, COALESCE(pv.volume_unit, ''),
+               COALESCE(pv.shade, ''), pv.price, pv.weight_grams,
+               COALESCE((SELECT SUM(sl.quantity) FROM stock_levels sl JOIN warehouses w ON w.id = sl.warehouse_id
+                         WHERE sl.variant_id = pv.id AND w.is_active), 0),
+               pv.created_at, pv.updated_at
+        FROM product_variants pv
+        WHERE pv.product_id = $1
+        ORDER BY pv.price, pv.id`
+
+	rows, err := r.db.Query(ctx, getVariantsQuery, productID)
+	if err != nil {
+		logrus.Errorf("Failed to query product variants (ID: %d): %v", productID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var variants []domains.ProductVariant
+	for rows.Next() {
+		var variant domains.ProductVariant
+		if err := rows.Scan(
+			&variant.ID,
+			&variant.ProductID,
+			&variant.SKU,
+			&variant.Barcode,
+			&variant.Volume,
+			&variant.VolumeUnit,
+			&variant.Shade,
+			&variant.Price,
+			&variant.WeightGrams,
+			&variant.StockQuantity,
+			&variant.CreatedAt,
+			&variant.UpdatedAt,
+		); err != nil {
+			logrus.Errorf("Failed to scan product variant row (ID: %d): %v", productID, err)
+			return nil, err
+		}
+		variant.InStock = variant.StockQuantity > 0
+		variants = append(variants, variant)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating product variant rows (ID: %d): %v", productID, err)
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+// getIngredients returns the product's ingredients in label order.
+func (r *productRepository) getIngredients(ctx context.Context, productID int) ([]domains.Ingredient, error) {
+	const getIngredientsQuery = `
+        SELECT i.id, i.inci_name, i.common_names, COALESCE(i.function, ''), i.comedogenic_rating, i.is_allergen
+        FROM product_ingredients pi
+        JOIN ingredients i ON i.id = pi.ingredient_id
+        WHERE pi.product_id = $1
+        ORDER BY pi.position`
+
+	rows, err := r.db.Query(ctx, getIngredientsQuery, productID)
+	if err != nil {
+		logrus.Errorf("Failed to query product ingredients (ID: %d): %v", productID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ingredients []domains.Ingredient
+	for rows.Next() {
+		var ingredient domains.Ingredient
+		if err := rows.Scan(
+			&ingredient.ID,
+			&ingredient.INCIName,
+			&ingredient.CommonNames,
+			&ingredient.Function,
+			&ingredient.ComedogenicRating,
+			&ingredient.IsAllergen,
+		); err != nil {
+			logrus.Errorf("Failed to scan product ingredient row (ID: %d): %v", productID, err)
+			return nil, err
+		}
+		ingredients = append(ingredients, ingredient)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating product ingredient rows (ID: %d): %v", productID, err)
+		return nil, err
+	}
+
+	return ingredients, nil
+}
+
+// getSkinTypeRatings breaks the approved reviews of a product down by the
+// skin type reviewers gave.
+func (r *productRepository) getSkinTypeRatings(ctx context.Context, productID int) ([]domains.SkinTypeRating, error) {
+	const getRatingsQuery = `
+        SELECT st.id, st.name, ROUND(AVG(rv.rating), 2), COUNT(*)
+        FROM reviews rv
+        JOIN skin_types st ON st.id = rv.skin_type_id
+        WHERE rv.product_id = $1 AND rv.status = 'approved'
+        GROUP BY st.id, st.name
+        ORDER BY st.id`
+
+	rows, err := r.db.Query(ctx, getRatingsQuery, productID)
+	if err != nil {
+		logrus.Errorf("Failed to query skin type ratings (ID: %d): %v", productID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ratings []domains.SkinTypeRating
+	for rows.Next() {
+		var rating domains.SkinTypeRating
+		if err := rows.Scan(&rating.SkinTypeID, &rating.SkinTypeName, &rating.Average, &rating.Count); err != nil {
+			logrus.Errorf("Failed to scan skin type rating row (ID: %d): %v", productID, err)
+			return nil, err
+		}
+		ratings = append(ratings, rating)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating skin type rating rows (ID: %d): %v", productID, err)
+		return nil, err
+	}
+
+	return ratings, nil
+}
+
+// trackFilter records a filter request so the most popular filters can be
+// preloaded into the cache.
+func (r *productRepository) trackFilter(filter *domains.ProductFilter) {
+	member, err := json.Marshal(filter)
+	if err != nil {
+		logrus.Warnf("Failed to encode filter for popularity tracking: %v", err)
+		return
+	}
+	if err := r.filterStats.Track(context.Background(), string(member)); err != nil {
+		logrus.Warnf("Failed to track filter popularity: %v", err)
+	}
+}
+
+func (r *productRepository) GetPopularFilters(ctx context.Context, limit int) ([]*domains.ProductFilter, error) {
+	members, err := r.filterStats.Top(ctx, limit)
+	if err != nil {
+		logrus.Errorf("Failed to get popular filters: %v", err)
+		return nil, err
+	}
+
+	filters := make([]*domains.ProductFilter, 0, len(members))
+	for _, member := range members {
+		filter := &domains.ProductFilter{}
+		if err := json.Unmarshal([]byte(member), filter); err != nil {
+			logrus.Warnf("Skipping malformed popular filter %q: %v", member, err)
+			continue
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
 func (r *productRepository) UploadImage(ctx context.Context, productID int, file io.Reader, isMain bool, altText string) (*domains.ProductImage, error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
@@ -578,3 +937,54 @@ func (r *productRepository) GetProductImages(ctx context.Context, productID int)
 
 	return images, nil
 }
+
+// GetPriceHistory returns the product's prices, newest first.
+func (r *productRepository) GetPriceHistory(ctx context.Context, productID int) ([]*domains.ProductPrice, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)`, productID).Scan(&exists); err != nil {
+		logrus.Errorf("Failed to check product existence (ID: %d): %v", productID, err)
+		return nil, err
+	}
+	if !exists {
+		logrus.Infof("Product not found for price history (ID: %d)", productID)
+		return nil, sql.ErrNoRows
+	}
+
+	const historyQuery = `
+        SELECT id, product_id, price, sale_price, starts_at, ends_at, changed_at
+        FROM product_prices
+        WHERE product_id = $1
+        ORDER BY changed_at DESC, id DESC`
+
+	rows, err := r.db.Query(ctx, historyQuery, productID)
+	if err != nil {
+		logrus.Errorf("Failed to query price history (product_id: %d): %v", productID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []*domains.ProductPrice
+	for rows.Next() {
+		price := &domains.ProductPrice{}
+		if err := rows.Scan(
+			&price.ID,
+			&price.ProductID,
+			&price.Price,
+			&price.SalePrice,
+			&price.StartsAt,
+			&price.EndsAt,
+			&price.ChangedAt,
+		); err != nil {
+			logrus.Errorf("Failed to scan price history row: %v", err)
+			return nil, err
+		}
+		history = append(history, price)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating price history rows: %v", err)
+		return nil, err
+	}
+
+	logrus.Debugf("Price history retrieved successfully (product_id: %d, Count: %d)", productID, len(history))
+	return history, nil
+}
@@ -2,52 +2,134 @@ package product
 
 import (
 	"context"
+	"e-commerce/internal/currency"
 	"e-commerce/internal/domains"
 	"io"
+	"slices"
+	"time"
 )
 
 type ProductService interface {
 	CreateProduct(ctx context.Context, req *domains.ProductRequest) (*domains.ProductResponse, error)
-	GetProductByID(ctx context.Context, id int) (*domains.ProductResponse, error)
+	GetProductByID(ctx context.Context, id int, currencyCode string) (*domains.ProductResponse, error)
 	UpdateProduct(ctx context.Context, id int, req *domains.ProductRequest) (*domains.ProductResponse, error)
 	DeleteProduct(ctx context.Context, id int) error
-	GetAllProducts(ctx context.Context) ([]*domains.ProductResponse, error)
-	GetProductsByFilter(ctx context.Context, skinTypeIDs []int, brandIDs []int, categoryIDs []int, priceRange *domains.PriceRange) ([]*domains.ProductResponse, error)
+	GetAllProducts(ctx context.Context, currencyCode string) ([]*domains.ProductResponse, error)
+	GetProductsByFilter(ctx context.Context, filter *domains.ProductFilter) ([]*domains.ProductResponse, error)
+	WarmProductsByFilter(ctx context.Context, filter *domains.ProductFilter) error
+	GetPopularFilters(ctx context.Context, limit int) ([]*domains.ProductFilter, error)
 	UploadProductImage(ctx context.Context, productID int, file io.Reader, isMain bool, altText string) (*domains.ProductImage, error)
 	DeleteProductImage(ctx context.Context, imageID int) error
 	GetProductImages(ctx context.Context, productID int) ([]*domains.ProductImage, error)
+	GetPriceHistory(ctx context.Context, productID int) ([]*domains.ProductPrice, error)
 }
 
 type productService struct {
-	repo ProductRepository
+	repo       ProductRepository
+	currencies currency.CurrencyService
 }
 
-func NewProductService(repo ProductRepository) ProductService {
-	return &productService{repo: repo}
+func NewProductService(repo ProductRepository, currencies currency.CurrencyService) ProductService {
+	return &productService{repo: repo, currencies: currencies}
 }
 
 func (s *productService) CreateProduct(ctx context.Context, req *domains.ProductRequest) (*domains.ProductResponse, error) {
-	return s.repo.Create(ctx, req)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	product, err := s.repo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return s.present(ctx, product, "")
 }
 
-func (s *productService) GetProductByID(ctx context.Context, id int) (*domains.ProductResponse, error) {
-	return s.repo.GetByID(ctx, id)
+// GetProductByID returns the product with prices in currencyCode, or in the
+// base currency when it is empty.
+func (s *productService) GetProductByID(ctx context.Context, id int, currencyCode string) (*domains.ProductResponse, error) {
+	conversion, err := s.currencies.Conversion(ctx, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return present(product, conversion, time.Now()), nil
 }
 
 func (s *productService) UpdateProduct(ctx context.Context, id int, req *domains.ProductRequest) (*domains.ProductResponse, error) {
-	return s.repo.Update(ctx, id, req)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	product, err := s.repo.Update(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	return s.present(ctx, product, "")
 }
 
 func (s *productService) DeleteProduct(ctx context.Context, id int) error {
 	return s.repo.Delete(ctx, id)
 }
 
-func (s *productService) GetAllProducts(ctx context.Context) ([]*domains.ProductResponse, error) {
-	return s.repo.GetAll(ctx)
+func (s *productService) GetAllProducts(ctx context.Context, currencyCode string) ([]*domains.ProductResponse, error) {
+	conversion, err := s.currencies.Conversion(ctx, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	products, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return presentAll(products, conversion), nil
 }
 
-func (s *productService) GetProductsByFilter(ctx context.Context, skinTypeIDs []int, brandIDs []int, categoryIDs []int, priceRange *domains.PriceRange) ([]*domains.ProductResponse, error) {
-	return s.repo.GetByFilter(ctx, skinTypeIDs, brandIDs, categoryIDs, priceRange)
+// GetProductsByFilter treats the filter's price range as being in the
+// filter's currency. The currency is cleared for the base currency so that
+// base-currency requests share cache entries.
+func (s *productService) GetProductsByFilter(ctx context.Context, filter *domains.ProductFilter) ([]*domains.ProductResponse, error) {
+	conversion, err := s.prepareFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.repo.GetByFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return presentAll(products, conversion), nil
+}
+
+// WarmProductsByFilter loads the filter's results into the cache without
+// counting towards the filter's popularity, so warming the popular filters
+// does not keep them popular.
+func (s *productService) WarmProductsByFilter(ctx context.Context, filter *domains.ProductFilter) error {
+	if _, err := s.prepareFilter(ctx, filter); err != nil {
+		return err
+	}
+	return s.repo.WarmFilter(ctx, filter)
+}
+
+func (s *productService) prepareFilter(ctx context.Context, filter *domains.ProductFilter) (*currency.Conversion, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	conversion, err := s.currencies.Conversion(ctx, filter.Currency)
+	if err != nil {
+		return nil, err
+	}
+	filter.Currency = ""
+	if !conversion.IsBase() {
+		filter.Currency = conversion.Currency
+	}
+	filter.Conversion = conversion.PriceConversion()
+	return conversion, nil
+}
+
+func (s *productService) GetPopularFilters(ctx context.Context, limit int) ([]*domains.ProductFilter, error) {
+	return s.repo.GetPopularFilters(ctx, limit)
 }
 
 func (s *productService) UploadProductImage(ctx context.Context, productID int, file io.Reader, isMain bool, altText string) (*domains.ProductImage, error) {
@@ -61,3 +143,47 @@ func (s *productService) DeleteProductImage(ctx context.Context, imageID int) er
 func (s *productService) GetProductImages(ctx context.Context, productID int) ([]*domains.ProductImage, error) {
 	return s.repo.GetProductImages(ctx, productID)
 }
+
+func (s *productService) GetPriceHistory(ctx context.Context, productID int) ([]*domains.ProductPrice, error) {
+	return s.repo.GetPriceHistory(ctx, productID)
+}
+
+func (s *productService) present(ctx context.Context, product *domains.ProductResponse, currencyCode string) (*domains.ProductResponse, error) {
+	conversion, err := s.currencies.Conversion(ctx, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+	return present(product, conversion, time.Now()), nil
+}
+
+// present returns a copy of a product read from the repository with the
+// current sale applied and its prices converted. Cached entries hold base
+// currency prices, so rate changes apply immediately. The original is left
+// alone because the repository may still be writing it to cache.
+func present(product *domains.ProductResponse, conversion *currency.Conversion, now time.Time) *domains.ProductResponse {
+	p := *product
+	p.ApplySale(now)
+
+	p.Currency = conversion.Currency
+	p.Price = conversion.Amount(p.Price)
+	p.OriginalPrice = conversion.Amount(p.OriginalPrice)
+	if p.SalePrice != nil {
+		salePrice := conversion.Amount(*p.SalePrice)
+		p.SalePrice = &salePrice
+	}
+	p.Variants = slices.Clone(p.Variants)
+	for i := range p.Variants {
+		p.Variants[i].Price = conversion.Amount(p.Variants[i].Price)
+		p.Variants[i].Currency = conversion.Currency
+	}
+	return &p
+}
+
+func presentAll(products []*domains.ProductResponse, conversion *currency.Conversion) []*domains.ProductResponse {
+	now := time.Now()
+	presented := make([]*domains.ProductResponse, 0, len(products))
+	for _, product := range products {
+		presented = append(presented, present(product, conversion, now))
+	}
+	return presented
+}
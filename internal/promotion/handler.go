@@ -0,0 +1,204 @@
+package promotion
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type PromotionHandler struct {
+	service PromotionService
+}
+
+func NewPromotionHandler(service PromotionService) *PromotionHandler {
+	return &PromotionHandler{service: service}
+}
+
+func (h *PromotionHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/promotions", h.CreatePromotion)
+	router.GET("/promotions/:id", h.GetPromotionByID)
+	router.PUT("/promotions/:id", h.UpdatePromotion)
+	router.DELETE("/promotions/:id", h.DeletePromotion)
+	router.GET("/promotions", h.GetAllPromotions)
+	router.POST("/promotions/apply", h.PriceCart)
+}
+
+// @Summary Create a new promotion
+// @Description Create a percentage or fixed discount, optionally limited to products, brands, categories or skin types and behind a coupon code
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param promotion body domains.PromotionRequest true "Promotion object"
+// @Success 201 {object} domains.Promotion
+// @Failure 400 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /promotions [post]
+func (h *PromotionHandler) CreatePromotion(c *gin.Context) {
+	var req domains.PromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdPromotion, err := h.service.CreatePromotion(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(promotionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdPromotion)
+}
+
+// @Summary Get promotion by ID
+// @Description Get a promotion by its ID
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param id path int true "Promotion ID"
+// @Success 200 {object} domains.Promotion
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /promotions/{id} [get]
+func (h *PromotionHandler) GetPromotionByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid promotion id"})
+		return
+	}
+
+	promotion, err := h.service.GetPromotionByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(promotionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, promotion)
+}
+
+// @Summary Update promotion
+// @Description Replace the rules of an existing promotion. The usage count is kept.
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param id path int true "Promotion ID"
+// @Param promotion body domains.PromotionRequest true "Promotion object"
+// @Success 200 {object} domains.Promotion
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Router /promotions/{id} [put]
+func (h *PromotionHandler) UpdatePromotion(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid promotion id"})
+		return
+	}
+
+	var req domains.PromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedPromotion, err := h.service.UpdatePromotion(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(promotionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedPromotion)
+}
+
+// @Summary Delete promotion
+// @Description Delete a promotion by its ID
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param id path int true "Promotion ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /promotions/{id} [delete]
+func (h *PromotionHandler) DeletePromotion(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid promotion id"})
+		return
+	}
+
+	if err := h.service.DeletePromotion(c.Request.Context(), id); err != nil {
+		c.JSON(promotionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get all promotions
+// @Description Get a list of all promotions ordered by priority
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Success 200 {array} domains.Promotion
+// @Failure 500 {object} domains.Error
+// @Router /promotions [get]
+func (h *PromotionHandler) GetAllPromotions(c *gin.Context) {
+	promotions, err := h.service.GetAllPromotions(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, promotions)
+}
+
+// @Summary Price a cart with promotions
+// @Description Apply automatic promotions and coupon codes to a cart and return a per-line breakdown. Nothing is redeemed.
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param pricing body domains.PricingRequest true "Cart and coupon codes"
+// @Success 200 {object} domains.PricingResult
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /promotions/apply [post]
+func (h *PromotionHandler) PriceCart(c *gin.Context) {
+	var req domains.PricingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.PriceCart(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(promotionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func promotionErrorStatus(err error) int {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, domains.ErrInvalidPromotion):
+		return http.StatusBadRequest
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
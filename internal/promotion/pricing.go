@@ -0,0 +1,195 @@
+package promotion
+
+import (
+	"slices"
+
+	"e-commerce/internal/domains"
+)
+
+// Line is a cart line together with the catalog attributes promotions can
+// target.
+type Line struct {
+	Item        domains.CartItem
+	BrandID     *int
+	CategoryID  *int
+	SkinTypeIDs []int
+}
+
+// Price applies promotions to the lines and returns a per-line breakdown.
+//
+// Promotions must already be usable (active, inside their validity window
+// and under their usage limit); Price only checks targeting and the minimum
+// order amount, which is compared with the cart subtotal before discounts.
+//
+// Stackable promotions combine: they are applied in priority order, each to
+// what the previous ones left of a line. A non-stackable promotion is used
+// alone. Price picks whichever of these choices gives the largest discount;
+// on a tie the stackable combination wins, then the higher priority.
+func Price(lines []Line, promotions []*domains.Promotion) *domains.PricingResult {
+	subtotals := make([]domains.Money, len(lines))
+	var subtotal domains.Money
+	for i, line := range lines {
+		subtotals[i] = line.Item.UnitPrice.Mul(line.Item.Quantity)
+		subtotal += subtotals[i]
+	}
+
+	sorted := slices.Clone(promotions)
+	slices.SortStableFunc(sorted, func(a, b *domains.Promotion) int {
+		if a.Priority != b.Priority {
+			return a.Priority - b.Priority
+		}
+		return a.ID - b.ID
+	})
+
+	var stackable []*domains.Promotion
+	var choices [][]*domains.Promotion
+	for _, p := range sorted {
+		if p.MinOrderAmount != nil && subtotal < *p.MinOrderAmount {
+			continue
+		}
+		if p.Stackable {
+			stackable = append(stackable, p)
+		} else {
+			choices = append(choices, []*domains.Promotion{p})
+		}
+	}
+	if len(stackable) > 0 {
+		choices = append([][]*domains.Promotion{stackable}, choices...)
+	}
+
+	var (
+		bestSet       []*domains.Promotion
+		bestDiscounts [][]domains.Money
+		bestTotal     domains.Money
+	)
+	for _, set := range choices {
+		discounts, total := applySet(lines, subtotals, set)
+		if total > bestTotal {
+			bestSet, bestDiscounts, bestTotal = set, discounts, total
+		}
+	}
+
+	return buildResult(lines, subtotals, bestSet, bestDiscounts)
+}
+
+// applySet applies the promotions in order and returns the discount each of
+// them gives on each line, along with the total discount.
+func applySet(lines []Line, subtotals []domains.Money, set []*domains.Promotion) ([][]domains.Money, domains.Money) {
+	remaining := slices.Clone(subtotals)
+	discounts := make([][]domains.Money, len(set))
+	var total domains.Money
+
+	for j, p := range set {
+		discounts[j] = make([]domains.Money, len(lines))
+
+		var eligible []int
+		var base domains.Money
+		for i, line := range lines {
+			if remaining[i] > 0 && matches(p, &line) {
+				eligible = append(eligible, i)
+				base += remaining[i]
+			}
+		}
+		if base == 0 {
+			continue
+		}
+
+		switch p.DiscountType {
+		case domains.DiscountPercentage:
+			for _, i := range eligible {
+				discounts[j][i] = min(remaining[i].Percent(p.DiscountValue), remaining[i])
+			}
+		case domains.DiscountFixed:
+			// The amount is split in proportion to each line's remaining
+			// price. Flooring cumulative sums keeps the parts adding up to
+			// the amount without any part exceeding its line.
+			amount := min(p.DiscountValue, base)
+			var cumulative, allocated domains.Money
+			for _, i := range eligible {
+				cumulative += remaining[i]
+				share := amount.MulDivDown(int64(cumulative), int64(base))
+				discounts[j][i] = share - allocated
+				allocated = share
+			}
+		}
+
+		for _, i := range eligible {
+			remaining[i] -= discounts[j][i]
+			total += discounts[j][i]
+		}
+	}
+
+	return discounts, total
+}
+
+func matches(p *domains.Promotion, line *Line) bool {
+	switch p.Target {
+	case domains.TargetAll:
+		return true
+	case domains.TargetProduct:
+		return slices.Contains(p.TargetIDs, line.Item.ProductID)
+	case domains.TargetBrand:
+		return line.BrandID != nil && slices.Contains(p.TargetIDs, *line.BrandID)
+	case domains.TargetCategory:
+		return line.CategoryID != nil && slices.Contains(p.TargetIDs, *line.CategoryID)
+	case domains.TargetSkinType:
+		return slices.ContainsFunc(line.SkinTypeIDs, func(id int) bool {
+			return slices.Contains(p.TargetIDs, id)
+		})
+	}
+	return false
+}
+
+func buildResult(lines []Line, subtotals []domains.Money, set []*domains.Promotion, discounts [][]domains.Money) *domains.PricingResult {
+	result := &domains.PricingResult{Lines: make([]domains.PricedLine, 0, len(lines))}
+
+	var subtotal, discount domains.Money
+	for i, line := range lines {
+		priced := domains.PricedLine{
+			ItemID:    line.Item.ID,
+			ProductID: line.Item.ProductID,
+			VariantID: line.Item.VariantID,
+			Quantity:  line.Item.Quantity,
+			UnitPrice: line.Item.UnitPrice,
+			Subtotal:  subtotals[i],
+		}
+		var lineDiscount domains.Money
+		for j, p := range set {
+			if discounts[j][i] == 0 {
+				continue
+			}
+			lineDiscount += discounts[j][i]
+			priced.Discounts = append(priced.Discounts, domains.LineDiscount{
+				PromotionID: p.ID,
+				Amount:      discounts[j][i],
+			})
+		}
+		priced.Discount = lineDiscount
+		priced.Total = subtotals[i] - lineDiscount
+		result.Lines = append(result.Lines, priced)
+
+		subtotal += subtotals[i]
+		discount += lineDiscount
+	}
+
+	for j, p := range set {
+		var amount domains.Money
+		for _, d := range discounts[j] {
+			amount += d
+		}
+		if amount == 0 {
+			continue
+		}
+		result.AppliedPromotions = append(result.AppliedPromotions, domains.AppliedPromotion{
+			ID:         p.ID,
+			Name:       p.Name,
+			CouponCode: p.CouponCode,
+			Amount:     amount,
+		})
+	}
+
+	result.Subtotal = subtotal
+	result.Discount = discount
+	result.Total = subtotal - discount
+	return result
+}
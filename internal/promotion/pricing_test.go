@@ -0,0 +1,68 @@
+package promotion
+
+import (
+	"e-commerce/internal/domains"
+	"reflect"
+	"testing"
+)
+
+func TestApplySetSplitsFixedDiscount(t *testing.T) {
+	tests := []struct {
+		name      string
+		subtotals []domains.Money
+		value     domains.Money
+		want      []domains.Money
+	}{
+		{
+			name:      "parts add up to the amount",
+			subtotals: []domains.Money{3333, 3333, 3334},
+			value:     1000,
+			want:      []domains.Money{333, 333, 334},
+		},
+		{
+			name:      "amount capped at the eligible total",
+			subtotals: []domains.Money{500, 300},
+			value:     1000,
+			want:      []domains.Money{500, 300},
+		},
+		{
+			name:      "no part exceeds its line",
+			subtotals: []domains.Money{1, 1, 1},
+			value:     2,
+			want:      []domains.Money{0, 1, 1},
+		},
+		{
+			name:      "amounts whose product overflows 64 bits",
+			subtotals: []domains.Money{domains.MaxMoney, domains.MaxMoney},
+			value:     domains.MaxMoney,
+			want:      []domains.Money{4_999_999_999_99, 5_000_000_000_00},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := make([]Line, len(tt.subtotals))
+			for i := range lines {
+				lines[i] = Line{Item: domains.CartItem{ProductID: i + 1, Quantity: 1, UnitPrice: tt.subtotals[i]}}
+			}
+			set := []*domains.Promotion{{
+				ID:            1,
+				DiscountType:  domains.DiscountFixed,
+				DiscountValue: tt.value,
+				Target:        domains.TargetAll,
+			}}
+
+			discounts, total := applySet(lines, tt.subtotals, set)
+			if !reflect.DeepEqual(discounts[0], tt.want) {
+				t.Errorf("discounts = %v, want %v", discounts[0], tt.want)
+			}
+			var wantTotal domains.Money
+			for _, d := range tt.want {
+				wantTotal += d
+			}
+			if total != wantTotal {
+				t.Errorf("total = %s, want %s", total, wantTotal)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package promotion
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/domains"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, req *domains.PromotionRequest) (*domains.Promotion, error)
+	GetByID(ctx context.Context, id int) (*domains.Promotion, error)
+	Update(ctx context.Context, id int, req *domains.PromotionRequest) (*domains.Promotion, error)
+	Delete(ctx context.Context, id int) error
+	GetAll(ctx context.Context) ([]*domains.Promotion, error)
+	GetCandidates(ctx context.Context, couponCodes []string) ([]*domains.Promotion, error)
+	GetLineAttributes(ctx context.Context, items []domains.CartItem) ([]Line, error)
+	Redeem(ctx context.Context, ids []int) error
+	Release(ctx context.Context, ids []int) error
+}
+
+type promotionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPromotionRepository(db *pgxpool.Pool) PromotionRepository {
+	return &promotionRepository{db: db}
+}
+
+const promotionColumns = `
+        id, name, COALESCE(description, ''), discount_type, discount_value, target_type, target_ids,
+        min_order_amount, COALESCE(coupon_code, ''), usage_limit, usage_count, starts_at, ends_at,
+        stackable, priority, is_active, created_at, updated_at`
+
+func scanPromotion(row pgx.Row) (*domains.Promotion, error) {
+	promotion := &domains.Promotion{}
+	err := row.Scan(
+		&promotion.ID,
+		&promotion.Name,
+		&promotion.Description,
+		&promotion.DiscountType,
+		&promotion.DiscountValue,
+		&promotion.Target,
+		&promotion.TargetIDs,
+		&promotion.MinOrderAmount,
+		&promotion.CouponCode,
+		&promotion.UsageLimit,
+		&promotion.UsageCount,
+		&promotion.StartsAt,
+		&promotion.EndsAt,
+		&promotion.Stackable,
+		&promotion.Priority,
+		&promotion.IsActive,
+		&promotion.CreatedAt,
+		&promotion.UpdatedAt,
+	)
+	return promotion, err
+}
+
+func targetIDs(req *domains.PromotionRequest) []int {
+	if req.TargetIDs == nil {
+		return []int{}
+	}
+	return req.TargetIDs
+}
+
+func (r *promotionRepository) Create(ctx context.Context, req *domains.PromotionRequest) (*domains.Promotion, error) {
+	const insertQuery = `
+        INSERT INTO promotions (name, description, discount_type, discount_value, target_type, target_ids,
+                                min_order_amount, coupon_code, usage_limit, starts_at, ends_at, stackable,
+                                priority, is_active)
+        VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10, $11, $12, $13, COALESCE($14, TRUE))
+        RETURNING` + promotionColumns
+
+	promotion, err := scanPromotion(r.db.QueryRow(ctx, insertQuery,
+		req.Name,
+		req.Description,
+		req.DiscountType,
+		req.DiscountValue,
+		req.Target,
+		targetIDs(req),
+		req.MinOrderAmount,
+		req.CouponCode,
+		req.UsageLimit,
+		req.StartsAt,
+		req.EndsAt,
+		req.Stackable,
+		req.Priority,
+		req.IsActive,
+	))
+	if err != nil {
+		logrus.WithError(err).WithField("promotion", req).Error("Failed to insert promotion")
+		return nil, err
+	}
+
+	logrus.Debugf("Promotion created successfully (ID: %d)", promotion.ID)
+	return promotion, nil
+}
+
+func (r *promotionRepository) GetByID(ctx context.Context, id int) (*domains.Promotion, error) {
+	const getQuery = `SELECT` + promotionColumns + ` FROM promotions WHERE id = $1`
+
+	promotion, err := scanPromotion(r.db.QueryRow(ctx, getQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Promotion not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get promotion (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Promotion retrieved successfully (ID: %d)", promotion.ID)
+	return promotion, nil
+}
+
+// Update replaces the promotion's rules. The usage count is kept.
+func (r *promotionRepository) Update(ctx context.Context, id int, req *domains.PromotionRequest) (*domains.Promotion, error) {
+	const updateQuery = `
+        UPDATE promotions
+        SET name = $1, description = NULLIF($2, ''), discount_type = $3, discount_value = $4,
+            target_type = $5, target_ids = $6, min_order_amount = $7, coupon_code = NULLIF($8, ''),
+            usage_limit = $9, starts_at = $10, ends_at = $11, stackable = $12, priority = $13,
+            is_active = COALESCE($14, is_active), updated_at = CURRENT_TIMESTAMP
+        WHERE id = $15
+        RETURNING` + promotionColumns
+
+	promotion, err := scanPromotion(r.db.QueryRow(ctx, updateQuery,
+		req.Name,
+		req.Description,
+		req.DiscountType,
+		req.DiscountValue,
+		req.Target,
+		targetIDs(req),
+		req.MinOrderAmount,
+		req.CouponCode,
+		req.UsageLimit,
+		req.StartsAt,
+		req.EndsAt,
+		req.Stackable,
+		req.Priority,
+		req.IsActive,
+		id,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to update non-existent promotion (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to update promotion (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Promotion updated successfully (ID: %d)", promotion.ID)
+	return promotion, nil
+}
+
+func (r *promotionRepository) Delete(ctx context.Context, id int) error {
+	const deleteQuery = `DELETE FROM promotions WHERE id = $1 RETURNING id`
+
+	var deletedID int
+	err := r.db.QueryRow(ctx, deleteQuery, id).Scan(&deletedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to delete non-existent promotion (ID: %d)", id)
+			return sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to delete promotion (ID: %d): %v", id, err)
+		return err
+	}
+
+	logrus.Debugf("Promotion deleted successfully (ID: %d)", deletedID)
+	return nil
+}
+
+func (r *promotionRepository) GetAll(ctx context.Context) ([]*domains.Promotion, error) {
+	const listQuery = `SELECT` + promotionColumns + ` FROM promotions ORDER BY priority, id`
+	return r.queryPromotions(ctx, listQuery)
+}
+
+// GetCandidates returns the active automatic promotions and the promotions
+// behind the given coupon codes, whatever their state, so callers can tell
+// why a coupon was not applied.
+func (r *promotionRepository) GetCandidates(ctx context.Context, couponCodes []string) ([]*domains.Promotion, error) {
+	const candidatesQuery = `
+        SELECT` + promotionColumns + `
+        FROM promotions
+        WHERE (coupon_code IS NULL AND is_active) OR coupon_code = ANY($1)
+        ORDER BY priority, id`
+	if couponCodes == nil {
+		couponCodes = []string{}
+	}
+	return r.queryPromotions(ctx, candidatesQuery, couponCodes)
+}
+
+func (r *promotionRepository) queryPromotions(ctx context.Context, query string, args ...interface{}) ([]*domains.Promotion, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("Failed to query promotions: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var promotions []*domains.Promotion
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan promotion row: %v", err)
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating promotion rows: %v", err)
+		return nil, err
+	}
+
+	logrus.Debugf("Promotions retrieved successfully (Count: %d)", len(promotions))
+	return promotions, nil
+}
+
+// GetLineAttributes loads the brand, category and skin types of the
+// products in the cart. Products deleted since they were added keep empty
+// attributes and only match store-wide promotions.
+func (r *promotionRepository) GetLineAttributes(ctx context.Context, items []domains.CartItem) ([]Line, error) {
+	const attributesQuery = `
+        SELECT p.id, p.brand_id, p.category_id,
+               COALESCE(array_agg(pst.skin_type_id) FILTER (WHERE pst.skin_type_id IS NOT NULL), '{}')
+        FROM products p
+        LEFT JOIN product_skin_types pst ON pst.product_id = p.id
+        WHERE p.id = ANY($1)
+        GROUP BY p.id`
+
+	productIDs := make([]int, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	rows, err := r.db.Query(ctx, attributesQuery, productIDs)
+	if err != nil {
+		logrus.Errorf("Failed to query product attributes: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	attributes := make(map[int]Line, len(productIDs))
+	for rows.Next() {
+		var id int
+		var line Line
+		if err := rows.Scan(&id, &line.BrandID, &line.CategoryID, &line.SkinTypeIDs); err != nil {
+			logrus.Errorf("Failed to scan product attributes row: %v", err)
+			return nil, err
+		}
+		attributes[id] = line
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating product attributes rows: %v", err)
+		return nil, err
+	}
+
+	lines := make([]Line, 0, len(items))
+	for _, item := range items {
+		line := attributes[item.ProductID]
+		line.Item = item
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// Redeem counts one use of each promotion. Either every promotion is
+// counted or, when one has reached its usage limit, none is.
+func (r *promotionRepository) Redeem(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	const redeemQuery = `
+        UPDATE promotions
+        SET usage_count = usage_count + 1
+        WHERE id = ANY($1) AND (usage_limit IS NULL OR usage_count < usage_limit)`
+	tag, err := tx.Exec(ctx, redeemQuery, ids)
+	if err != nil {
+		logrus.Errorf("Failed to redeem promotions (IDs: %v): %v", ids, err)
+		return err
+	}
+	if tag.RowsAffected() != int64(len(ids)) {
+		logrus.Infof("Promotion usage limit reached during redemption (IDs: %v)", ids)
+		err = fmt.Errorf("%w: a promotion was used up while checking out", domains.ErrCouponExhausted)
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+
+	logrus.Debugf("Promotions redeemed successfully (IDs: %v)", ids)
+	return nil
+}
+
+// Release undoes Redeem, for example when the order could not be placed.
+func (r *promotionRepository) Release(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	const releaseQuery = `UPDATE promotions SET usage_count = usage_count - 1 WHERE id = ANY($1) AND usage_count > 0`
+	if _, err := r.db.Exec(ctx, releaseQuery, ids); err != nil {
+		logrus.Errorf("Failed to release promotions (IDs: %v): %v", ids, err)
+		return err
+	}
+
+	logrus.Debugf("Promotions released successfully (IDs: %v)", ids)
+	return nil
+}
@@ -0,0 +1,149 @@
+package promotion
+
+import (
+	"context"
+	"e-commerce/internal/cart"
+	"e-commerce/internal/domains"
+	"slices"
+	"time"
+)
+
+type PromotionService interface {
+	CreatePromotion(ctx context.Context, req *domains.PromotionRequest) (*domains.Promotion, error)
+	GetPromotionByID(ctx context.Context, id int) (*domains.Promotion, error)
+	UpdatePromotion(ctx context.Context, id int, req *domains.PromotionRequest) (*domains.Promotion, error)
+	DeletePromotion(ctx context.Context, id int) error
+	GetAllPromotions(ctx context.Context) ([]*domains.Promotion, error)
+	PriceCart(ctx context.Context, req *domains.PricingRequest) (*domains.PricingResult, error)
+	Apply(ctx context.Context, c *domains.Cart, couponCodes []string) (*domains.PricingResult, error)
+	Redeem(ctx context.Context, result *domains.PricingResult) error
+	Release(ctx context.Context, result *domains.PricingResult) error
+}
+
+type promotionService struct {
+	repo  PromotionRepository
+	carts cart.CartService
+}
+
+func NewPromotionService(repo PromotionRepository, carts cart.CartService) PromotionService {
+	return &promotionService{repo: repo, carts: carts}
+}
+
+func (s *promotionService) CreatePromotion(ctx context.Context, req *domains.PromotionRequest) (*domains.Promotion, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, req)
+}
+
+func (s *promotionService) GetPromotionByID(ctx context.Context, id int) (*domains.Promotion, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *promotionService) UpdatePromotion(ctx context.Context, id int, req *domains.PromotionRequest) (*domains.Promotion, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.Update(ctx, id, req)
+}
+
+func (s *promotionService) DeletePromotion(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *promotionService) GetAllPromotions(ctx context.Context) ([]*domains.Promotion, error) {
+	return s.repo.GetAll(ctx)
+}
+
+// PriceCart loads the cart and applies promotions to it without redeeming
+// anything.
+func (s *promotionService) PriceCart(ctx context.Context, req *domains.PricingRequest) (*domains.PricingResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	ref := cart.CartRef{Token: req.CartToken}
+	if req.UserID != nil {
+		ref.UserID = *req.UserID
+	}
+	c, err := s.carts.GetCart(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.Apply(ctx, c, req.CouponCodes)
+}
+
+// Apply prices an already loaded cart with the automatic promotions and
+// the given coupon codes. Coupons that are unknown, unusable or lose out to
+// a better combination are listed in RejectedCoupons.
+func (s *promotionService) Apply(ctx context.Context, c *domains.Cart, couponCodes []string) (*domains.PricingResult, error) {
+	var codes []string
+	for _, code := range couponCodes {
+		code = domains.NormalizeCouponCode(code)
+		if code != "" && !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+
+	candidates, err := s.repo.GetCandidates(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var usable []*domains.Promotion
+	var rejected []domains.RejectedCoupon
+	found := make(map[string]bool, len(codes))
+	for _, p := range candidates {
+		if p.CouponCode != "" {
+			found[p.CouponCode] = true
+		}
+		if reason := p.Unavailable(now); reason != "" {
+			if p.CouponCode != "" {
+				rejected = append(rejected, domains.RejectedCoupon{Code: p.CouponCode, Reason: reason})
+			}
+			continue
+		}
+		usable = append(usable, p)
+	}
+
+	lines, err := s.repo.GetLineAttributes(ctx, c.Items)
+	if err != nil {
+		return nil, err
+	}
+	result := Price(lines, usable)
+	result.Currency = c.Currency
+
+	for _, code := range codes {
+		applied := slices.ContainsFunc(result.AppliedPromotions, func(a domains.AppliedPromotion) bool { return a.CouponCode == code })
+		if applied || slices.ContainsFunc(rejected, func(r domains.RejectedCoupon) bool { return r.Code == code }) {
+			continue
+		}
+		reason := "not applicable to this cart"
+		if !found[code] {
+			reason = "unknown coupon code"
+		}
+		rejected = append(rejected, domains.RejectedCoupon{Code: code, Reason: reason})
+	}
+	result.RejectedCoupons = rejected
+
+	return result, nil
+}
+
+// Redeem counts a use of every promotion applied in result. It fails with
+// domains.ErrCouponExhausted if one was used up since the cart was priced.
+func (s *promotionService) Redeem(ctx context.Context, result *domains.PricingResult) error {
+	return s.repo.Redeem(ctx, appliedIDs(result))
+}
+
+func (s *promotionService) Release(ctx context.Context, result *domains.PricingResult) error {
+	return s.repo.Release(ctx, appliedIDs(result))
+}
+
+func appliedIDs(result *domains.PricingResult) []int {
+	ids := make([]int, 0, len(result.AppliedPromotions))
+	for _, applied := range result.AppliedPromotions {
+		ids = append(ids, applied.ID)
+	}
+	return ids
+}
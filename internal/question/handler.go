@@ -0,0 +1,417 @@
+package question
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type QuestionHandler struct {
+	service QuestionService
+}
+
+func NewQuestionHandler(service QuestionService) *QuestionHandler {
+	return &QuestionHandler{service: service}
+}
+
+func (h *QuestionHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/products/:id/questions", h.AskQuestion)
+	router.GET("/products/:id/questions", h.GetProductQuestions)
+
+	router.GET("/questions", h.GetQuestions)
+	router.GET("/questions/:id", h.GetQuestionByID)
+	router.PUT("/questions/:id/moderation", h.ModerateQuestion)
+	router.DELETE("/questions/:id", h.DeleteQuestion)
+	router.POST("/questions/:id/answers", h.AnswerQuestion)
+
+	router.GET("/answers", h.GetAnswers)
+	router.PUT("/answers/:id/moderation", h.ModerateAnswer)
+	router.DELETE("/answers/:id", h.DeleteAnswer)
+	router.POST("/answers/:id/upvote", h.UpvoteAnswer)
+}
+
+// @Summary Ask a question about a product
+// @Description Post a question about a product. The question is queued for moderation.
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param question body domains.QuestionRequest true "Question object"
+// @Success 201 {object} domains.Question
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /products/{id}/questions [post]
+func (h *QuestionHandler) AskQuestion(c *gin.Context) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	var req domains.QuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	question, err := h.service.AskQuestion(c.Request.Context(), productID, &req)
+	if err != nil {
+		c.JSON(questionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, question)
+}
+
+// @Summary Get product questions
+// @Description Get a page of a product's published questions, newest first, with their published answers
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param limit query int false "Page size (default 20)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} domains.Question
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /products/{id}/questions [get]
+func (h *QuestionHandler) GetProductQuestions(c *gin.Context) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	var filter domains.QuestionFilter
+	if !bindPage(c, &filter) {
+		return
+	}
+
+	questions, err := h.service.GetProductQuestions(c.Request.Context(), productID, filter.Limit, filter.Offset)
+	if err != nil {
+		c.JSON(questionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, questions)
+}
+
+// @Summary Get questions
+// @Description Get questions for moderation, newest first. Defaults to the pending queue.
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param status query string false "Question status" Enums(pending, approved, rejected) default(pending)
+// @Param product_id query int false "Product ID"
+// @Param limit query int false "Page size (default 20)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} domains.Question
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /questions [get]
+func (h *QuestionHandler) GetQuestions(c *gin.Context) {
+	filter, ok := moderationFilter(c)
+	if !ok {
+		return
+	}
+
+	questions, err := h.service.GetQuestions(c.Request.Context(), &filter)
+	if err != nil {
+		c.JSON(questionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, questions)
+}
+
+// @Summary Get question by ID
+// @Description Get a question, whatever its status, with its published answers
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path int true "Question ID"
+// @Success 200 {object} domains.Question
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /questions/{id} [get]
+func (h *QuestionHandler) GetQuestionByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid question id"})
+		return
+	}
+
+	question, err := h.service.GetQuestionByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(questionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// @Summary Moderate question
+// @Description Approve or reject a question
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path int true "Question ID"
+// @Param moderation body domains.ModerationRequest true "Moderation decision"
+// @Success 200 {object} domains.Question
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /questions/{id}/moderation [put]
+func (h *QuestionHandler) ModerateQuestion(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid question id"})
+		return
+	}
+
+	var req domains.ModerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	question, err := h.service.ModerateQuestion(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(questionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// @Summary Delete question
+// @Description Delete a question and its answers
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path int true "Question ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /questions/{id} [delete]
+func (h *QuestionHandler) DeleteQuestion(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid question id"})
+		return
+	}
+
+	if err := h.service.DeleteQuestion(c.Request.Context(), id); err != nil {
+		c.JSON(questionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Answer a question
+// @Description Answer a question as staff or as a customer. Staff answers are published immediately; customer answers are queued for moderation and need a published question.
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path int true "Question ID"
+// @Param answer body domains.AnswerRequest true "Answer object"
+// @Success 201 {object} domains.Answer
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /questions/{id}/answers [post]
+func (h *QuestionHandler) AnswerQuestion(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid question id"})
+		return
+	}
+
+	var req domains.AnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	answer, err := h.service.AnswerQuestion(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(questionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, answer)
+}
+
+// @Summary Get answers
+// @Description Get answers for moderation, newest first. Defaults to the pending queue.
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param status query string false "Answer status" Enums(pending, approved, rejected) default(pending)
+// @Param product_id query int false "Product ID"
+// @Param limit query int false "Page size (default 20)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} domains.Answer
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /answers [get]
+func (h *QuestionHandler) GetAnswers(c *gin.Context) {
+	filter, ok := moderationFilter(c)
+	if !ok {
+		return
+	}
+
+	answers, err := h.service.GetAnswers(c.Request.Context(), &filter)
+	if err != nil {
+		c.JSON(questionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, answers)
+}
+
+// @Summary Moderate answer
+// @Description Approve or reject an answer
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path int true "Answer ID"
+// @Param moderation body domains.ModerationRequest true "Moderation decision"
+// @Success 200 {object} domains.Answer
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /answers/{id}/moderation [put]
+func (h *QuestionHandler) ModerateAnswer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid answer id"})
+		return
+	}
+
+	var req domains.ModerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	answer, err := h.service.ModerateAnswer(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(questionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, answer)
+}
+
+// @Summary Delete answer
+// @Description Delete an answer and its votes
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path int true "Answer ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /answers/{id} [delete]
+func (h *QuestionHandler) DeleteAnswer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid answer id"})
+		return
+	}
+
+	if err := h.service.DeleteAnswer(c.Request.Context(), id); err != nil {
+		c.JSON(questionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Upvote answer
+// @Description Mark a published answer as helpful. Each customer counts once.
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path int true "Answer ID"
+// @Param vote body domains.UpvoteRequest true "Voting customer"
+// @Success 200 {object} domains.Answer
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /answers/{id}/upvote [post]
+func (h *QuestionHandler) UpvoteAnswer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid answer id"})
+		return
+	}
+
+	var req domains.UpvoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	answer, err := h.service.UpvoteAnswer(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(questionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, answer)
+}
+
+// moderationFilter reads the moderation queue query: status (pending by
+// default), product_id and the page.
+func moderationFilter(c *gin.Context) (domains.QuestionFilter, bool) {
+	filter := domains.QuestionFilter{Status: domains.ModerationStatus(c.DefaultQuery("status", string(domains.ModerationPending)))}
+	if productID := c.Query("product_id"); productID != "" {
+		id, err := strconv.Atoi(productID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+			return filter, false
+		}
+		filter.ProductID = id
+	}
+	return filter, bindPage(c, &filter)
+}
+
+func bindPage(c *gin.Context, filter *domains.QuestionFilter) bool {
+	for param, dest := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		if raw := c.Query(param); raw != "" {
+			value, err := strconv.Atoi(raw)
+			if err != nil || value < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param})
+				return false
+			}
+			*dest = value
+		}
+	}
+	return true
+}
+
+// questionErrorStatus maps a foreign key violation to 404: the only
+// reference a request can break is the product asked about.
+func questionErrorStatus(err error) int {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, domains.ErrInvalidQuestion), errors.Is(err, domains.ErrInvalidModeration):
+		return http.StatusBadRequest
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23503":
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
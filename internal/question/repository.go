@@ -0,0 +1,363 @@
+package question
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/domains"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultListLimit = 20
+
+type QuestionRepository interface {
+	CreateQuestion(ctx context.Context, productID int, req *domains.QuestionRequest) (*domains.Question, error)
+	GetQuestionByID(ctx context.Context, id int) (*domains.Question, error)
+	GetQuestions(ctx context.Context, filter *domains.QuestionFilter) ([]*domains.Question, error)
+	ModerateQuestion(ctx context.Context, id int, req *domains.ModerationRequest) (*domains.Question, error)
+	DeleteQuestion(ctx context.Context, id int) error
+	CreateAnswer(ctx context.Context, questionID int, req *domains.AnswerRequest, status domains.ModerationStatus) (*domains.Answer, error)
+	GetAnswerByID(ctx context.Context, id int) (*domains.Answer, error)
+	GetAnswers(ctx context.Context, filter *domains.QuestionFilter) ([]*domains.Answer, error)
+	GetApprovedAnswers(ctx context.Context, questionIDs []int) (map[int][]domains.Answer, error)
+	ModerateAnswer(ctx context.Context, id int, req *domains.ModerationRequest) (*domains.Answer, error)
+	DeleteAnswer(ctx context.Context, id int) error
+	Upvote(ctx context.Context, answerID, userID int) error
+}
+
+type questionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewQuestionRepository(db *pgxpool.Pool) QuestionRepository {
+	return &questionRepository{db: db}
+}
+
+const questionColumns = `
+        id, product_id, user_id, body, status, COALESCE(moderation_note, ''), moderated_at, created_at, updated_at`
+
+func scanQuestion(row pgx.Row) (*domains.Question, error) {
+	question := &domains.Question{}
+	err := row.Scan(
+		&question.ID,
+		&question.ProductID,
+		&question.UserID,
+		&question.Body,
+		&question.Status,
+		&question.ModerationNote,
+		&question.ModeratedAt,
+		&question.CreatedAt,
+		&question.UpdatedAt,
+	)
+	return question, err
+}
+
+const answerColumns = `
+        id, question_id, user_id, is_staff, body, status, upvotes, COALESCE(moderation_note, ''), moderated_at,
+        created_at, updated_at`
+
+func scanAnswer(row pgx.Row) (*domains.Answer, error) {
+	answer := &domains.Answer{}
+	err := row.Scan(
+		&answer.ID,
+		&answer.QuestionID,
+		&answer.UserID,
+		&answer.IsStaff,
+		&answer.Body,
+		&answer.Status,
+		&answer.Upvotes,
+		&answer.ModerationNote,
+		&answer.ModeratedAt,
+		&answer.CreatedAt,
+		&answer.UpdatedAt,
+	)
+	return answer, err
+}
+
+// listQuery appends the filter's conditions to query, newest first, and pages
+// the result. productColumn is the column holding the product ID.
+func listQuery(query, productColumn string, filter *domains.QuestionFilter) (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if filter.ProductID != 0 {
+		args = append(args, filter.ProductID)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", productColumn, len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	args = append(args, limit, filter.Offset)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	return query, args
+}
+
+func (r *questionRepository) CreateQuestion(ctx context.Context, productID int, req *domains.QuestionRequest) (*domains.Question, error) {
+	const insertQuery = `
+        INSERT INTO product_questions (product_id, user_id, body)
+        VALUES ($1, $2, $3)
+        RETURNING` + questionColumns
+
+	question, err := scanQuestion(r.db.QueryRow(ctx, insertQuery, productID, req.UserID, req.Body))
+	if err != nil {
+		logrus.WithError(err).WithField("question", req).Errorf("Failed to insert question (product_id: %d)", productID)
+		return nil, err
+	}
+
+	logrus.Debugf("Question created successfully (ID: %d)", question.ID)
+	return question, nil
+}
+
+func (r *questionRepository) GetQuestionByID(ctx context.Context, id int) (*domains.Question, error) {
+	const getQuery = `SELECT` + questionColumns + ` FROM product_questions WHERE id = $1`
+
+	question, err := scanQuestion(r.db.QueryRow(ctx, getQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Question not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get question (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Question retrieved successfully (ID: %d)", question.ID)
+	return question, nil
+}
+
+func (r *questionRepository) GetQuestions(ctx context.Context, filter *domains.QuestionFilter) ([]*domains.Question, error) {
+	query, args := listQuery(`SELECT`+questionColumns+` FROM product_questions`, "product_id", filter)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("Failed to query questions: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	questions := []*domains.Question{}
+	for rows.Next() {
+		question, err := scanQuestion(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan question row: %v", err)
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating question rows: %v", err)
+		return nil, err
+	}
+
+	logrus.Debugf("Questions retrieved successfully (Count: %d)", len(questions))
+	return questions, nil
+}
+
+func (r *questionRepository) ModerateQuestion(ctx context.Context, id int, req *domains.ModerationRequest) (*domains.Question, error) {
+	const moderateQuery = `
+        UPDATE product_questions
+        SET status = $1, moderation_note = NULLIF($2, ''),
+            moderated_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $3
+        RETURNING` + questionColumns
+
+	question, err := scanQuestion(r.db.QueryRow(ctx, moderateQuery, req.Status, req.Note, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to moderate non-existent question (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to moderate question (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Question moderated successfully (ID: %d, status: %s)", question.ID, question.Status)
+	return question, nil
+}
+
+func (r *questionRepository) DeleteQuestion(ctx context.Context, id int) error {
+	const deleteQuery = `DELETE FROM product_questions WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, deleteQuery, id)
+	if err != nil {
+		logrus.Errorf("Failed to delete question (ID: %d): %v", id, err)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		logrus.Infof("Attempted to delete non-existent question (ID: %d)", id)
+		return sql.ErrNoRows
+	}
+
+	logrus.Debugf("Question deleted successfully (ID: %d)", id)
+	return nil
+}
+
+func (r *questionRepository) CreateAnswer(ctx context.Context, questionID int, req *domains.AnswerRequest, status domains.ModerationStatus) (*domains.Answer, error) {
+	const insertQuery = `
+        INSERT INTO product_answers (question_id, user_id, is_staff, body, status)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING` + answerColumns
+
+	answer, err := scanAnswer(r.db.QueryRow(ctx, insertQuery, questionID, req.UserID, req.IsStaff, req.Body, status))
+	if err != nil {
+		logrus.WithError(err).WithField("answer", req).Errorf("Failed to insert answer (question_id: %d)", questionID)
+		return nil, err
+	}
+
+	logrus.Debugf("Answer created successfully (ID: %d)", answer.ID)
+	return answer, nil
+}
+
+func (r *questionRepository) GetAnswerByID(ctx context.Context, id int) (*domains.Answer, error) {
+	const getQuery = `SELECT` + answerColumns + ` FROM product_answers WHERE id = $1`
+
+	answer, err := scanAnswer(r.db.QueryRow(ctx, getQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Answer not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get answer (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Answer retrieved successfully (ID: %d)", answer.ID)
+	return answer, nil
+}
+
+func (r *questionRepository) GetAnswers(ctx context.Context, filter *domains.QuestionFilter) ([]*domains.Answer, error) {
+	query, args := listQuery(`SELECT`+answerColumns+` FROM product_answers`,
+		"(SELECT product_id FROM product_questions WHERE product_questions.id = question_id)", filter)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("Failed to query answers: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	answers := []*domains.Answer{}
+	for rows.Next() {
+		answer, err := scanAnswer(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan answer row: %v", err)
+			return nil, err
+		}
+		answers = append(answers, answer)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating answer rows: %v", err)
+		return nil, err
+	}
+
+	logrus.Debugf("Answers retrieved successfully (Count: %d)", len(answers))
+	return answers, nil
+}
+
+// GetApprovedAnswers returns the approved answers of the questions keyed by
+// question ID, staff answers first, then by upvotes.
+func (r *questionRepository) GetApprovedAnswers(ctx context.Context, questionIDs []int) (map[int][]domains.Answer, error) {
+	const getQuery = `
+        SELECT` + answerColumns + `
+        FROM product_answers
+        WHERE question_id = ANY($1) AND status = 'approved'
+        ORDER BY is_staff DESC, upvotes DESC, created_at, id`
+
+	rows, err := r.db.Query(ctx, getQuery, questionIDs)
+	if err != nil {
+		logrus.Errorf("Failed to query approved answers: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	answers := make(map[int][]domains.Answer, len(questionIDs))
+	for rows.Next() {
+		answer, err := scanAnswer(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan answer row: %v", err)
+			return nil, err
+		}
+		answers[answer.QuestionID] = append(answers[answer.QuestionID], *answer)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating answer rows: %v", err)
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+func (r *questionRepository) ModerateAnswer(ctx context.Context, id int, req *domains.ModerationRequest) (*domains.Answer, error) {
+	const moderateQuery = `
+        UPDATE product_answers
+        SET status = $1, moderation_note = NULLIF($2, ''),
+            moderated_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $3
+        RETURNING` + answerColumns
+
+	answer, err := scanAnswer(r.db.QueryRow(ctx, moderateQuery, req.Status, req.Note, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to moderate non-existent answer (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to moderate answer (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Answer moderated successfully (ID: %d, status: %s)", answer.ID, answer.Status)
+	return answer, nil
+}
+
+func (r *questionRepository) DeleteAnswer(ctx context.Context, id int) error {
+	const deleteQuery = `DELETE FROM product_answers WHERE id = $1`
+
+	result, err := r.db.Exec(ctx, deleteQuery, id)
+	if err != nil {
+		logrus.Errorf("Failed to delete answer (ID: %d): %v", id, err)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		logrus.Infof("Attempted to delete non-existent answer (ID: %d)", id)
+		return sql.ErrNoRows
+	}
+
+	logrus.Debugf("Answer deleted successfully (ID: %d)", id)
+	return nil
+}
+
+// Upvote records the customer's vote on the answer. Voting twice is a
+// no-op.
+func (r *questionRepository) Upvote(ctx context.Context, answerID, userID int) error {
+	const upvoteQuery = `
+        WITH vote AS (
+            INSERT INTO product_answer_votes (answer_id, user_id) VALUES ($1, $2)
+            ON CONFLICT DO NOTHING
+            RETURNING answer_id
+        )
+        UPDATE product_answers SET upvotes = upvotes + 1
+        WHERE id IN (SELECT answer_id FROM vote)`
+
+	if _, err := r.db.Exec(ctx, upvoteQuery, answerID, userID); err != nil {
+		logrus.Errorf("Failed to upvote answer (ID: %d, user_id: %d): %v", answerID, userID, err)
+		return err
+	}
+
+	logrus.Debugf("Answer upvoted successfully (ID: %d, user_id: %d)", answerID, userID)
+	return nil
+}
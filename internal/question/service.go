@@ -0,0 +1,164 @@
+package question
+
+import (
+	"context"
+	"e-commerce/internal/domains"
+	"fmt"
+)
+
+type QuestionService interface {
+	AskQuestion(ctx context.Context, productID int, req *domains.QuestionRequest) (*domains.Question, error)
+	GetQuestionByID(ctx context.Context, id int) (*domains.Question, error)
+	GetProductQuestions(ctx context.Context, productID, limit, offset int) ([]*domains.Question, error)
+	GetQuestions(ctx context.Context, filter *domains.QuestionFilter) ([]*domains.Question, error)
+	ModerateQuestion(ctx context.Context, id int, req *domains.ModerationRequest) (*domains.Question, error)
+	DeleteQuestion(ctx context.Context, id int) error
+	AnswerQuestion(ctx context.Context, questionID int, req *domains.AnswerRequest) (*domains.Answer, error)
+	GetAnswers(ctx context.Context, filter *domains.QuestionFilter) ([]*domains.Answer, error)
+	ModerateAnswer(ctx context.Context, id int, req *domains.ModerationRequest) (*domains.Answer, error)
+	DeleteAnswer(ctx context.Context, id int) error
+	UpvoteAnswer(ctx context.Context, id int, req *domains.UpvoteRequest) (*domains.Answer, error)
+}
+
+type questionService struct {
+	repo QuestionRepository
+}
+
+func NewQuestionService(repo QuestionRepository) QuestionService {
+	return &questionService{repo: repo}
+}
+
+// AskQuestion queues the question for moderation.
+func (s *questionService) AskQuestion(ctx context.Context, productID int, req *domains.QuestionRequest) (*domains.Question, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateQuestion(ctx, productID, req)
+}
+
+// GetQuestionByID returns the question, whatever its status, with its
+// approved answers.
+func (s *questionService) GetQuestionByID(ctx context.Context, id int) (*domains.Question, error) {
+	question, err := s.repo.GetQuestionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachAnswers(ctx, []*domains.Question{question}); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+// GetProductQuestions returns a page of a product's approved questions,
+// newest first, with their approved answers.
+func (s *questionService) GetProductQuestions(ctx context.Context, productID, limit, offset int) ([]*domains.Question, error) {
+	questions, err := s.repo.GetQuestions(ctx, &domains.QuestionFilter{
+		ProductID: productID,
+		Status:    domains.ModerationApproved,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachAnswers(ctx, questions); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (s *questionService) GetQuestions(ctx context.Context, filter *domains.QuestionFilter) ([]*domains.Question, error) {
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, fmt.Errorf("%w: unknown status %q", domains.ErrInvalidQuestion, filter.Status)
+	}
+	return s.repo.GetQuestions(ctx, filter)
+}
+
+func (s *questionService) ModerateQuestion(ctx context.Context, id int, req *domains.ModerationRequest) (*domains.Question, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.ModerateQuestion(ctx, id, req)
+}
+
+func (s *questionService) DeleteQuestion(ctx context.Context, id int) error {
+	return s.repo.DeleteQuestion(ctx, id)
+}
+
+// AnswerQuestion publishes staff answers right away and queues the others
+// for moderation. Customers can only answer published questions.
+func (s *questionService) AnswerQuestion(ctx context.Context, questionID int, req *domains.AnswerRequest) (*domains.Answer, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	question, err := s.repo.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := domains.ModerationPending
+	if req.IsStaff {
+		status = domains.ModerationApproved
+	} else if question.Status != domains.ModerationApproved {
+		return nil, fmt.Errorf("%w: question %d is not published", domains.ErrInvalidQuestion, questionID)
+	}
+	return s.repo.CreateAnswer(ctx, questionID, req, status)
+}
+
+func (s *questionService) GetAnswers(ctx context.Context, filter *domains.QuestionFilter) ([]*domains.Answer, error) {
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, fmt.Errorf("%w: unknown status %q", domains.ErrInvalidQuestion, filter.Status)
+	}
+	return s.repo.GetAnswers(ctx, filter)
+}
+
+func (s *questionService) ModerateAnswer(ctx context.Context, id int, req *domains.ModerationRequest) (*domains.Answer, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.ModerateAnswer(ctx, id, req)
+}
+
+func (s *questionService) DeleteAnswer(ctx context.Context, id int) error {
+	return s.repo.DeleteAnswer(ctx, id)
+}
+
+// UpvoteAnswer counts one vote per customer on a published answer.
+func (s *questionService) UpvoteAnswer(ctx context.Context, id int, req *domains.UpvoteRequest) (*domains.Answer, error) {
+	if req.UserID <= 0 {
+		return nil, fmt.Errorf("%w: user_id is required", domains.ErrInvalidQuestion)
+	}
+
+	answer, err := s.repo.GetAnswerByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if answer.Status != domains.ModerationApproved {
+		return nil, fmt.Errorf("%w: answer %d is not published", domains.ErrInvalidQuestion, id)
+	}
+
+	if err := s.repo.Upvote(ctx, id, req.UserID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAnswerByID(ctx, id)
+}
+
+func (s *questionService) attachAnswers(ctx context.Context, questions []*domains.Question) error {
+	if len(questions) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(questions))
+	for _, question := range questions {
+		ids = append(ids, question.ID)
+	}
+	answers, err := s.repo.GetApprovedAnswers(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, question := range questions {
+		question.Answers = answers[question.ID]
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package recommendation
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecommendationHandler struct {
+	service RecommendationService
+}
+
+func NewRecommendationHandler(service RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{service: service}
+}
+
+func (h *RecommendationHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/questionnaire", h.GetQuestionnaire)
+	router.POST("/questionnaire/profile", h.BuildProfile)
+	router.POST("/questionnaire/questions", h.CreateQuestion)
+	router.GET("/questionnaire/questions/:id", h.GetQuestionByID)
+	router.PUT("/questionnaire/questions/:id", h.UpdateQuestion)
+	router.DELETE("/questionnaire/questions/:id", h.DeleteQuestion)
+	router.GET("/recommendations", h.GetRecommendations)
+}
+
+// @Summary Get the skin questionnaire
+// @Description Get all questions with their options in display order
+// @Tags recommendations
+// @Accept json
+// @Produce json
+// @Success 200 {array} domains.QuestionnaireQuestion
+// @Failure 500 {object} domains.Error
+// @Router /questionnaire [get]
+func (h *RecommendationHandler) GetQuestionnaire(c *gin.Context) {
+	questions, err := h.service.GetQuestionnaire(c.Request.Context())
+	if err != nil {
+		c.JSON(recommendationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, questions)
+}
+
+// @Summary Build a skin profile
+// @Description Turn questionnaire answers into a skin profile of weighted skin types and concerns
+// @Tags recommendations
+// @Accept json
+// @Produce json
+// @Param answers body domains.QuestionnaireAnswers true "Chosen options"
+// @Success 200 {object} domains.SkinProfile
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /questionnaire/profile [post]
+func (h *RecommendationHandler) BuildProfile(c *gin.Context) {
+	var answers domains.QuestionnaireAnswers
+	if err := c.ShouldBindJSON(&answers); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := h.service.BuildProfile(c.Request.Context(), &answers)
+	if err != nil {
+		c.JSON(recommendationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// @Summary Create a questionnaire question
+// @Description Add a question with its options and the weights each option adds to the profile
+// @Tags recommendations
+// @Accept json
+// @Produce json
+// @Param question body domains.QuestionnaireQuestionRequest true "Question"
+// @Success 201 {object} domains.QuestionnaireQuestion
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /questionnaire/questions [post]
+func (h *RecommendationHandler) CreateQuestion(c *gin.Context) {
+	var req domains.QuestionnaireQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	question, err := h.service.CreateQuestion(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(recommendationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, question)
+}
+
+// @Summary Get questionnaire question by ID
+// @Description Get a question with its options
+// @Tags recommendations
+// @Accept json
+// @Produce json
+// @Param id path int true "Question ID"
+// @Success 200 {object} domains.QuestionnaireQuestion
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /questionnaire/questions/{id} [get]
+func (h *RecommendationHandler) GetQuestionByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid question id"})
+		return
+	}
+
+	question, err := h.service.GetQuestionByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(recommendationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// @Summary Update questionnaire question
+// @Description Replace a question and all of its options; the options get new IDs
+// @Tags recommendations
+// @Accept json
+// @Produce json
+// @Param id path int true "Question ID"
+// @Param question body domains.QuestionnaireQuestionRequest true "Question"
+// @Success 200 {object} domains.QuestionnaireQuestion
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /questionnaire/questions/{id} [put]
+func (h *RecommendationHandler) UpdateQuestion(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid question id"})
+		return
+	}
+
+	var req domains.QuestionnaireQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	question, err := h.service.UpdateQuestion(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(recommendationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// @Summary Delete questionnaire question
+// @Description Delete a question and its options
+// @Tags recommendations
+// @Accept json
+// @Produce json
+// @Param id path int true "Question ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /questionnaire/questions/{id} [delete]
+func (h *RecommendationHandler) DeleteQuestion(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid question id"})
+		return
+	}
+
+	if err := h.service.DeleteQuestion(c.Request.Context(), id); err != nil {
+		c.JSON(recommendationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get product recommendations
+// @Description Rank products by how well they match a skin profile, their rating and brand diversity. Pass the profile returned by the questionnaire as comma-separated "key:weight" pairs; a missing weight counts as 1.
+// @Tags recommendations
+// @Accept json
+// @Produce json
+// @Param skin_types query string false "Skin type weights, e.g. 2:0.6,5:0.4"
+// @Param concerns query string false "Concern weights, e.g. acne:0.7,sensitivity:0.3"
+// @Param limit query int false "Number of products to return"
+// @Success 200 {array} domains.Recommendation
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /recommendations [get]
+func (h *RecommendationHandler) GetRecommendations(c *gin.Context) {
+	skinTypes, ok := parseWeights(c.Query("skin_types"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid skin_types"})
+		return
+	}
+	concerns, ok := parseWeights(c.Query("concerns"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid concerns"})
+		return
+	}
+
+	var profile domains.SkinProfile
+	for key, weight := range skinTypes {
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid skin_types"})
+			return
+		}
+		profile.SkinTypes = append(profile.SkinTypes, domains.SkinTypeWeight{SkinTypeID: id, Weight: weight})
+	}
+	for key, weight := range concerns {
+		profile.Concerns = append(profile.Concerns, domains.ConcernWeight{Concern: domains.Concern(key), Weight: weight})
+	}
+
+	var limit int
+	if param := c.Query("limit"); param != "" {
+		value, err := strconv.Atoi(param)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		limit = value
+	}
+
+	recommendations, err := h.service.GetRecommendations(c.Request.Context(), &profile, limit)
+	if err != nil {
+		c.JSON(recommendationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, recommendations)
+}
+
+// parseWeights reads comma-separated "key:weight" pairs; a pair without a
+// weight counts as 1. It reports false for a missing key or a weight that
+// is not a finite non-negative number.
+func parseWeights(param string) (map[string]float64, bool) {
+	weights := make(map[string]float64)
+	if param == "" {
+		return weights, true
+	}
+
+	for _, pair := range strings.Split(param, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(pair), ":")
+		weight := 1.0
+		if found {
+			var err error
+			weight, err = strconv.ParseFloat(value, 64)
+			if err != nil || !(weight >= 0) || math.IsInf(weight, 1) {
+				return nil, false
+			}
+		}
+		if key == "" {
+			return nil, false
+		}
+		weights[key] += weight
+	}
+
+	return weights, true
+}
+
+func recommendationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domains.ErrInvalidQuestionnaire), errors.Is(err, domains.ErrInvalidRecommendation):
+		return http.StatusBadRequest
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
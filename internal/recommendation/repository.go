@@ -0,0 +1,367 @@
+package recommendation
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/cache"
+	"e-commerce/internal/domains"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+type RecommendationRepository interface {
+	CreateQuestion(ctx context.Context, req *domains.QuestionnaireQuestionRequest) (*domains.QuestionnaireQuestion, error)
+	GetQuestionByID(ctx context.Context, id int) (*domains.QuestionnaireQuestion, error)
+	UpdateQuestion(ctx context.Context, id int, req *domains.QuestionnaireQuestionRequest) (*domains.QuestionnaireQuestion, error)
+	DeleteQuestion(ctx context.Context, id int) error
+	GetQuestions(ctx context.Context) ([]*domains.QuestionnaireQuestion, error)
+	GetAnsweredQuestions(ctx context.Context, optionIDs []int) ([]*domains.QuestionnaireQuestion, error)
+	GetCandidates(ctx context.Context, skinTypeIDs []int, concerns []domains.Concern) ([]*domains.RecommendationCandidate, error)
+	GetCached(ctx context.Context, key string) ([]*domains.Recommendation, error)
+	SetCached(ctx context.Context, key string, recommendations []*domains.Recommendation) error
+}
+
+type recommendationRepository struct {
+	db    *pgxpool.Pool
+	cache cache.CacheRepository[domains.Recommendation]
+}
+
+func NewRecommendationRepository(db *pgxpool.Pool, cacheClient *cache.Cache) RecommendationRepository {
+	return &recommendationRepository{
+		db:    db,
+		cache: cache.NewCacheRepository[domains.Recommendation](cacheClient, "recommendation"),
+	}
+}
+
+const questionColumns = `
+        id, text, position, multiple_choice, created_at, updated_at`
+
+func scanQuestion(row pgx.Row) (*domains.QuestionnaireQuestion, error) {
+	question := &domains.QuestionnaireQuestion{}
+	err := row.Scan(
+		&question.ID,
+		&question.Text,
+		&question.Position,
+		&question.MultipleChoice,
+		&question.CreatedAt,
+		&question.UpdatedAt,
+	)
+	return question, err
+}
+
+const insertOptionQuery = `
+    INSERT INTO questionnaire_options (question_id, text, position, skin_type_weights, concern_weights)
+    VALUES ($1, $2, $3, $4, $5)`
+
+func insertOptions(ctx context.Context, tx pgx.Tx, questionID int, options []domains.QuestionnaireOptionRequest) error {
+	for i, option := range options {
+		skinTypes, concerns := option.SkinTypes, option.Concerns
+		if skinTypes == nil {
+			skinTypes = []domains.SkinTypeWeight{}
+		}
+		if concerns == nil {
+			concerns = []domains.ConcernWeight{}
+		}
+		if _, err := tx.Exec(ctx, insertOptionQuery, questionID, option.Text, i+1, skinTypes, concerns); err != nil {
+			logrus.WithError(err).Errorf("Failed to insert questionnaire option (question_id: %d, position: %d)", questionID, i+1)
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *recommendationRepository) CreateQuestion(ctx context.Context, req *domains.QuestionnaireQuestionRequest) (*domains.QuestionnaireQuestion, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	const insertQuery = `
+        INSERT INTO questionnaire_questions (text, position, multiple_choice)
+        VALUES ($1, $2, $3)
+        RETURNING id`
+
+	var questionID int
+	if err = tx.QueryRow(ctx, insertQuery, req.Text, req.Position, req.MultipleChoice).Scan(&questionID); err != nil {
+		logrus.WithError(err).WithField("question", req).Error("Failed to insert questionnaire question")
+		return nil, err
+	}
+	if err = insertOptions(ctx, tx, questionID, req.Options); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+
+	logrus.Debugf("Questionnaire question created successfully (ID: %d)", questionID)
+	return r.GetQuestionByID(ctx, questionID)
+}
+
+func (r *recommendationRepository) GetQuestionByID(ctx context.Context, id int) (*domains.QuestionnaireQuestion, error) {
+	const getQuery = `SELECT` + questionColumns + ` FROM questionnaire_questions WHERE id = $1`
+
+	question, err := scanQuestion(r.db.QueryRow(ctx, getQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Questionnaire question not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get questionnaire question (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	if err := r.attachOptions(ctx, []*domains.QuestionnaireQuestion{question}, nil); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+// UpdateQuestion replaces the question and all of its options.
+func (r *recommendationRepository) UpdateQuestion(ctx context.Context, id int, req *domains.QuestionnaireQuestionRequest) (*domains.QuestionnaireQuestion, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	const updateQuery = `
+        UPDATE questionnaire_questions
+        SET text = $1, position = $2, multiple_choice = $3, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $4
+        RETURNING id`
+
+	var questionID int
+	if err = tx.QueryRow(ctx, updateQuery, req.Text, req.Position, req.MultipleChoice, id).Scan(&questionID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to update non-existent questionnaire question (ID: %d)", id)
+			err = sql.ErrNoRows
+			return nil, err
+		}
+		logrus.Errorf("Failed to update questionnaire question (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	const deleteOptionsQuery = `DELETE FROM questionnaire_options WHERE question_id = $1`
+	if _, err = tx.Exec(ctx, deleteOptionsQuery, id); err != nil {
+		logrus.Errorf("Failed to delete old options of questionnaire question (ID: %d): %v", id, err)
+		return nil, err
+	}
+	if err = insertOptions(ctx, tx, id, req.Options); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+
+	logrus.Debugf("Questionnaire question updated successfully (ID: %d)", id)
+	return r.GetQuestionByID(ctx, id)
+}
+
+func (r *recommendationRepository) DeleteQuestion(ctx context.Context, id int) error {
+	const deleteQuery = `DELETE FROM questionnaire_questions WHERE id = $1 RETURNING id`
+
+	var deletedID int
+	err := r.db.QueryRow(ctx, deleteQuery, id).Scan(&deletedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to delete non-existent questionnaire question (ID: %d)", id)
+			return sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to delete questionnaire question (ID: %d): %v", id, err)
+		return err
+	}
+
+	logrus.Debugf("Questionnaire question deleted successfully (ID: %d)", deletedID)
+	return nil
+}
+
+func (r *recommendationRepository) GetQuestions(ctx context.Context) ([]*domains.QuestionnaireQuestion, error) {
+	const listQuery = `SELECT` + questionColumns + ` FROM questionnaire_questions ORDER BY position, id`
+	return r.queryQuestions(ctx, nil, listQuery)
+}
+
+// GetAnsweredQuestions returns the questions the options belong to, each
+// with only those of its options that are among optionIDs.
+func (r *recommendationRepository) GetAnsweredQuestions(ctx context.Context, optionIDs []int) ([]*domains.QuestionnaireQuestion, error) {
+	const answeredQuery = `
+        SELECT` + questionColumns + `
+        FROM questionnaire_questions
+        WHERE id IN (SELECT question_id FROM questionnaire_options WHERE id = ANY($1))
+        ORDER BY position, id`
+	return r.queryQuestions(ctx, optionIDs, answeredQuery, optionIDs)
+}
+
+func (r *recommendationRepository) queryQuestions(ctx context.Context, optionIDs []int, query string, args ...interface{}) ([]*domains.QuestionnaireQuestion, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("Failed to query questionnaire questions: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	questions := []*domains.QuestionnaireQuestion{}
+	for rows.Next() {
+		question, err := scanQuestion(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan questionnaire question row: %v", err)
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating questionnaire question rows: %v", err)
+		return nil, err
+	}
+
+	if err := r.attachOptions(ctx, questions, optionIDs); err != nil {
+		return nil, err
+	}
+
+	logrus.Debugf("Questionnaire questions retrieved successfully (Count: %d)", len(questions))
+	return questions, nil
+}
+
+// attachOptions loads the options of the questions in display order. A
+// non-nil optionIDs restricts them to those options.
+func (r *recommendationRepository) attachOptions(ctx context.Context, questions []*domains.QuestionnaireQuestion, optionIDs []int) error {
+	if len(questions) == 0 {
+		return nil
+	}
+	byID := make(map[int]*domains.QuestionnaireQuestion, len(questions))
+	questionIDs := make([]int, 0, len(questions))
+	for _, question := range questions {
+		question.Options = []domains.QuestionnaireOption{}
+		byID[question.ID] = question
+		questionIDs = append(questionIDs, question.ID)
+	}
+
+	const optionsQuery = `
+        SELECT id, question_id, text, skin_type_weights, concern_weights
+        FROM questionnaire_options
+        WHERE question_id = ANY($1) AND ($2::int[] IS NULL OR id = ANY($2))
+        ORDER BY question_id, position`
+
+	rows, err := r.db.Query(ctx, optionsQuery, questionIDs, optionIDs)
+	if err != nil {
+		logrus.Errorf("Failed to query questionnaire options: %v", err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			option     domains.QuestionnaireOption
+			questionID int
+		)
+		if err := rows.Scan(&option.ID, &questionID, &option.Text, &option.SkinTypes, &option.Concerns); err != nil {
+			logrus.Errorf("Failed to scan questionnaire option row: %v", err)
+			return err
+		}
+		question := byID[questionID]
+		question.Options = append(question.Options, option)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating questionnaire option rows: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetCandidates returns the products made for any of the skin types or
+// addressing any of the concerns, with the matching ones of each.
+func (r *recommendationRepository) GetCandidates(ctx context.Context, skinTypeIDs []int, concerns []domains.Concern) ([]*domains.RecommendationCandidate, error) {
+	const candidatesQuery = `
+        SELECT p.id, p.name, p.price, p.sale_price, p.sale_starts_at, p.sale_ends_at,
+               b.id, b.name, p.rating_average, p.rating_count,
+               COALESCE((SELECT SUM(sl.quantity) FROM stock_levels sl JOIN warehouses w ON w.id = sl.warehouse_id WHERE sl.product_id = p.id AND w.is_active), 0),
+               ARRAY(SELECT pst.skin_type_id FROM product_skin_types pst
+                     WHERE pst.product_id = p.id AND pst.skin_type_id = ANY($1) ORDER BY pst.skin_type_id),
+               ARRAY(SELECT c FROM unnest(p.concerns) AS c WHERE c = ANY($2::text[]) ORDER BY c)
+        FROM products p
+        LEFT JOIN brands b ON b.id = p.brand_id
+        WHERE EXISTS (SELECT 1 FROM product_skin_types pst WHERE pst.product_id = p.id AND pst.skin_type_id = ANY($1))
+           OR p.concerns && $2::text[]`
+
+	rows, err := r.db.Query(ctx, candidatesQuery, skinTypeIDs, concerns)
+	if err != nil {
+		logrus.Errorf("Failed to query recommendation candidates: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []*domains.RecommendationCandidate
+	for rows.Next() {
+		var (
+			candidate domains.RecommendationCandidate
+			brandID   *int
+			brandName *string
+		)
+		product := &candidate.Product
+		if err := rows.Scan(
+			&product.ID,
+			&product.Name,
+			&product.OriginalPrice,
+			&product.SalePrice,
+			&product.SaleStartsAt,
+			&product.SaleEndsAt,
+			&brandID,
+			&brandName,
+			&product.RatingAverage,
+			&product.RatingCount,
+			&product.StockQuantity,
+			&candidate.SkinTypeIDs,
+			&candidate.Concerns,
+		); err != nil {
+			logrus.Errorf("Failed to scan recommendation candidate row: %v", err)
+			return nil, err
+		}
+		if brandID != nil {
+			product.Brand = &domains.Brand{ID: *brandID, Name: *brandName}
+		}
+		product.InStock = product.StockQuantity > 0
+		candidates = append(candidates, &candidate)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating recommendation candidate rows: %v", err)
+		return nil, err
+	}
+
+	logrus.Debugf("Recommendation candidates retrieved successfully (Count: %d)", len(candidates))
+	return candidates, nil
+}
+
+func (r *recommendationRepository) GetCached(ctx context.Context, key string) ([]*domains.Recommendation, error) {
+	recommendations, err := r.cache.GetByKey(ctx, key)
+	if err != nil {
+		if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
+			logrus.Errorf("Cache lookup failed for recommendations (key: %s): %v", key, err)
+		}
+		return nil, err
+	}
+	logrus.Debugf("Cache hit for recommendations (key: %s)", key)
+	return recommendations, nil
+}
+
+func (r *recommendationRepository) SetCached(ctx context.Context, key string, recommendations []*domains.Recommendation) error {
+	return r.cache.SetByKey(ctx, key, recommendations)
+}
@@ -0,0 +1,288 @@
+package recommendation
+
+import (
+	"context"
+	"e-commerce/internal/config"
+	"e-commerce/internal/domains"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultMatchWeight  = 0.7
+	defaultRatingWeight = 0.3
+	defaultBrandPenalty = 0.25
+	defaultRatingPrior  = 5
+	defaultLimit        = 20
+	defaultMaxLimit     = 50
+
+	// priorRating is the rating products are assumed to have before any
+	// reviews, halfway between domains.MinRating and domains.MaxRating.
+	priorRating = float64(domains.MinRating+domains.MaxRating) / 2
+)
+
+type RecommendationService interface {
+	CreateQuestion(ctx context.Context, req *domains.QuestionnaireQuestionRequest) (*domains.QuestionnaireQuestion, error)
+	GetQuestionByID(ctx context.Context, id int) (*domains.QuestionnaireQuestion, error)
+	UpdateQuestion(ctx context.Context, id int, req *domains.QuestionnaireQuestionRequest) (*domains.QuestionnaireQuestion, error)
+	DeleteQuestion(ctx context.Context, id int) error
+	GetQuestionnaire(ctx context.Context) ([]*domains.QuestionnaireQuestion, error)
+	BuildProfile(ctx context.Context, answers *domains.QuestionnaireAnswers) (*domains.SkinProfile, error)
+	GetRecommendations(ctx context.Context, profile *domains.SkinProfile, limit int) ([]*domains.Recommendation, error)
+}
+
+type recommendationService struct {
+	repo         RecommendationRepository
+	currency     string
+	matchWeight  float64
+	ratingWeight float64
+	brandPenalty float64
+	ratingPrior  float64
+	limit        int
+	maxLimit     int
+}
+
+func NewRecommendationService(repo RecommendationRepository, cfg *config.RecommendationConfig, currency string) RecommendationService {
+	s := &recommendationService{
+		repo:         repo,
+		currency:     currency,
+		matchWeight:  cfg.MatchWeight,
+		ratingWeight: cfg.RatingWeight,
+		brandPenalty: cfg.BrandPenalty,
+		ratingPrior:  float64(cfg.RatingPrior),
+		limit:        cfg.Limit,
+		maxLimit:     cfg.MaxLimit,
+	}
+	if s.matchWeight < 0 || s.ratingWeight < 0 || s.matchWeight+s.ratingWeight <= 0 {
+		s.matchWeight, s.ratingWeight = defaultMatchWeight, defaultRatingWeight
+	}
+	total := s.matchWeight + s.ratingWeight
+	s.matchWeight, s.ratingWeight = s.matchWeight/total, s.ratingWeight/total
+	if s.brandPenalty <= 0 || s.brandPenalty >= 1 {
+		s.brandPenalty = defaultBrandPenalty
+	}
+	if s.ratingPrior <= 0 {
+		s.ratingPrior = defaultRatingPrior
+	}
+	if s.maxLimit <= 0 {
+		s.maxLimit = defaultMaxLimit
+	}
+	if s.limit <= 0 || s.limit > s.maxLimit {
+		s.limit = min(defaultLimit, s.maxLimit)
+	}
+	return s
+}
+
+func (s *recommendationService) CreateQuestion(ctx context.Context, req *domains.QuestionnaireQuestionRequest) (*domains.QuestionnaireQuestion, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateQuestion(ctx, req)
+}
+
+func (s *recommendationService) GetQuestionByID(ctx context.Context, id int) (*domains.QuestionnaireQuestion, error) {
+	return s.repo.GetQuestionByID(ctx, id)
+}
+
+func (s *recommendationService) UpdateQuestion(ctx context.Context, id int, req *domains.QuestionnaireQuestionRequest) (*domains.QuestionnaireQuestion, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateQuestion(ctx, id, req)
+}
+
+func (s *recommendationService) DeleteQuestion(ctx context.Context, id int) error {
+	return s.repo.DeleteQuestion(ctx, id)
+}
+
+func (s *recommendationService) GetQuestionnaire(ctx context.Context) ([]*domains.QuestionnaireQuestion, error) {
+	return s.repo.GetQuestions(ctx)
+}
+
+// BuildProfile adds up the weights of the chosen options into a normalized
+// skin profile.
+func (s *recommendationService) BuildProfile(ctx context.Context, answers *domains.QuestionnaireAnswers) (*domains.SkinProfile, error) {
+	if err := answers.Validate(); err != nil {
+		return nil, err
+	}
+
+	var optionIDs []int
+	for _, answer := range answers.Answers {
+		optionIDs = append(optionIDs, answer.OptionIDs...)
+	}
+	questions, err := s.repo.GetAnsweredQuestions(ctx, optionIDs)
+	if err != nil {
+		return nil, err
+	}
+	options := make(map[int]*domains.QuestionnaireOption, len(optionIDs))
+	optionQuestions := make(map[int]*domains.QuestionnaireQuestion, len(optionIDs))
+	for _, question := range questions {
+		for i := range question.Options {
+			options[question.Options[i].ID] = &question.Options[i]
+			optionQuestions[question.Options[i].ID] = question
+		}
+	}
+
+	profile := &domains.SkinProfile{}
+	for _, answer := range answers.Answers {
+		for _, id := range answer.OptionIDs {
+			question := optionQuestions[id]
+			if question == nil || question.ID != answer.QuestionID {
+				return nil, fmt.Errorf("%w: option %d is not an option of question %d", domains.ErrInvalidQuestionnaire, id, answer.QuestionID)
+			}
+			if !question.MultipleChoice && len(answer.OptionIDs) > 1 {
+				return nil, fmt.Errorf("%w: question %d takes a single option", domains.ErrInvalidQuestionnaire, answer.QuestionID)
+			}
+			profile.SkinTypes = append(profile.SkinTypes, options[id].SkinTypes...)
+			profile.Concerns = append(profile.Concerns, options[id].Concerns...)
+		}
+	}
+
+	if err := profile.Normalize(); err != nil {
+		return nil, fmt.Errorf("%w: the chosen options do not point to any skin type or concern", domains.ErrInvalidQuestionnaire)
+	}
+	return profile, nil
+}
+
+// GetRecommendations ranks the products matching the profile. Rankings are
+// cached per profile hash and limit, so catalog changes show up once the
+// cache entry expires.
+func (s *recommendationService) GetRecommendations(ctx context.Context, profile *domains.SkinProfile, limit int) ([]*domains.Recommendation, error) {
+	if limit == 0 {
+		limit = s.limit
+	}
+	if limit < 0 || limit > s.maxLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domains.ErrInvalidRecommendation, s.maxLimit)
+	}
+	if err := profile.Normalize(); err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("%s:%d", profile.Hash, limit)
+	if recommendations, err := s.repo.GetCached(ctx, key); err == nil {
+		return s.present(recommendations), nil
+	}
+
+	skinTypeIDs := make([]int, 0, len(profile.SkinTypes))
+	for _, w := range profile.SkinTypes {
+		skinTypeIDs = append(skinTypeIDs, w.SkinTypeID)
+	}
+	concerns := make([]domains.Concern, 0, len(profile.Concerns))
+	for _, w := range profile.Concerns {
+		concerns = append(concerns, w.Concern)
+	}
+	candidates, err := s.repo.GetCandidates(ctx, skinTypeIDs, concerns)
+	if err != nil {
+		return nil, err
+	}
+
+	recommendations := s.rank(profile, candidates, limit)
+	go func() {
+		if err := s.repo.SetCached(context.Background(), key, recommendations); err != nil {
+			logrus.Warnf("Failed to cache recommendations asynchronously (key: %s): %v", key, err)
+		}
+	}()
+
+	return s.present(recommendations), nil
+}
+
+// rank scores each candidate by how much of the profile's weight it covers
+// and by its rating, then picks the best one at a time. Every product
+// already picked from a brand scales the scores of that brand's remaining
+// products by 1 - brandPenalty, so a single brand does not fill the list.
+func (s *recommendationService) rank(profile *domains.SkinProfile, candidates []*domains.RecommendationCandidate, limit int) []*domains.Recommendation {
+	skinTypeWeights := make(map[int]float64, len(profile.SkinTypes))
+	for _, w := range profile.SkinTypes {
+		skinTypeWeights[w.SkinTypeID] = w.Weight
+	}
+	concernWeights := make(map[domains.Concern]float64, len(profile.Concerns))
+	for _, w := range profile.Concerns {
+		concernWeights[w.Concern] = w.Weight
+	}
+	// Skin types and concerns each make up half of the match when the
+	// profile has both.
+	skinTypeShare := 0.5
+	if len(profile.Concerns) == 0 {
+		skinTypeShare = 1
+	} else if len(profile.SkinTypes) == 0 {
+		skinTypeShare = 0
+	}
+
+	remaining := make([]*domains.Recommendation, 0, len(candidates))
+	base := make(map[*domains.Recommendation]float64, len(candidates))
+	for _, candidate := range candidates {
+		var skinTypeMatch, concernMatch float64
+		for _, id := range candidate.SkinTypeIDs {
+			skinTypeMatch += skinTypeWeights[id]
+		}
+		for _, concern := range candidate.Concerns {
+			concernMatch += concernWeights[concern]
+		}
+		recommendation := &domains.Recommendation{
+			Product:          candidate.Product,
+			MatchScore:       skinTypeShare*skinTypeMatch + (1-skinTypeShare)*concernMatch,
+			RatingScore:      s.ratingScore(candidate.Product.RatingAverage, candidate.Product.RatingCount),
+			MatchedSkinTypes: candidate.SkinTypeIDs,
+			MatchedConcerns:  candidate.Concerns,
+		}
+		base[recommendation] = s.matchWeight*recommendation.MatchScore + s.ratingWeight*recommendation.RatingScore
+		remaining = append(remaining, recommendation)
+	}
+
+	picked := make(map[int]int)
+	recommendations := make([]*domains.Recommendation, 0, min(limit, len(remaining)))
+	for len(recommendations) < limit && len(remaining) > 0 {
+		best, bestScore := -1, 0.0
+		for i, recommendation := range remaining {
+			score := base[recommendation]
+			if brand := recommendation.Product.Brand; brand != nil {
+				score *= math.Pow(1-s.brandPenalty, float64(picked[brand.ID]))
+			}
+			if best < 0 || score > bestScore ||
+				score == bestScore && recommendation.Product.ID < remaining[best].Product.ID {
+				best, bestScore = i, score
+			}
+		}
+
+		recommendation := remaining[best]
+		recommendation.Score = round(bestScore)
+		recommendation.MatchScore = round(recommendation.MatchScore)
+		recommendation.RatingScore = round(recommendation.RatingScore)
+		if brand := recommendation.Product.Brand; brand != nil {
+			picked[brand.ID]++
+		}
+		recommendations = append(recommendations, recommendation)
+		remaining[best] = remaining[len(remaining)-1]
+		remaining = remaining[:len(remaining)-1]
+	}
+	return recommendations
+}
+
+// ratingScore maps a product's average rating onto 0..1 after blending it
+// with ratingPrior ratings of priorRating, so a single five-star review does
+// not outrank hundreds of good ones.
+func (s *recommendationService) ratingScore(average float64, count int) float64 {
+	blended := (average*float64(count) + priorRating*s.ratingPrior) / (float64(count) + s.ratingPrior)
+	return (blended - domains.MinRating) / (domains.MaxRating - domains.MinRating)
+}
+
+// present returns copies of the recommendations with the current sale
+// applied, since cached entries may predate a sale starting or ending.
+func (s *recommendationService) present(recommendations []*domains.Recommendation) []*domains.Recommendation {
+	now := time.Now()
+	presented := make([]*domains.Recommendation, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		r := *recommendation
+		r.Product.ApplySale(now)
+		r.Product.Currency = s.currency
+		presented = append(presented, &r)
+	}
+	return presented
+}
+
+func round(score float64) float64 {
+	return math.Round(score*1e4) / 1e4
+}
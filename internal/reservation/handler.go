@@ -0,0 +1,161 @@
+package reservation
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type ReservationHandler struct {
+	service ReservationService
+}
+
+func NewReservationHandler(service ReservationService) *ReservationHandler {
+	return &ReservationHandler{service: service}
+}
+
+func (h *ReservationHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/reservations", h.CreateReservation)
+	router.GET("/reservations/:id", h.GetReservationByID)
+	router.DELETE("/reservations/:id", h.ReleaseReservation)
+	router.GET("/products/:id/availability", h.GetAvailability)
+}
+
+// @Summary Reserve stock
+// @Description Hold quantity of a product or variant until the reservation expires or is released
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param reservation body domains.ReservationRequest true "Reservation"
+// @Success 201 {object} domains.Reservation
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /reservations [post]
+func (h *ReservationHandler) CreateReservation(c *gin.Context) {
+	var req domains.ReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reservation, err := h.service.CreateReservation(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(reservationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, reservation)
+}
+
+// @Summary Get reservation by ID
+// @Description Get a stock reservation by its ID
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path int true "Reservation ID"
+// @Success 200 {object} domains.Reservation
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /reservations/{id} [get]
+func (h *ReservationHandler) GetReservationByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reservation id"})
+		return
+	}
+
+	reservation, err := h.service.GetReservationByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(reservationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+// @Summary Release reservation
+// @Description Release an active reservation and return its quantity to available stock
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path int true "Reservation ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Router /reservations/{id} [delete]
+func (h *ReservationHandler) ReleaseReservation(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reservation id"})
+		return
+	}
+
+	if _, err := h.service.ReleaseReservation(c.Request.Context(), id); err != nil {
+		c.JSON(reservationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get available-to-sell quantity
+// @Description Get stock on hand in active warehouses, active reservations and the resulting available quantity
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param variant_id query int false "Variant ID"
+// @Success 200 {object} domains.Availability
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /products/{id}/availability [get]
+func (h *ReservationHandler) GetAvailability(c *gin.Context) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	var variantID *int
+	if raw := c.Query("variant_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid variant id"})
+			return
+		}
+		variantID = &id
+	}
+
+	availability, err := h.service.GetAvailability(c.Request.Context(), productID, variantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
+func reservationErrorStatus(err error) int {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, domains.ErrInvalidReservation):
+		return http.StatusBadRequest
+	case errors.Is(err, domains.ErrInsufficientStock), errors.Is(err, domains.ErrReservationInactive):
+		return http.StatusConflict
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23503":
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,238 @@
+package reservation
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/domains"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type ReservationRepository interface {
+	Create(ctx context.Context, req *domains.ReservationRequest, ttl time.Duration) (*domains.Reservation, error)
+	GetByID(ctx context.Context, id int) (*domains.Reservation, error)
+	Release(ctx context.Context, id int) (*domains.Reservation, error)
+	ConsumeTx(ctx context.Context, tx pgx.Tx, ids []int) ([]*domains.Reservation, error)
+	AvailableTx(ctx context.Context, tx pgx.Tx, productID int, variantID *int) (int, error)
+	ExpireOverdue(ctx context.Context) (int64, error)
+	GetAvailability(ctx context.Context, productID int, variantID *int) (*domains.Availability, error)
+}
+
+type reservationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReservationRepository(db *pgxpool.Pool) ReservationRepository {
+	return &reservationRepository{db: db}
+}
+
+const reservationColumns = `id, product_id, variant_id, quantity, status, expires_at, created_at, released_at`
+
+// availabilityQuery sums stock in active warehouses and holds that have not
+// expired yet, so the result is correct even before the sweeper has run.
+const availabilityQuery = `
+    SELECT
+        COALESCE((SELECT SUM(sl.quantity) FROM stock_levels sl JOIN warehouses w ON w.id = sl.warehouse_id
+                  WHERE sl.product_id = $1 AND sl.variant_id IS NOT DISTINCT FROM $2 AND w.is_active), 0),
+        COALESCE((SELECT SUM(sr.quantity) FROM stock_reservations sr
+                  WHERE sr.product_id = $1 AND sr.variant_id IS NOT DISTINCT FROM $2
+                    AND sr.status = 'active' AND sr.expires_at > CURRENT_TIMESTAMP), 0)`
+
+func scanReservation(row pgx.Row) (*domains.Reservation, error) {
+	reservation := &domains.Reservation{}
+	err := row.Scan(
+		&reservation.ID,
+		&reservation.ProductID,
+		&reservation.VariantID,
+		&reservation.Quantity,
+		&reservation.Status,
+		&reservation.ExpiresAt,
+		&reservation.CreatedAt,
+		&reservation.ReleasedAt,
+	)
+	return reservation, err
+}
+
+// Create holds stock for ttl. Holds on the same product are serialized with
+// an advisory lock so two concurrent requests cannot both take the last units.
+func (r *reservationRepository) Create(ctx context.Context, req *domains.ReservationRequest, ttl time.Duration) (*domains.Reservation, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	if req.VariantID != nil {
+		const variantQuery = `SELECT id FROM product_variants WHERE id = $1 AND product_id = $2`
+		var variantID int
+		if err = tx.QueryRow(ctx, variantQuery, *req.VariantID, req.ProductID).Scan(&variantID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				logrus.Infof("Variant not found for reservation (ID: %d, product_id: %d)", *req.VariantID, req.ProductID)
+				err = sql.ErrNoRows
+				return nil, err
+			}
+			logrus.Errorf("Failed to look up variant for reservation (ID: %d): %v", *req.VariantID, err)
+			return nil, err
+		}
+	}
+
+	var available int
+	if available, err = r.AvailableTx(ctx, tx, req.ProductID, req.VariantID); err != nil {
+		return nil, err
+	}
+	if available < req.Quantity {
+		logrus.Infof("Insufficient stock for reservation (product_id: %d, available: %d, requested: %d)", req.ProductID, available, req.Quantity)
+		err = domains.ErrInsufficientStock
+		return nil, err
+	}
+
+	insertQuery := `
+        INSERT INTO stock_reservations (product_id, variant_id, quantity, expires_at)
+        VALUES ($1, $2, $3, CURRENT_TIMESTAMP + make_interval(secs => $4::int))
+        RETURNING ` + reservationColumns
+	reservation, err := scanReservation(tx.QueryRow(ctx, insertQuery, req.ProductID, req.VariantID, req.Quantity, int(ttl.Seconds())))
+	if err != nil {
+		logrus.WithError(err).WithField("reservation", req).Error("Failed to insert reservation")
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+
+	logrus.Debugf("Reservation created successfully (ID: %d, product_id: %d, quantity: %d)", reservation.ID, reservation.ProductID, reservation.Quantity)
+	return reservation, nil
+}
+
+func (r *reservationRepository) GetByID(ctx context.Context, id int) (*domains.Reservation, error) {
+	getQuery := `SELECT ` + reservationColumns + ` FROM stock_reservations WHERE id = $1`
+
+	reservation, err := scanReservation(r.db.QueryRow(ctx, getQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Reservation not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get reservation (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+func (r *reservationRepository) Release(ctx context.Context, id int) (*domains.Reservation, error) {
+	releaseQuery := `
+        UPDATE stock_reservations
+        SET status = 'released', released_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status = 'active' AND expires_at > CURRENT_TIMESTAMP
+        RETURNING ` + reservationColumns
+
+	reservation, err := scanReservation(r.db.QueryRow(ctx, releaseQuery, id))
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			logrus.Errorf("Failed to release reservation (ID: %d): %v", id, err)
+			return nil, err
+		}
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+		logrus.Infof("Attempted to release inactive reservation (ID: %d)", id)
+		return nil, domains.ErrReservationInactive
+	}
+
+	logrus.Debugf("Reservation released successfully (ID: %d)", reservation.ID)
+	return reservation, nil
+}
+
+// ConsumeTx releases the given active reservations inside the caller's
+// transaction once the stock they held has been sold. It fails with
+// ErrReservationInactive unless every reservation was still active.
+func (r *reservationRepository) ConsumeTx(ctx context.Context, tx pgx.Tx, ids []int) ([]*domains.Reservation, error) {
+	consumeQuery := `
+        UPDATE stock_reservations
+        SET status = 'released', released_at = CURRENT_TIMESTAMP
+        WHERE id = ANY($1) AND status = 'active' AND expires_at > CURRENT_TIMESTAMP
+        RETURNING ` + reservationColumns
+
+	rows, err := tx.Query(ctx, consumeQuery, ids)
+	if err != nil {
+		logrus.Errorf("Failed to consume reservations (IDs: %v): %v", ids, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reservations []*domains.Reservation
+	for rows.Next() {
+		reservation, err := scanReservation(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan reservation row: %v", err)
+			return nil, err
+		}
+		reservations = append(reservations, reservation)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating reservation rows: %v", err)
+		return nil, err
+	}
+
+	if len(reservations) != len(ids) {
+		logrus.Infof("Attempted to consume inactive reservations (IDs: %v)", ids)
+		return nil, domains.ErrReservationInactive
+	}
+	return reservations, nil
+}
+
+// AvailableTx locks the product for the rest of the caller's transaction and
+// returns its on-hand stock minus unexpired holds. Every change to holds or
+// sales of the product takes the same lock, so the result stays valid until
+// the transaction ends.
+func (r *reservationRepository) AvailableTx(ctx context.Context, tx pgx.Tx, productID int, variantID *int) (int, error) {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, productID); err != nil {
+		logrus.Errorf("Failed to lock product for availability (ID: %d): %v", productID, err)
+		return 0, err
+	}
+
+	var onHand, reserved int
+	if err := tx.QueryRow(ctx, availabilityQuery, productID, variantID).Scan(&onHand, &reserved); err != nil {
+		logrus.Errorf("Failed to compute availability (product_id: %d): %v", productID, err)
+		return 0, err
+	}
+	return onHand - reserved, nil
+}
+
+// ExpireOverdue marks active reservations past their expiry as expired and
+// returns how many were released.
+func (r *reservationRepository) ExpireOverdue(ctx context.Context) (int64, error) {
+	const expireQuery = `
+        UPDATE stock_reservations
+        SET status = 'expired', released_at = expires_at
+        WHERE status = 'active' AND expires_at <= CURRENT_TIMESTAMP`
+
+	tag, err := r.db.Exec(ctx, expireQuery)
+	if err != nil {
+		logrus.Errorf("Failed to expire reservations: %v", err)
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *reservationRepository) GetAvailability(ctx context.Context, productID int, variantID *int) (*domains.Availability, error) {
+	availability := &domains.Availability{ProductID: productID, VariantID: variantID}
+	if err := r.db.QueryRow(ctx, availabilityQuery, productID, variantID).Scan(&availability.OnHand, &availability.Reserved); err != nil {
+		logrus.Errorf("Failed to compute availability (product_id: %d): %v", productID, err)
+		return nil, err
+	}
+
+	availability.Available = max(availability.OnHand-availability.Reserved, 0)
+	return availability, nil
+}
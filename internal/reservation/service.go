@@ -0,0 +1,70 @@
+package reservation
+
+import (
+	"context"
+	"e-commerce/internal/config"
+	"e-commerce/internal/domains"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultReservationTTL    = 15 * time.Minute
+	defaultReservationMaxTTL = time.Hour
+)
+
+type ReservationService interface {
+	CreateReservation(ctx context.Context, req *domains.ReservationRequest) (*domains.Reservation, error)
+	GetReservationByID(ctx context.Context, id int) (*domains.Reservation, error)
+	ReleaseReservation(ctx context.Context, id int) (*domains.Reservation, error)
+	GetAvailability(ctx context.Context, productID int, variantID *int) (*domains.Availability, error)
+}
+
+type reservationService struct {
+	repo   ReservationRepository
+	ttl    time.Duration
+	maxTTL time.Duration
+}
+
+func NewReservationService(repo ReservationRepository, cfg *config.ReservationConfig) ReservationService {
+	s := &reservationService{
+		repo:   repo,
+		ttl:    cfg.TTL,
+		maxTTL: cfg.MaxTTL,
+	}
+	if s.ttl <= 0 {
+		s.ttl = defaultReservationTTL
+	}
+	if s.maxTTL <= 0 {
+		s.maxTTL = defaultReservationMaxTTL
+	}
+	return s
+}
+
+func (s *reservationService) CreateReservation(ctx context.Context, req *domains.ReservationRequest) (*domains.Reservation, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	ttl := s.ttl
+	if req.TTLSeconds > 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+	if ttl > s.maxTTL {
+		return nil, fmt.Errorf("%w: ttl_seconds must not exceed %d", domains.ErrInvalidReservation, int(s.maxTTL.Seconds()))
+	}
+
+	return s.repo.Create(ctx, req, ttl)
+}
+
+func (s *reservationService) GetReservationByID(ctx context.Context, id int) (*domains.Reservation, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *reservationService) ReleaseReservation(ctx context.Context, id int) (*domains.Reservation, error) {
+	return s.repo.Release(ctx, id)
+}
+
+func (s *reservationService) GetAvailability(ctx context.Context, productID int, variantID *int) (*domains.Availability, error) {
+	return s.repo.GetAvailability(ctx, productID, variantID)
+}
@@ -0,0 +1,72 @@
+package reservation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const defaultSweepInterval = time.Minute
+
+// Sweeper periodically marks expired reservations so they stop counting
+// against available stock and show up as expired when fetched.
+type Sweeper struct {
+	repo     ReservationRepository
+	interval time.Duration
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewSweeper(repo ReservationRepository, interval time.Duration) *Sweeper {
+	if interval <= 0 {
+		interval = defaultSweepInterval
+	}
+	return &Sweeper{
+		repo:     repo,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+func (s *Sweeper) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.sweep()
+
+			select {
+			case <-ticker.C:
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the sweep loop and waits for a running sweep to finish.
+func (s *Sweeper) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+func (s *Sweeper) sweep() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+	defer cancel()
+
+	expired, err := s.repo.ExpireOverdue(ctx)
+	if err != nil {
+		logrus.Warnf("Reservation sweep failed: %v", err)
+		return
+	}
+	if expired > 0 {
+		logrus.Infof("Released %d expired reservations", expired)
+	}
+}
@@ -0,0 +1,240 @@
+package returns
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type ReturnHandler struct {
+	service ReturnService
+}
+
+func NewReturnHandler(service ReturnService) *ReturnHandler {
+	return &ReturnHandler{service: service}
+}
+
+func (h *ReturnHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/orders/:id/returns", h.CreateReturn)
+	router.GET("/orders/:id/returns", h.GetOrderReturns)
+	router.GET("/returns/:id", h.GetReturnByID)
+	router.POST("/returns/:id/approve", h.ApproveReturn)
+	router.POST("/returns/:id/reject", h.RejectReturn)
+	router.POST("/returns/:id/refund", h.RefundReturn)
+}
+
+// @Summary Request a return
+// @Description Open a return for a line of a delivered order
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param return body domains.CreateReturnRequest true "Return request"
+// @Success 201 {object} domains.Return
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /orders/{id}/returns [post]
+func (h *ReturnHandler) CreateReturn(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	var req domains.CreateReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ret, err := h.service.CreateReturn(c.Request.Context(), orderID, &req)
+	if err != nil {
+		c.JSON(returnErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, ret)
+}
+
+// @Summary Get order returns
+// @Description Get all return requests of an order
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {array} domains.Return
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /orders/{id}/returns [get]
+func (h *ReturnHandler) GetOrderReturns(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	returns, err := h.service.GetOrderReturns(c.Request.Context(), orderID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, returns)
+}
+
+// @Summary Get return by ID
+// @Description Get a return request with its status history
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path int true "Return ID"
+// @Success 200 {object} domains.Return
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /returns/{id} [get]
+func (h *ReturnHandler) GetReturnByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid return id"})
+		return
+	}
+
+	ret, err := h.service.GetReturnByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(returnErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ret)
+}
+
+// @Summary Approve return
+// @Description Approve a requested return and optionally restock the goods
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path int true "Return ID"
+// @Param approval body domains.ReturnApprovalRequest true "Approval"
+// @Success 200 {object} domains.Return
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /returns/{id}/approve [post]
+func (h *ReturnHandler) ApproveReturn(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid return id"})
+		return
+	}
+
+	var req domains.ReturnApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ret, err := h.service.ApproveReturn(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(returnErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ret)
+}
+
+// @Summary Reject return
+// @Description Reject a requested return
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path int true "Return ID"
+// @Param rejection body domains.ReturnRejectionRequest true "Rejection"
+// @Success 200 {object} domains.Return
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /returns/{id}/reject [post]
+func (h *ReturnHandler) RejectReturn(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid return id"})
+		return
+	}
+
+	var req domains.ReturnRejectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ret, err := h.service.RejectReturn(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(returnErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ret)
+}
+
+// @Summary Refund return
+// @Description Refund an approved return, fully or partially, through the order payment. Retrying a return left refunding completes it once the payment shows the refund
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path int true "Return ID"
+// @Param refund body domains.ReturnRefundRequest false "Refund amount"
+// @Success 200 {object} domains.Return
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /returns/{id}/refund [post]
+func (h *ReturnHandler) RefundReturn(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid return id"})
+		return
+	}
+
+	var req domains.ReturnRefundRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	ret, err := h.service.RefundReturn(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(returnErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ret)
+}
+
+func returnErrorStatus(err error) int {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, domains.ErrInvalidReturn), errors.Is(err, domains.ErrInvalidPayment), errors.Is(err, domains.ErrInvalidAdjustment):
+		return http.StatusBadRequest
+	case errors.Is(err, domains.ErrReturnState), errors.Is(err, domains.ErrPaymentState), errors.Is(err, domains.ErrInvalidTransition):
+		return http.StatusConflict
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23503":
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,423 @@
+package returns
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/domains"
+	"e-commerce/internal/inventory"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type ReturnRepository interface {
+	Create(ctx context.Context, orderID int, req *domains.CreateReturnRequest) (*domains.Return, error)
+	GetByID(ctx context.Context, id int) (*domains.Return, error)
+	GetByOrderID(ctx context.Context, orderID int) ([]*domains.Return, error)
+	Transition(ctx context.Context, id int, from, to domains.ReturnStatus, note string) (*domains.Return, error)
+	Approve(ctx context.Context, id int, note string, restock *domains.StockAdjustmentRequest) (*domains.Return, error)
+	ClaimRefund(ctx context.Context, id int, amount domains.Money, paymentID int, linePaid domains.Money) (*domains.Return, error)
+	ReleaseRefund(ctx context.Context, id int, note string) (*domains.Return, error)
+	MarkRefunded(ctx context.Context, id int, amount domains.Money, paymentID int) (*domains.Return, error)
+	RefundedForItem(ctx context.Context, orderItemID int) (domains.Money, error)
+	RefundedByPayment(ctx context.Context, paymentID int) (domains.Money, error)
+}
+
+type returnRepository struct {
+	db        *pgxpool.Pool
+	inventory inventory.InventoryRepository
+}
+
+func NewReturnRepository(db *pgxpool.Pool, inventoryRepo inventory.InventoryRepository) ReturnRepository {
+	return &returnRepository{db: db, inventory: inventoryRepo}
+}
+
+const returnColumns = `
+        id, order_id, order_item_id, quantity, reason, COALESCE(comment, ''), status,
+        restocked, refund_amount, payment_id, created_at, updated_at`
+
+func scanReturn(row pgx.Row) (*domains.Return, error) {
+	ret := &domains.Return{}
+	err := row.Scan(
+		&ret.ID,
+		&ret.OrderID,
+		&ret.OrderItemID,
+		&ret.Quantity,
+		&ret.Reason,
+		&ret.Comment,
+		&ret.Status,
+		&ret.Restocked,
+		&ret.RefundAmount,
+		&ret.PaymentID,
+		&ret.CreatedAt,
+		&ret.UpdatedAt,
+	)
+	return ret, err
+}
+
+// Create opens a return for an order line. The line is locked while the
+// quantity already under return is checked, so concurrent requests cannot
+// return more than was bought.
+func (r *returnRepository) Create(ctx context.Context, orderID int, req *domains.CreateReturnRequest) (*domains.Return, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	const lineQuery = `
+        SELECT oi.quantity, o.status
+        FROM order_items oi
+        JOIN orders o ON o.id = oi.order_id
+        WHERE oi.id = $1 AND oi.order_id = $2
+        FOR UPDATE OF oi`
+	var purchased int
+	var orderStatus domains.OrderStatus
+	if err = tx.QueryRow(ctx, lineQuery, req.OrderItemID, orderID).Scan(&purchased, &orderStatus); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Order item not found for return (ID: %d, order_id: %d)", req.OrderItemID, orderID)
+			err = sql.ErrNoRows
+			return nil, err
+		}
+		logrus.Errorf("Failed to look up order item for return (ID: %d): %v", req.OrderItemID, err)
+		return nil, err
+	}
+	if orderStatus != domains.OrderDelivered {
+		err = fmt.Errorf("%w: only delivered orders can be returned, order is %s", domains.ErrReturnState, orderStatus)
+		return nil, err
+	}
+
+	const returnedQuery = `
+        SELECT COALESCE(SUM(quantity), 0)
+        FROM return_requests
+        WHERE order_item_id = $1 AND status <> 'rejected'`
+	var returned int
+	if err = tx.QueryRow(ctx, returnedQuery, req.OrderItemID).Scan(&returned); err != nil {
+		logrus.Errorf("Failed to sum returned quantity (order_item_id: %d): %v", req.OrderItemID, err)
+		return nil, err
+	}
+	if returned+req.Quantity > purchased {
+		err = fmt.Errorf("%w: only %d of %d units can still be returned", domains.ErrInvalidReturn, purchased-returned, purchased)
+		return nil, err
+	}
+
+	insertQuery := `
+        INSERT INTO return_requests (order_id, order_item_id, quantity, reason, comment)
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+        RETURNING ` + returnColumns
+	created, err := scanReturn(tx.QueryRow(ctx, insertQuery, orderID, req.OrderItemID, req.Quantity, req.Reason, req.Comment))
+	if err != nil {
+		logrus.WithError(err).WithField("return", req).Error("Failed to insert return request")
+		return nil, err
+	}
+
+	if err = insertHistory(ctx, tx, created.ID, "", domains.ReturnRequested, ""); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+
+	logrus.Debugf("Return request created successfully (ID: %d, order_id: %d)", created.ID, orderID)
+	return r.GetByID(ctx, created.ID)
+}
+
+func (r *returnRepository) GetByID(ctx context.Context, id int) (*domains.Return, error) {
+	getQuery := `SELECT ` + returnColumns + ` FROM return_requests WHERE id = $1`
+
+	ret, err := scanReturn(r.db.QueryRow(ctx, getQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Return request not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get return request (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	if ret.History, err = r.getHistory(ctx, id); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (r *returnRepository) GetByOrderID(ctx context.Context, orderID int) ([]*domains.Return, error) {
+	listQuery := `SELECT ` + returnColumns + ` FROM return_requests WHERE order_id = $1 ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, listQuery, orderID)
+	if err != nil {
+		logrus.Errorf("Failed to query return requests (order_id: %d): %v", orderID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var returns []*domains.Return
+	for rows.Next() {
+		ret, err := scanReturn(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan return request row: %v", err)
+			return nil, err
+		}
+		returns = append(returns, ret)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating return request rows: %v", err)
+		return nil, err
+	}
+
+	return returns, nil
+}
+
+// Transition moves a return from one status to another and records the
+// change. It fails with ErrReturnState if the return is no longer in from.
+func (r *returnRepository) Transition(ctx context.Context, id int, from, to domains.ReturnStatus, note string) (*domains.Return, error) {
+	return r.update(ctx, id, from, to, note, `status = $1`)
+}
+
+// Approve moves a requested return to approved. With a restock request the
+// goods are put back into stock in the same transaction, so a return is
+// either approved with its stock movement or not approved at all.
+func (r *returnRepository) Approve(ctx context.Context, id int, note string, restock *domains.StockAdjustmentRequest) (*domains.Return, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	if err = r.updateTx(ctx, tx, id, domains.ReturnRequested, domains.ReturnApproved, note,
+		`status = $1, restocked = $4`, restock != nil); err != nil {
+		return nil, err
+	}
+
+	var inStockChanged bool
+	if restock != nil {
+		if _, inStockChanged, err = r.inventory.AdjustTx(ctx, tx, restock, restock.Quantity); err != nil {
+			logrus.Errorf("Failed to restock approved return (ID: %d): %v", id, err)
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+	if restock != nil {
+		r.inventory.Invalidate(ctx, restock.ProductID, inStockChanged)
+	}
+
+	logrus.Debugf("Return request approved successfully (ID: %d, restocked: %t)", id, restock != nil)
+	return r.GetByID(ctx, id)
+}
+
+// ClaimRefund moves an approved return to refunding and records the amount
+// and the payment it is refunded through. The order line is locked while the
+// amounts of its other refunds are summed, so the returns of a line can never
+// refund more than linePaid between them.
+func (r *returnRepository) ClaimRefund(ctx context.Context, id int, amount domains.Money, paymentID int, linePaid domains.Money) (*domains.Return, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	const lockQuery = `
+        SELECT oi.id
+        FROM return_requests rr
+        JOIN order_items oi ON oi.id = rr.order_item_id
+        WHERE rr.id = $1
+        FOR UPDATE OF oi`
+	var orderItemID int
+	if err = tx.QueryRow(ctx, lockQuery, id).Scan(&orderItemID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Return request not found (ID: %d)", id)
+			err = sql.ErrNoRows
+			return nil, err
+		}
+		logrus.Errorf("Failed to lock order item for refund (return ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	var refunded domains.Money
+	if err = tx.QueryRow(ctx, refundedForItemQuery, orderItemID).Scan(&refunded); err != nil {
+		logrus.Errorf("Failed to sum refunded amount (order_item_id: %d): %v", orderItemID, err)
+		return nil, err
+	}
+	if amount > linePaid-refunded {
+		err = fmt.Errorf("%w: only %s of the line's %s can still be refunded", domains.ErrInvalidReturn, linePaid-refunded, linePaid)
+		return nil, err
+	}
+
+	note := fmt.Sprintf("refunding %s via payment %d", amount, paymentID)
+	if err = r.updateTx(ctx, tx, id, domains.ReturnApproved, domains.ReturnRefunding, note,
+		`status = $1, refund_amount = $4, payment_id = $5`, amount, paymentID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+
+	logrus.Debugf("Return refund claimed successfully (ID: %d, amount: %s)", id, amount)
+	return r.GetByID(ctx, id)
+}
+
+// ReleaseRefund hands a refund claim back, e.g. after the provider refused
+// it, so the return can be refunded again.
+func (r *returnRepository) ReleaseRefund(ctx context.Context, id int, note string) (*domains.Return, error) {
+	return r.update(ctx, id, domains.ReturnRefunding, domains.ReturnApproved, note,
+		`status = $1, refund_amount = NULL, payment_id = NULL`)
+}
+
+// MarkRefunded completes a refund claimed by moving the return to refunding.
+func (r *returnRepository) MarkRefunded(ctx context.Context, id int, amount domains.Money, paymentID int) (*domains.Return, error) {
+	note := fmt.Sprintf("refunded %s via payment %d", amount, paymentID)
+	return r.update(ctx, id, domains.ReturnRefunding, domains.ReturnRefunded, note,
+		`status = $1, refund_amount = $4, payment_id = $5`, amount, paymentID)
+}
+
+// refundedForItemQuery sums the amounts refunded, or being refunded, by the
+// returns of an order line.
+const refundedForItemQuery = `
+        SELECT COALESCE(SUM(refund_amount), 0)
+        FROM return_requests
+        WHERE order_item_id = $1 AND status IN ('refunding', 'refunded')`
+
+// RefundedForItem returns the amount refunded, or being refunded, by the
+// returns of an order line.
+func (r *returnRepository) RefundedForItem(ctx context.Context, orderItemID int) (domains.Money, error) {
+	var refunded domains.Money
+	if err := r.db.QueryRow(ctx, refundedForItemQuery, orderItemID).Scan(&refunded); err != nil {
+		logrus.Errorf("Failed to sum refunded amount (order_item_id: %d): %v", orderItemID, err)
+		return 0, err
+	}
+	return refunded, nil
+}
+
+// RefundedByPayment returns the amount refunded, or being refunded, through
+// a payment by returns.
+func (r *returnRepository) RefundedByPayment(ctx context.Context, paymentID int) (domains.Money, error) {
+	const refundedQuery = `
+        SELECT COALESCE(SUM(refund_amount), 0)
+        FROM return_requests
+        WHERE payment_id = $1 AND status IN ('refunding', 'refunded')`
+
+	var refunded domains.Money
+	if err := r.db.QueryRow(ctx, refundedQuery, paymentID).Scan(&refunded); err != nil {
+		logrus.Errorf("Failed to sum refunded amount (payment_id: %d): %v", paymentID, err)
+		return 0, err
+	}
+	return refunded, nil
+}
+
+func (r *returnRepository) update(ctx context.Context, id int, from, to domains.ReturnStatus, note, set string, args ...any) (*domains.Return, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	if err = r.updateTx(ctx, tx, id, from, to, note, set, args...); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+
+	logrus.Debugf("Return request transitioned successfully (ID: %d, from: %s, to: %s)", id, from, to)
+	return r.GetByID(ctx, id)
+}
+
+// updateTx applies set to the return if it is still in from and records the
+// change. It fails with ErrReturnState otherwise.
+func (r *returnRepository) updateTx(ctx context.Context, tx pgx.Tx, id int, from, to domains.ReturnStatus, note, set string, args ...any) error {
+	updateQuery := `
+        UPDATE return_requests
+        SET ` + set + `, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2 AND status = $3
+        RETURNING id`
+	var updatedID int
+	if err := tx.QueryRow(ctx, updateQuery, append([]any{to, id, from}, args...)...).Scan(&updatedID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if _, err := r.GetByID(ctx, id); err != nil {
+				return err
+			}
+			logrus.Infof("Rejected return transition (ID: %d, expected: %s, to: %s)", id, from, to)
+			return fmt.Errorf("%w: return is not %s", domains.ErrReturnState, from)
+		}
+		logrus.Errorf("Failed to update return request (ID: %d): %v", id, err)
+		return err
+	}
+
+	return insertHistory(ctx, tx, id, from, to, note)
+}
+
+func (r *returnRepository) getHistory(ctx context.Context, returnID int) ([]domains.ReturnStatusChange, error) {
+	const historyQuery = `
+        SELECT COALESCE(from_status, ''), to_status, COALESCE(note, ''), created_at
+        FROM return_status_history
+        WHERE return_id = $1
+        ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, historyQuery, returnID)
+	if err != nil {
+		logrus.Errorf("Failed to query return history (return_id: %d): %v", returnID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []domains.ReturnStatusChange
+	for rows.Next() {
+		var change domains.ReturnStatusChange
+		if err := rows.Scan(&change.FromStatus, &change.ToStatus, &change.Note, &change.CreatedAt); err != nil {
+			logrus.Errorf("Failed to scan return history row: %v", err)
+			return nil, err
+		}
+		history = append(history, change)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating return history rows: %v", err)
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func insertHistory(ctx context.Context, tx pgx.Tx, returnID int, from, to domains.ReturnStatus, note string) error {
+	const insertQuery = `
+        INSERT INTO return_status_history (return_id, from_status, to_status, note)
+        VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, ''))`
+
+	if _, err := tx.Exec(ctx, insertQuery, returnID, from, to, note); err != nil {
+		logrus.Errorf("Failed to insert return history (return_id: %d): %v", returnID, err)
+		return err
+	}
+	return nil
+}
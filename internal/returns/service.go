@@ -0,0 +1,205 @@
+package returns
+
+import (
+	"context"
+	"e-commerce/internal/domains"
+	"e-commerce/internal/order"
+	"e-commerce/internal/payment"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ReturnService interface {
+	CreateReturn(ctx context.Context, orderID int, req *domains.CreateReturnRequest) (*domains.Return, error)
+	GetReturnByID(ctx context.Context, id int) (*domains.Return, error)
+	GetOrderReturns(ctx context.Context, orderID int) ([]*domains.Return, error)
+	ApproveReturn(ctx context.Context, id int, req *domains.ReturnApprovalRequest) (*domains.Return, error)
+	RejectReturn(ctx context.Context, id int, req *domains.ReturnRejectionRequest) (*domains.Return, error)
+	RefundReturn(ctx context.Context, id int, req *domains.ReturnRefundRequest) (*domains.Return, error)
+}
+
+type returnService struct {
+	repo     ReturnRepository
+	orders   order.OrderService
+	payments payment.PaymentService
+}
+
+func NewReturnService(repo ReturnRepository, orders order.OrderService, payments payment.PaymentService) ReturnService {
+	return &returnService{
+		repo:     repo,
+		orders:   orders,
+		payments: payments,
+	}
+}
+
+func (s *returnService) CreateReturn(ctx context.Context, orderID int, req *domains.CreateReturnRequest) (*domains.Return, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, orderID, req)
+}
+
+func (s *returnService) GetReturnByID(ctx context.Context, id int) (*domains.Return, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *returnService) GetOrderReturns(ctx context.Context, orderID int) ([]*domains.Return, error) {
+	return s.repo.GetByOrderID(ctx, orderID)
+}
+
+// ApproveReturn accepts the return and optionally puts the goods back into
+// stock. Approval and restock commit together, so a failed restock leaves
+// the return requested and the approval can simply be retried.
+func (s *returnService) ApproveReturn(ctx context.Context, id int, req *domains.ReturnApprovalRequest) (*domains.Return, error) {
+	if !req.Restock {
+		return s.repo.Approve(ctx, id, req.Note, nil)
+	}
+
+	ret, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.orderItem(ctx, ret)
+	if err != nil {
+		return nil, err
+	}
+	if item.ProductID == nil {
+		logrus.Warnf("Skipping restock of return with deleted product (ID: %d)", ret.ID)
+		return s.repo.Approve(ctx, id, req.Note, nil)
+	}
+
+	return s.repo.Approve(ctx, id, req.Note, &domains.StockAdjustmentRequest{
+		ProductID:   *item.ProductID,
+		VariantID:   item.VariantID,
+		WarehouseID: req.WarehouseID,
+		Type:        domains.MovementReturn,
+		Quantity:    ret.Quantity,
+		Reason:      fmt.Sprintf("return #%d", ret.ID),
+	})
+}
+
+func (s *returnService) RejectReturn(ctx context.Context, id int, req *domains.ReturnRejectionRequest) (*domains.Return, error) {
+	if req.Note == "" {
+		return nil, fmt.Errorf("%w: a note explaining the rejection is required", domains.ErrInvalidReturn)
+	}
+	return s.repo.Transition(ctx, id, domains.ReturnRequested, domains.ReturnRejected, req.Note)
+}
+
+// RefundReturn refunds an approved return through the order's captured
+// payment. The amount defaults to the paid price of the returned units and
+// may not exceed it, nor what is left of the line's paid total after its
+// other refunds. The return is claimed as refunding, with the amount and
+// payment, before the provider is called, so concurrent requests cannot
+// refund it twice, and handed back to approved if the refund fails. A
+// return left refunding, e.g. because recording the completed refund
+// failed, is finished by calling RefundReturn again.
+func (s *returnService) RefundReturn(ctx context.Context, id int, req *domains.ReturnRefundRequest) (*domains.Return, error) {
+	ret, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status == domains.ReturnRefunding {
+		return s.finishRefund(ctx, ret)
+	}
+	if ret.Status != domains.ReturnApproved {
+		return nil, fmt.Errorf("%w: only approved returns can be refunded, return is %s", domains.ErrReturnState, ret.Status)
+	}
+
+	o, err := s.orders.GetOrderByID(ctx, ret.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	item, err := findOrderItem(o, ret)
+	if err != nil {
+		return nil, err
+	}
+	// Order-level discounts were spread over the lines at checkout, so the
+	// paid price of a unit is the discounted line total, plus any tax added
+	// on top of it, divided evenly and rounded half up to the minor unit.
+	linePaid := item.LineTotal - item.Discount
+	if !o.TaxIncluded {
+		linePaid += item.Tax
+	}
+	refunded, err := s.repo.RefundedForItem(ctx, ret.OrderItemID)
+	if err != nil {
+		return nil, err
+	}
+	quantity := domains.Money(item.Quantity)
+	maxAmount := min((linePaid.Mul(ret.Quantity)+quantity/2)/quantity, linePaid-refunded)
+	amount := maxAmount
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+	if amount <= 0 || amount > maxAmount {
+		return nil, fmt.Errorf("%w: refund amount must be between 0.01 and %s", domains.ErrInvalidReturn, maxAmount)
+	}
+
+	payments, err := s.payments.GetOrderPayments(ctx, ret.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	var paid *domains.Payment
+	for _, p := range payments {
+		if p.Status == domains.PaymentCaptured && p.CapturedAmount-p.RefundedAmount >= amount {
+			paid = p
+			break
+		}
+	}
+	if paid == nil {
+		return nil, fmt.Errorf("%w: the order has no captured payment covering %s", domains.ErrReturnState, amount)
+	}
+
+	if _, err := s.repo.ClaimRefund(ctx, ret.ID, amount, paid.ID, linePaid); err != nil {
+		return nil, err
+	}
+	if _, err := s.payments.Refund(ctx, paid.ID, &domains.RefundRequest{Amount: &amount}); err != nil {
+		note := fmt.Sprintf("refund failed: %v", err)
+		if _, releaseErr := s.repo.ReleaseRefund(ctx, ret.ID, note); releaseErr != nil {
+			logrus.Errorf("Failed to release refund claim on return (ID: %d): %v", ret.ID, releaseErr)
+		}
+		return nil, err
+	}
+	return s.repo.MarkRefunded(ctx, ret.ID, amount, paid.ID)
+}
+
+// finishRefund completes a return left refunding once its payment shows the
+// refunds of all returns claimed against it. Until then the refund is still
+// with the provider and the return stays as it is.
+func (s *returnService) finishRefund(ctx context.Context, ret *domains.Return) (*domains.Return, error) {
+	if ret.RefundAmount == nil || ret.PaymentID == nil {
+		return nil, fmt.Errorf("%w: return is refunding without a recorded refund", domains.ErrReturnState)
+	}
+
+	paid, err := s.payments.GetPaymentByID(ctx, *ret.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	claimed, err := s.repo.RefundedByPayment(ctx, paid.ID)
+	if err != nil {
+		return nil, err
+	}
+	if paid.RefundedAmount < claimed {
+		return nil, fmt.Errorf("%w: the refund of %s is still in progress", domains.ErrReturnState, *ret.RefundAmount)
+	}
+
+	logrus.Infof("Completing refund left in progress (ID: %d, payment ID: %d)", ret.ID, paid.ID)
+	return s.repo.MarkRefunded(ctx, ret.ID, *ret.RefundAmount, paid.ID)
+}
+
+func (s *returnService) orderItem(ctx context.Context, ret *domains.Return) (*domains.OrderItem, error) {
+	o, err := s.orders.GetOrderByID(ctx, ret.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	return findOrderItem(o, ret)
+}
+
+func findOrderItem(o *domains.Order, ret *domains.Return) (*domains.OrderItem, error) {
+	for i := range o.Items {
+		if o.Items[i].ID == ret.OrderItemID {
+			return &o.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: order item %d not found", domains.ErrInvalidReturn, ret.OrderItemID)
+}
@@ -0,0 +1,236 @@
+package review
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type ReviewHandler struct {
+	service ReviewService
+}
+
+func NewReviewHandler(service ReviewService) *ReviewHandler {
+	return &ReviewHandler{service: service}
+}
+
+func (h *ReviewHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/products/:id/reviews", h.CreateReview)
+	router.GET("/products/:id/reviews", h.GetProductReviews)
+
+	router.GET("/reviews", h.GetReviews)
+	router.GET("/reviews/:id", h.GetReviewByID)
+	router.PUT("/reviews/:id/moderation", h.ModerateReview)
+	router.DELETE("/reviews/:id", h.DeleteReview)
+}
+
+// @Summary Review a product
+// @Description Post a rating and review for a product. The review is queued for moderation and counts towards the rating once approved.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param review body domains.ReviewRequest true "Review object"
+// @Success 201 {object} domains.Review
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /products/{id}/reviews [post]
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	var req domains.ReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdReview, err := h.service.CreateReview(c.Request.Context(), productID, &req)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdReview)
+}
+
+// @Summary Get product reviews
+// @Description Get the approved reviews of a product, newest first, optionally only those by reviewers of a skin type
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param skin_type_id query int false "Skin type ID"
+// @Success 200 {array} domains.Review
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /products/{id}/reviews [get]
+func (h *ReviewHandler) GetProductReviews(c *gin.Context) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	var skinTypeID int
+	if skinType := c.Query("skin_type_id"); skinType != "" {
+		if skinTypeID, err = strconv.Atoi(skinType); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid skin type id"})
+			return
+		}
+	}
+
+	reviews, err := h.service.GetProductReviews(c.Request.Context(), productID, skinTypeID)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+// @Summary Get reviews
+// @Description Get reviews for moderation, newest first. Defaults to the pending queue.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param status query string false "Review status" Enums(pending, approved, rejected) default(pending)
+// @Param product_id query int false "Product ID"
+// @Success 200 {array} domains.Review
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /reviews [get]
+func (h *ReviewHandler) GetReviews(c *gin.Context) {
+	filter := domains.ReviewFilter{Status: domains.ModerationStatus(c.DefaultQuery("status", string(domains.ModerationPending)))}
+	if productID := c.Query("product_id"); productID != "" {
+		id, err := strconv.Atoi(productID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+			return
+		}
+		filter.ProductID = id
+	}
+
+	reviews, err := h.service.GetReviews(c.Request.Context(), &filter)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+// @Summary Get review by ID
+// @Description Get a review by its ID, whatever its status
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Success 200 {object} domains.Review
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /reviews/{id} [get]
+func (h *ReviewHandler) GetReviewByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	review, err := h.service.GetReviewByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// @Summary Moderate review
+// @Description Approve or reject a review and refresh the product's rating
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param moderation body domains.ModerationRequest true "Moderation decision"
+// @Success 200 {object} domains.Review
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /reviews/{id}/moderation [put]
+func (h *ReviewHandler) ModerateReview(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	var req domains.ModerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	review, err := h.service.ModerateReview(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// @Summary Delete review
+// @Description Delete a review and refresh the product's rating
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /reviews/{id} [delete]
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	if err := h.service.DeleteReview(c.Request.Context(), id); err != nil {
+		c.JSON(reviewErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// reviewErrorStatus maps a foreign key violation to 404 (an unknown product
+// or skin type) and a unique violation to 409 (the customer already reviewed
+// the product).
+func reviewErrorStatus(err error) int {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, domains.ErrInvalidReview), errors.Is(err, domains.ErrInvalidModeration):
+		return http.StatusBadRequest
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23503":
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,303 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/cache"
+	"e-commerce/internal/domains"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type ReviewRepository interface {
+	Create(ctx context.Context, productID int, req *domains.ReviewRequest) (*domains.Review, error)
+	GetByID(ctx context.Context, id int) (*domains.Review, error)
+	GetAll(ctx context.Context, filter *domains.ReviewFilter) ([]*domains.Review, error)
+	Moderate(ctx context.Context, id int, req *domains.ModerationRequest) (*domains.Review, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type reviewRepository struct {
+	db           *pgxpool.Pool
+	productCache cache.CacheRepository[domains.ProductResponse]
+}
+
+func NewReviewRepository(db *pgxpool.Pool, cacheClient *cache.Cache) ReviewRepository {
+	return &reviewRepository{
+		db:           db,
+		productCache: cache.NewCacheRepository[domains.ProductResponse](cacheClient, "product"),
+	}
+}
+
+// reviewColumns reads a review aliased as rv joined with its skin type as st.
+const reviewColumns = `
+        rv.id, rv.product_id, rv.user_id, st.id, st.name, rv.rating, COALESCE(rv.title, ''), COALESCE(rv.body, ''),
+        rv.status, COALESCE(rv.moderation_note, ''), rv.moderated_at, rv.created_at, rv.updated_at`
+
+func scanReview(row pgx.Row) (*domains.Review, error) {
+	review := &domains.Review{}
+	var skinTypeID *int
+	var skinTypeName *string
+	err := row.Scan(
+		&review.ID,
+		&review.ProductID,
+		&review.UserID,
+		&skinTypeID,
+		&skinTypeName,
+		&review.Rating,
+		&review.Title,
+		&review.Body,
+		&review.Status,
+		&review.ModerationNote,
+		&review.ModeratedAt,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+	)
+	if err == nil && skinTypeID != nil {
+		review.SkinType = &domains.SkinType{ID: *skinTypeID, Name: *skinTypeName}
+	}
+	return review, err
+}
+
+func (r *reviewRepository) Create(ctx context.Context, productID int, req *domains.ReviewRequest) (*domains.Review, error) {
+	const insertQuery = `
+        WITH rv AS (
+            INSERT INTO reviews (product_id, user_id, skin_type_id, rating, title, body)
+            VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''))
+            RETURNING *
+        )
+        SELECT` + reviewColumns + `
+        FROM rv
+        LEFT JOIN skin_types st ON st.id = rv.skin_type_id`
+
+	review, err := scanReview(r.db.QueryRow(ctx, insertQuery,
+		productID,
+		req.UserID,
+		req.SkinTypeID,
+		req.Rating,
+		req.Title,
+		req.Body,
+	))
+	if err != nil {
+		logrus.WithError(err).WithField("review", req).Errorf("Failed to insert review (product_id: %d)", productID)
+		return nil, err
+	}
+
+	logrus.Debugf("Review created successfully (ID: %d)", review.ID)
+	return review, nil
+}
+
+func (r *reviewRepository) GetByID(ctx context.Context, id int) (*domains.Review, error) {
+	const getQuery = `
+        SELECT` + reviewColumns + `
+        FROM reviews rv
+        LEFT JOIN skin_types st ON st.id = rv.skin_type_id
+        WHERE rv.id = $1`
+
+	review, err := scanReview(r.db.QueryRow(ctx, getQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Review not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get review (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Review retrieved successfully (ID: %d)", review.ID)
+	return review, nil
+}
+
+// GetAll lists matching reviews, newest first.
+func (r *reviewRepository) GetAll(ctx context.Context, filter *domains.ReviewFilter) ([]*domains.Review, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if filter.ProductID != 0 {
+		args = append(args, filter.ProductID)
+		conditions = append(conditions, fmt.Sprintf("rv.product_id = $%d", len(args)))
+	}
+	if filter.SkinTypeID != 0 {
+		args = append(args, filter.SkinTypeID)
+		conditions = append(conditions, fmt.Sprintf("rv.skin_type_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("rv.status = $%d", len(args)))
+	}
+
+	query := `
+        SELECT` + reviewColumns + `
+        FROM reviews rv
+        LEFT JOIN skin_types st ON st.id = rv.skin_type_id`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY rv.created_at DESC, rv.id DESC"
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("Failed to query reviews: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []*domains.Review{}
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan review row: %v", err)
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating review rows: %v", err)
+		return nil, err
+	}
+
+	logrus.Debugf("Reviews retrieved successfully (Count: %d)", len(reviews))
+	return reviews, nil
+}
+
+// Moderate sets the review's status and refreshes the product's rating in
+// the same transaction.
+func (r *reviewRepository) Moderate(ctx context.Context, id int, req *domains.ModerationRequest) (*domains.Review, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	const moderateQuery = `
+        WITH rv AS (
+            UPDATE reviews
+            SET status = $1, moderation_note = NULLIF($2, ''),
+                moderated_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $3
+            RETURNING *
+        )
+        SELECT` + reviewColumns + `
+        FROM rv
+        LEFT JOIN skin_types st ON st.id = rv.skin_type_id`
+
+	review, err := scanReview(tx.QueryRow(ctx, moderateQuery, req.Status, req.Note, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to moderate non-existent review (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to moderate review (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	if err = refreshRating(ctx, tx, review.ProductID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+	r.invalidateProduct(ctx, review.ProductID)
+
+	logrus.Debugf("Review moderated successfully (ID: %d, status: %s)", review.ID, review.Status)
+	return review, nil
+}
+
+func (r *reviewRepository) Delete(ctx context.Context, id int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	const deleteQuery = `DELETE FROM reviews WHERE id = $1 RETURNING product_id, status`
+
+	var productID int
+	var status domains.ModerationStatus
+	if err = tx.QueryRow(ctx, deleteQuery, id).Scan(&productID, &status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to delete non-existent review (ID: %d)", id)
+			return sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to delete review (ID: %d): %v", id, err)
+		return err
+	}
+
+	if status == domains.ModerationApproved {
+		if err = refreshRating(ctx, tx, productID); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return err
+	}
+	if status == domains.ModerationApproved {
+		r.invalidateProduct(ctx, productID)
+	}
+
+	logrus.Debugf("Review deleted successfully (ID: %d)", id)
+	return nil
+}
+
+// refreshRating recomputes a product's rating from its approved reviews. The
+// product row is locked first so that concurrent review changes recompute
+// one after another, each counting the reviews the others committed.
+func refreshRating(ctx context.Context, tx pgx.Tx, productID int) error {
+	const lockQuery = `SELECT 1 FROM products WHERE id = $1 FOR UPDATE`
+
+	if _, err := tx.Exec(ctx, lockQuery, productID); err != nil {
+		logrus.Errorf("Failed to lock product for rating refresh (ID: %d): %v", productID, err)
+		return err
+	}
+
+	const refreshQuery = `
+        UPDATE products p
+        SET rating_average = COALESCE(r.average, 0), rating_count = r.count
+        FROM (
+            SELECT ROUND(AVG(rating), 2) AS average, COUNT(*) AS count
+            FROM reviews
+            WHERE product_id = $1 AND status = 'approved'
+        ) r
+        WHERE p.id = $1`
+
+	if _, err := tx.Exec(ctx, refreshQuery, productID); err != nil {
+		logrus.Errorf("Failed to refresh product rating (ID: %d): %v", productID, err)
+		return err
+	}
+	return nil
+}
+
+// invalidateProduct drops the cached product so its rating is reloaded on
+// the next read, along with cached filter results, which filter and sort by
+// rating.
+func (r *reviewRepository) invalidateProduct(ctx context.Context, productID int) {
+	if err := r.productCache.Delete(ctx, productID); err != nil {
+		logrus.Warnf("Failed to remove product from cache after review change (ID: %d): %v", productID, err)
+	}
+	if err := r.productCache.DeleteAll(ctx); err != nil {
+		logrus.Warnf("Failed to clear all products cache after review change (ID: %d): %v", productID, err)
+	}
+	if err := r.productCache.DeleteByPrefix(ctx, domains.ProductFilterKeyPrefix); err != nil {
+		logrus.Warnf("Failed to clear product filter cache after review change (ID: %d): %v", productID, err)
+	}
+}
@@ -0,0 +1,65 @@
+package review
+
+import (
+	"context"
+	"e-commerce/internal/domains"
+	"fmt"
+)
+
+type ReviewService interface {
+	CreateReview(ctx context.Context, productID int, req *domains.ReviewRequest) (*domains.Review, error)
+	GetReviewByID(ctx context.Context, id int) (*domains.Review, error)
+	GetProductReviews(ctx context.Context, productID, skinTypeID int) ([]*domains.Review, error)
+	GetReviews(ctx context.Context, filter *domains.ReviewFilter) ([]*domains.Review, error)
+	ModerateReview(ctx context.Context, id int, req *domains.ModerationRequest) (*domains.Review, error)
+	DeleteReview(ctx context.Context, id int) error
+}
+
+type reviewService struct {
+	repo ReviewRepository
+}
+
+func NewReviewService(repo ReviewRepository) ReviewService {
+	return &reviewService{repo: repo}
+}
+
+// CreateReview queues the review for moderation; it does not count towards
+// the product's rating until approved.
+func (s *reviewService) CreateReview(ctx context.Context, productID int, req *domains.ReviewRequest) (*domains.Review, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, productID, req)
+}
+
+func (s *reviewService) GetReviewByID(ctx context.Context, id int) (*domains.Review, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// GetProductReviews returns the approved reviews of a product, optionally
+// only those by reviewers of one skin type.
+func (s *reviewService) GetProductReviews(ctx context.Context, productID, skinTypeID int) ([]*domains.Review, error) {
+	return s.repo.GetAll(ctx, &domains.ReviewFilter{
+		ProductID:  productID,
+		SkinTypeID: skinTypeID,
+		Status:     domains.ModerationApproved,
+	})
+}
+
+func (s *reviewService) GetReviews(ctx context.Context, filter *domains.ReviewFilter) ([]*domains.Review, error) {
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, fmt.Errorf("%w: unknown status %q", domains.ErrInvalidReview, filter.Status)
+	}
+	return s.repo.GetAll(ctx, filter)
+}
+
+func (s *reviewService) ModerateReview(ctx context.Context, id int, req *domains.ModerationRequest) (*domains.Review, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.Moderate(ctx, id, req)
+}
+
+func (s *reviewService) DeleteReview(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
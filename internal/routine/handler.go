@@ -0,0 +1,214 @@
+package routine
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type RoutineHandler struct {
+	service RoutineService
+}
+
+func NewRoutineHandler(service RoutineService) *RoutineHandler {
+	return &RoutineHandler{service: service}
+}
+
+func (h *RoutineHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/routines/check", h.CheckRoutine)
+	router.POST("/routines/conflicts", h.CreateConflict)
+	router.GET("/routines/conflicts", h.GetConflicts)
+	router.GET("/routines/conflicts/:id", h.GetConflictByID)
+	router.PUT("/routines/conflicts/:id", h.UpdateConflict)
+	router.DELETE("/routines/conflicts/:id", h.DeleteConflict)
+}
+
+// @Summary Check a skincare routine
+// @Description Find ingredient conflicts between the products of a routine and suggest which to use in the morning and which in the evening
+// @Tags routines
+// @Accept json
+// @Produce json
+// @Param routine body domains.RoutineCheckRequest true "Products used together"
+// @Success 200 {object} domains.RoutineCheck
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /routines/check [post]
+func (h *RoutineHandler) CheckRoutine(c *gin.Context) {
+	var req domains.RoutineCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	check, err := h.service.CheckRoutine(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(routineErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, check)
+}
+
+// @Summary Create an ingredient conflict
+// @Description Add a rule that two ingredients should not be used together
+// @Tags routines
+// @Accept json
+// @Produce json
+// @Param conflict body domains.IngredientConflictRequest true "Conflict rule"
+// @Success 201 {object} domains.IngredientConflict
+// @Failure 400 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /routines/conflicts [post]
+func (h *RoutineHandler) CreateConflict(c *gin.Context) {
+	var req domains.IngredientConflictRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conflict, err := h.service.CreateConflict(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(routineErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, conflict)
+}
+
+// @Summary List ingredient conflicts
+// @Description Get conflict rules, optionally only those involving an ingredient
+// @Tags routines
+// @Accept json
+// @Produce json
+// @Param ingredient_id query int false "Ingredient ID"
+// @Success 200 {array} domains.IngredientConflict
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /routines/conflicts [get]
+func (h *RoutineHandler) GetConflicts(c *gin.Context) {
+	var filter domains.IngredientConflictFilter
+	if ingredientID := c.Query("ingredient_id"); ingredientID != "" {
+		id, err := strconv.Atoi(ingredientID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ingredient id"})
+			return
+		}
+		filter.IngredientID = id
+	}
+
+	conflicts, err := h.service.GetConflicts(c.Request.Context(), &filter)
+	if err != nil {
+		c.JSON(routineErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, conflicts)
+}
+
+// @Summary Get ingredient conflict by ID
+// @Description Get a conflict rule by its ID
+// @Tags routines
+// @Accept json
+// @Produce json
+// @Param id path int true "Conflict ID"
+// @Success 200 {object} domains.IngredientConflict
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /routines/conflicts/{id} [get]
+func (h *RoutineHandler) GetConflictByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid conflict id"})
+		return
+	}
+
+	conflict, err := h.service.GetConflictByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(routineErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, conflict)
+}
+
+// @Summary Update ingredient conflict
+// @Description Replace a conflict rule
+// @Tags routines
+// @Accept json
+// @Produce json
+// @Param id path int true "Conflict ID"
+// @Param conflict body domains.IngredientConflictRequest true "Conflict rule"
+// @Success 200 {object} domains.IngredientConflict
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Router /routines/conflicts/{id} [put]
+func (h *RoutineHandler) UpdateConflict(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid conflict id"})
+		return
+	}
+
+	var req domains.IngredientConflictRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conflict, err := h.service.UpdateConflict(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(routineErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, conflict)
+}
+
+// @Summary Delete ingredient conflict
+// @Description Delete a conflict rule by its ID
+// @Tags routines
+// @Accept json
+// @Produce json
+// @Param id path int true "Conflict ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /routines/conflicts/{id} [delete]
+func (h *RoutineHandler) DeleteConflict(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid conflict id"})
+		return
+	}
+
+	if err := h.service.DeleteConflict(c.Request.Context(), id); err != nil {
+		c.JSON(routineErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func routineErrorStatus(err error) int {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, domains.ErrInvalidRoutine):
+		return http.StatusBadRequest
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23503":
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,229 @@
+package routine
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/domains"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type RoutineRepository interface {
+	CreateConflict(ctx context.Context, req *domains.IngredientConflictRequest) (*domains.IngredientConflict, error)
+	GetConflictByID(ctx context.Context, id int) (*domains.IngredientConflict, error)
+	UpdateConflict(ctx context.Context, id int, req *domains.IngredientConflictRequest) (*domains.IngredientConflict, error)
+	DeleteConflict(ctx context.Context, id int) error
+	GetConflicts(ctx context.Context, filter *domains.IngredientConflictFilter) ([]*domains.IngredientConflict, error)
+	GetProducts(ctx context.Context, productIDs []int) ([]domains.RoutineProduct, error)
+	FindConflicts(ctx context.Context, productIDs []int) ([]domains.RoutineConflict, error)
+}
+
+type routineRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRoutineRepository(db *pgxpool.Pool) RoutineRepository {
+	return &routineRepository{db: db}
+}
+
+// conflictQuery selects conflict rules with their ingredient names. Write
+// queries wrap their RETURNING row in a CTE named c to reuse it.
+const conflictQuery = `
+        SELECT c.id, c.ingredient_id, a.inci_name, c.conflicting_ingredient_id, b.inci_name,
+               c.severity, c.explanation, c.created_at, c.updated_at
+        FROM c
+        JOIN ingredients a ON a.id = c.ingredient_id
+        JOIN ingredients b ON b.id = c.conflicting_ingredient_id`
+
+func scanConflict(row pgx.Row) (*domains.IngredientConflict, error) {
+	conflict := &domains.IngredientConflict{}
+	err := row.Scan(
+		&conflict.ID,
+		&conflict.IngredientID,
+		&conflict.IngredientName,
+		&conflict.ConflictingIngredientID,
+		&conflict.ConflictingIngredientName,
+		&conflict.Severity,
+		&conflict.Explanation,
+		&conflict.CreatedAt,
+		&conflict.UpdatedAt,
+	)
+	return conflict, err
+}
+
+func (r *routineRepository) CreateConflict(ctx context.Context, req *domains.IngredientConflictRequest) (*domains.IngredientConflict, error) {
+	const insertQuery = `
+        WITH c AS (
+            INSERT INTO ingredient_conflicts (ingredient_id, conflicting_ingredient_id, severity, explanation)
+            VALUES ($1, $2, $3, $4)
+            RETURNING *
+        )` + conflictQuery
+
+	conflict, err := scanConflict(r.db.QueryRow(ctx, insertQuery,
+		req.IngredientID, req.ConflictingIngredientID, req.Severity, req.Explanation))
+	if err != nil {
+		logrus.WithError(err).WithField("ingredient_conflict", req).Error("Failed to insert ingredient conflict")
+		return nil, err
+	}
+
+	logrus.Debugf("Ingredient conflict created successfully (ID: %d)", conflict.ID)
+	return conflict, nil
+}
+
+func (r *routineRepository) GetConflictByID(ctx context.Context, id int) (*domains.IngredientConflict, error) {
+	const getQuery = `
+        WITH c AS (SELECT * FROM ingredient_conflicts WHERE id = $1)` + conflictQuery
+
+	conflict, err := scanConflict(r.db.QueryRow(ctx, getQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Ingredient conflict not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get ingredient conflict (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	return conflict, nil
+}
+
+func (r *routineRepository) UpdateConflict(ctx context.Context, id int, req *domains.IngredientConflictRequest) (*domains.IngredientConflict, error) {
+	const updateQuery = `
+        WITH c AS (
+            UPDATE ingredient_conflicts
+            SET ingredient_id = $1, conflicting_ingredient_id = $2, severity = $3, explanation = $4,
+                updated_at = CURRENT_TIMESTAMP
+            WHERE id = $5
+            RETURNING *
+        )` + conflictQuery
+
+	conflict, err := scanConflict(r.db.QueryRow(ctx, updateQuery,
+		req.IngredientID, req.ConflictingIngredientID, req.Severity, req.Explanation, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to update non-existent ingredient conflict (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to update ingredient conflict (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Ingredient conflict updated successfully (ID: %d)", conflict.ID)
+	return conflict, nil
+}
+
+func (r *routineRepository) DeleteConflict(ctx context.Context, id int) error {
+	const deleteQuery = `DELETE FROM ingredient_conflicts WHERE id = $1 RETURNING id`
+
+	var deletedID int
+	err := r.db.QueryRow(ctx, deleteQuery, id).Scan(&deletedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to delete non-existent ingredient conflict (ID: %d)", id)
+			return sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to delete ingredient conflict (ID: %d): %v", id, err)
+		return err
+	}
+
+	logrus.Debugf("Ingredient conflict deleted successfully (ID: %d)", deletedID)
+	return nil
+}
+
+// GetConflicts lists conflict rules, optionally only those involving one
+// ingredient on either side of the pair.
+func (r *routineRepository) GetConflicts(ctx context.Context, filter *domains.IngredientConflictFilter) ([]*domains.IngredientConflict, error) {
+	const listQuery = `
+        WITH c AS (
+            SELECT * FROM ingredient_conflicts
+            WHERE $1 = 0 OR $1 IN (ingredient_id, conflicting_ingredient_id)
+        )` + conflictQuery + `
+        ORDER BY a.inci_name, b.inci_name`
+
+	rows, err := r.db.Query(ctx, listQuery, filter.IngredientID)
+	if err != nil {
+		logrus.Errorf("Failed to query ingredient conflicts: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	conflicts := []*domains.IngredientConflict{}
+	for rows.Next() {
+		conflict, err := scanConflict(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan ingredient conflict row: %v", err)
+			return nil, err
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating ingredient conflict rows: %v", err)
+		return nil, err
+	}
+
+	logrus.Debugf("Ingredient conflicts retrieved successfully (Count: %d)", len(conflicts))
+	return conflicts, nil
+}
+
+func (r *routineRepository) GetProducts(ctx context.Context, productIDs []int) ([]domains.RoutineProduct, error) {
+	const productsQuery = `SELECT id, name FROM products WHERE id = ANY($1)`
+
+	rows, err := r.db.Query(ctx, productsQuery, productIDs)
+	if err != nil {
+		logrus.Errorf("Failed to query routine products: %v", err)
+		return nil, err
+	}
+	products, err := pgx.CollectRows(rows, pgx.RowToStructByPos[domains.RoutineProduct])
+	if err != nil {
+		logrus.Errorf("Failed to scan routine products: %v", err)
+		return nil, err
+	}
+	return products, nil
+}
+
+// FindConflicts returns every conflict rule matched by the ingredients of
+// the products, once per pair of products. A product holding both
+// ingredients of a rule is paired with itself.
+func (r *routineRepository) FindConflicts(ctx context.Context, productIDs []int) ([]domains.RoutineConflict, error) {
+	const findQuery = `
+        SELECT pa.product_id, a.inci_name, pb.product_id, b.inci_name, c.severity, c.explanation
+        FROM ingredient_conflicts c
+        JOIN product_ingredients pa ON pa.ingredient_id = c.ingredient_id
+        JOIN product_ingredients pb ON pb.ingredient_id = c.conflicting_ingredient_id
+        JOIN ingredients a ON a.id = c.ingredient_id
+        JOIN ingredients b ON b.id = c.conflicting_ingredient_id
+        WHERE pa.product_id = ANY($1) AND pb.product_id = ANY($1)`
+
+	rows, err := r.db.Query(ctx, findQuery, productIDs)
+	if err != nil {
+		logrus.Errorf("Failed to query routine conflicts: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conflicts []domains.RoutineConflict
+	for rows.Next() {
+		var conflict domains.RoutineConflict
+		if err := rows.Scan(
+			&conflict.ProductID,
+			&conflict.Ingredient,
+			&conflict.ConflictingProductID,
+			&conflict.ConflictingIngredient,
+			&conflict.Severity,
+			&conflict.Explanation,
+		); err != nil {
+			logrus.Errorf("Failed to scan routine conflict row: %v", err)
+			return nil, err
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating routine conflict rows: %v", err)
+		return nil, err
+	}
+
+	return conflicts, nil
+}
@@ -0,0 +1,171 @@
+package routine
+
+import (
+	"cmp"
+	"context"
+	"e-commerce/internal/domains"
+	"fmt"
+	"slices"
+)
+
+type RoutineService interface {
+	CreateConflict(ctx context.Context, req *domains.IngredientConflictRequest) (*domains.IngredientConflict, error)
+	GetConflictByID(ctx context.Context, id int) (*domains.IngredientConflict, error)
+	UpdateConflict(ctx context.Context, id int, req *domains.IngredientConflictRequest) (*domains.IngredientConflict, error)
+	DeleteConflict(ctx context.Context, id int) error
+	GetConflicts(ctx context.Context, filter *domains.IngredientConflictFilter) ([]*domains.IngredientConflict, error)
+	CheckRoutine(ctx context.Context, req *domains.RoutineCheckRequest) (*domains.RoutineCheck, error)
+}
+
+type routineService struct {
+	repo RoutineRepository
+}
+
+func NewRoutineService(repo RoutineRepository) RoutineService {
+	return &routineService{repo: repo}
+}
+
+func (s *routineService) CreateConflict(ctx context.Context, req *domains.IngredientConflictRequest) (*domains.IngredientConflict, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateConflict(ctx, req)
+}
+
+func (s *routineService) GetConflictByID(ctx context.Context, id int) (*domains.IngredientConflict, error) {
+	return s.repo.GetConflictByID(ctx, id)
+}
+
+func (s *routineService) UpdateConflict(ctx context.Context, id int, req *domains.IngredientConflictRequest) (*domains.IngredientConflict, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateConflict(ctx, id, req)
+}
+
+func (s *routineService) DeleteConflict(ctx context.Context, id int) error {
+	return s.repo.DeleteConflict(ctx, id)
+}
+
+func (s *routineService) GetConflicts(ctx context.Context, filter *domains.IngredientConflictFilter) ([]*domains.IngredientConflict, error) {
+	return s.repo.GetConflicts(ctx, filter)
+}
+
+// CheckRoutine finds the conflicts between the products and splits the
+// conflicting ones between morning and evening. Conflicts are placed from
+// the most severe down, so when not every pair can be kept apart it is the
+// milder ones that are left together. The first product of each group of
+// conflicting products goes to the morning.
+func (s *routineService) CheckRoutine(ctx context.Context, req *domains.RoutineCheckRequest) (*domains.RoutineCheck, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	products, err := s.repo.GetProducts(ctx, req.ProductIDs)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int]string, len(products))
+	for _, product := range products {
+		names[product.ID] = product.Name
+	}
+	for _, id := range req.ProductIDs {
+		if _, ok := names[id]; !ok {
+			return nil, fmt.Errorf("%w: product %d not found", domains.ErrInvalidRoutine, id)
+		}
+	}
+
+	conflicts, err := s.repo.FindConflicts(ctx, req.ProductIDs)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(conflicts, func(a, b domains.RoutineConflict) int {
+		return cmp.Or(
+			cmp.Compare(b.Severity.Rank(), a.Severity.Rank()),
+			cmp.Compare(a.ProductID, b.ProductID),
+			cmp.Compare(a.ConflictingProductID, b.ConflictingProductID),
+		)
+	})
+
+	sched := newSchedule()
+	for i := range conflicts {
+		conflict := &conflicts[i]
+		conflict.ProductName = names[conflict.ProductID]
+		conflict.ConflictingProductName = names[conflict.ConflictingProductID]
+		if conflict.ProductID != conflict.ConflictingProductID {
+			conflict.Separable = sched.separate(conflict.ProductID, conflict.ConflictingProductID)
+		}
+	}
+
+	check := &domains.RoutineCheck{
+		Conflicts: conflicts,
+		AM:        []domains.RoutineProduct{},
+		PM:        []domains.RoutineProduct{},
+	}
+	if check.Conflicts == nil {
+		check.Conflicts = []domains.RoutineConflict{}
+	}
+	rootPM := make(map[int]bool)
+	for _, id := range req.ProductIDs {
+		product := domains.RoutineProduct{ID: id, Name: names[id]}
+		if !sched.placed[id] {
+			check.AM = append(check.AM, product)
+			check.PM = append(check.PM, product)
+			continue
+		}
+		root, flip := sched.find(id)
+		if _, ok := rootPM[root]; !ok {
+			rootPM[root] = flip
+		}
+		if rootPM[root] != flip {
+			check.PM = append(check.PM, product)
+		} else {
+			check.AM = append(check.AM, product)
+		}
+	}
+
+	return check, nil
+}
+
+// schedule is a union-find over products that remembers, for each product,
+// whether it goes to the other half of the day than its parent.
+type schedule struct {
+	parent map[int]int
+	flip   map[int]bool
+	placed map[int]bool
+}
+
+func newSchedule() *schedule {
+	return &schedule{
+		parent: make(map[int]int),
+		flip:   make(map[int]bool),
+		placed: make(map[int]bool),
+	}
+}
+
+// find returns the root of the product's group and whether the product is
+// used at the other half of the day than the root.
+func (s *schedule) find(id int) (int, bool) {
+	parent, ok := s.parent[id]
+	if !ok {
+		return id, false
+	}
+	root, flip := s.find(parent)
+	s.parent[id] = root
+	s.flip[id] = s.flip[id] != flip
+	return root, s.flip[id]
+}
+
+// separate puts the two products at different halves of the day. It
+// reports false when earlier placements already tie them to the same half.
+func (s *schedule) separate(a, b int) bool {
+	s.placed[a], s.placed[b] = true, true
+	rootA, flipA := s.find(a)
+	rootB, flipB := s.find(b)
+	if rootA == rootB {
+		return flipA != flipB
+	}
+	s.parent[rootB] = rootA
+	s.flip[rootB] = flipA == flipB
+	return true
+}
@@ -0,0 +1,346 @@
+package shipping
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type ShippingHandler struct {
+	service ShippingService
+}
+
+func NewShippingHandler(service ShippingService) *ShippingHandler {
+	return &ShippingHandler{service: service}
+}
+
+func (h *ShippingHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/shipping/zones", h.CreateZone)
+	router.GET("/shipping/zones/:id", h.GetZoneByID)
+	router.PUT("/shipping/zones/:id", h.UpdateZone)
+	router.DELETE("/shipping/zones/:id", h.DeleteZone)
+	router.GET("/shipping/zones", h.GetAllZones)
+	router.POST("/shipping/methods", h.CreateMethod)
+	router.GET("/shipping/methods/:id", h.GetMethodByID)
+	router.PUT("/shipping/methods/:id", h.UpdateMethod)
+	router.DELETE("/shipping/methods/:id", h.DeleteMethod)
+	router.GET("/shipping/methods", h.GetMethods)
+	router.POST("/shipping/quote", h.Quote)
+}
+
+// @Summary Create a new shipping zone
+// @Description Create a zone covering a set of countries, optionally narrowed to postal code prefixes
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Param zone body domains.ShippingZoneRequest true "Shipping zone object"
+// @Success 201 {object} domains.ShippingZone
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /shipping/zones [post]
+func (h *ShippingHandler) CreateZone(c *gin.Context) {
+	var req domains.ShippingZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdZone, err := h.service.CreateZone(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(shippingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdZone)
+}
+
+// @Summary Get shipping zone by ID
+// @Description Get a shipping zone by its ID
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipping zone ID"
+// @Success 200 {object} domains.ShippingZone
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /shipping/zones/{id} [get]
+func (h *ShippingHandler) GetZoneByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid shipping zone id"})
+		return
+	}
+
+	zone, err := h.service.GetZoneByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(shippingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, zone)
+}
+
+// @Summary Update shipping zone
+// @Description Replace an existing shipping zone
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipping zone ID"
+// @Param zone body domains.ShippingZoneRequest true "Shipping zone object"
+// @Success 200 {object} domains.ShippingZone
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /shipping/zones/{id} [put]
+func (h *ShippingHandler) UpdateZone(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid shipping zone id"})
+		return
+	}
+
+	var req domains.ShippingZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedZone, err := h.service.UpdateZone(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(shippingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedZone)
+}
+
+// @Summary Delete shipping zone
+// @Description Delete a shipping zone and its methods
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipping zone ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /shipping/zones/{id} [delete]
+func (h *ShippingHandler) DeleteZone(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid shipping zone id"})
+		return
+	}
+
+	if err := h.service.DeleteZone(c.Request.Context(), id); err != nil {
+		c.JSON(shippingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get all shipping zones
+// @Description Get every shipping zone in matching order
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Success 200 {array} domains.ShippingZone
+// @Failure 500 {object} domains.Error
+// @Router /shipping/zones [get]
+func (h *ShippingHandler) GetAllZones(c *gin.Context) {
+	zones, err := h.service.GetAllZones(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, zones)
+}
+
+// @Summary Create a new shipping method
+// @Description Create a flat rate, weight based, free over threshold or pickup method in a zone
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Param method body domains.ShippingMethodRequest true "Shipping method object"
+// @Success 201 {object} domains.ShippingMethod
+// @Failure 400 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /shipping/methods [post]
+func (h *ShippingHandler) CreateMethod(c *gin.Context) {
+	var req domains.ShippingMethodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdMethod, err := h.service.CreateMethod(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(shippingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdMethod)
+}
+
+// @Summary Get shipping method by ID
+// @Description Get a shipping method by its ID
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipping method ID"
+// @Success 200 {object} domains.ShippingMethod
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /shipping/methods/{id} [get]
+func (h *ShippingHandler) GetMethodByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid shipping method id"})
+		return
+	}
+
+	method, err := h.service.GetMethodByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(shippingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, method)
+}
+
+// @Summary Update shipping method
+// @Description Replace an existing shipping method
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipping method ID"
+// @Param method body domains.ShippingMethodRequest true "Shipping method object"
+// @Success 200 {object} domains.ShippingMethod
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Router /shipping/methods/{id} [put]
+func (h *ShippingHandler) UpdateMethod(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid shipping method id"})
+		return
+	}
+
+	var req domains.ShippingMethodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedMethod, err := h.service.UpdateMethod(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(shippingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedMethod)
+}
+
+// @Summary Delete shipping method
+// @Description Delete a shipping method by its ID
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Param id path int true "Shipping method ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /shipping/methods/{id} [delete]
+func (h *ShippingHandler) DeleteMethod(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid shipping method id"})
+		return
+	}
+
+	if err := h.service.DeleteMethod(c.Request.Context(), id); err != nil {
+		c.JSON(shippingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get shipping methods
+// @Description Get shipping methods, optionally only those of a zone
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Param zone_id query int false "Shipping zone ID"
+// @Success 200 {array} domains.ShippingMethod
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /shipping/methods [get]
+func (h *ShippingHandler) GetMethods(c *gin.Context) {
+	var filter domains.ShippingMethodFilter
+	if zoneID := c.Query("zone_id"); zoneID != "" {
+		id, err := strconv.Atoi(zoneID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid shipping zone id"})
+			return
+		}
+		filter.ZoneID = id
+	}
+
+	methods, err := h.service.GetMethods(c.Request.Context(), &filter)
+	if err != nil {
+		c.JSON(shippingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, methods)
+}
+
+// @Summary Quote shipping for a cart
+// @Description List the active shipping methods that can ship a cart to an address, with their prices, cheapest first
+// @Tags shipping
+// @Accept json
+// @Produce json
+// @Param quote body domains.ShippingQuoteRequest true "Cart and address"
+// @Success 200 {object} domains.ShippingQuote
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /shipping/quote [post]
+func (h *ShippingHandler) Quote(c *gin.Context) {
+	var req domains.ShippingQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	quote, err := h.service.Quote(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(shippingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+func shippingErrorStatus(err error) int {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, domains.ErrInvalidShipping), errors.Is(err, domains.ErrInvalidCartItem):
+		return http.StatusBadRequest
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && (pgErr.Code == "23503" || pgErr.Code == "23505"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
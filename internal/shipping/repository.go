@@ -0,0 +1,364 @@
+package shipping
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/domains"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type ShippingRepository interface {
+	CreateZone(ctx context.Context, req *domains.ShippingZoneRequest) (*domains.ShippingZone, error)
+	GetZoneByID(ctx context.Context, id int) (*domains.ShippingZone, error)
+	UpdateZone(ctx context.Context, id int, req *domains.ShippingZoneRequest) (*domains.ShippingZone, error)
+	DeleteZone(ctx context.Context, id int) error
+	GetAllZones(ctx context.Context) ([]*domains.ShippingZone, error)
+	CreateMethod(ctx context.Context, req *domains.ShippingMethodRequest) (*domains.ShippingMethod, error)
+	GetMethodByID(ctx context.Context, id int) (*domains.ShippingMethod, error)
+	UpdateMethod(ctx context.Context, id int, req *domains.ShippingMethodRequest) (*domains.ShippingMethod, error)
+	DeleteMethod(ctx context.Context, id int) error
+	GetMethods(ctx context.Context, filter *domains.ShippingMethodFilter) ([]*domains.ShippingMethod, error)
+	GetVariantWeights(ctx context.Context, variantIDs []int) (map[int]float64, error)
+}
+
+type shippingRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewShippingRepository(db *pgxpool.Pool) ShippingRepository {
+	return &shippingRepository{db: db}
+}
+
+const zoneColumns = `
+        id, name, countries, postal_prefixes, priority, created_at, updated_at`
+
+func scanZone(row pgx.Row) (*domains.ShippingZone, error) {
+	zone := &domains.ShippingZone{}
+	err := row.Scan(
+		&zone.ID,
+		&zone.Name,
+		&zone.Countries,
+		&zone.PostalPrefixes,
+		&zone.Priority,
+		&zone.CreatedAt,
+		&zone.UpdatedAt,
+	)
+	return zone, err
+}
+
+const methodColumns = `
+        id, zone_id, name, method_type, price, threshold, weight_tiers, COALESCE(pickup_address, ''),
+        estimated_days, is_active, created_at, updated_at`
+
+func scanMethod(row pgx.Row) (*domains.ShippingMethod, error) {
+	method := &domains.ShippingMethod{}
+	err := row.Scan(
+		&method.ID,
+		&method.ZoneID,
+		&method.Name,
+		&method.Type,
+		&method.Price,
+		&method.Threshold,
+		&method.WeightTiers,
+		&method.PickupAddress,
+		&method.EstimatedDays,
+		&method.IsActive,
+		&method.CreatedAt,
+		&method.UpdatedAt,
+	)
+	return method, err
+}
+
+func postalPrefixes(req *domains.ShippingZoneRequest) []string {
+	if req.PostalPrefixes == nil {
+		return []string{}
+	}
+	return req.PostalPrefixes
+}
+
+func weightTiers(req *domains.ShippingMethodRequest) []domains.WeightTier {
+	if req.WeightTiers == nil {
+		return []domains.WeightTier{}
+	}
+	return req.WeightTiers
+}
+
+func (r *shippingRepository) CreateZone(ctx context.Context, req *domains.ShippingZoneRequest) (*domains.ShippingZone, error) {
+	const insertQuery = `
+        INSERT INTO shipping_zones (name, countries, postal_prefixes, priority)
+        VALUES ($1, $2, $3, $4)
+        RETURNING` + zoneColumns
+
+	zone, err := scanZone(r.db.QueryRow(ctx, insertQuery, req.Name, req.Countries, postalPrefixes(req), req.Priority))
+	if err != nil {
+		logrus.WithError(err).WithField("zone", req).Error("Failed to insert shipping zone")
+		return nil, err
+	}
+
+	logrus.Debugf("Shipping zone created successfully (ID: %d)", zone.ID)
+	return zone, nil
+}
+
+func (r *shippingRepository) GetZoneByID(ctx context.Context, id int) (*domains.ShippingZone, error) {
+	const getQuery = `SELECT` + zoneColumns + ` FROM shipping_zones WHERE id = $1`
+
+	zone, err := scanZone(r.db.QueryRow(ctx, getQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Shipping zone not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get shipping zone (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Shipping zone retrieved successfully (ID: %d)", zone.ID)
+	return zone, nil
+}
+
+func (r *shippingRepository) UpdateZone(ctx context.Context, id int, req *domains.ShippingZoneRequest) (*domains.ShippingZone, error) {
+	const updateQuery = `
+        UPDATE shipping_zones
+        SET name = $1, countries = $2, postal_prefixes = $3, priority = $4, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $5
+        RETURNING` + zoneColumns
+
+	zone, err := scanZone(r.db.QueryRow(ctx, updateQuery, req.Name, req.Countries, postalPrefixes(req), req.Priority, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to update non-existent shipping zone (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to update shipping zone (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Shipping zone updated successfully (ID: %d)", zone.ID)
+	return zone, nil
+}
+
+// DeleteZone removes the zone together with its methods.
+func (r *shippingRepository) DeleteZone(ctx context.Context, id int) error {
+	const deleteQuery = `DELETE FROM shipping_zones WHERE id = $1 RETURNING id`
+
+	var deletedID int
+	err := r.db.QueryRow(ctx, deleteQuery, id).Scan(&deletedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to delete non-existent shipping zone (ID: %d)", id)
+			return sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to delete shipping zone (ID: %d): %v", id, err)
+		return err
+	}
+
+	logrus.Debugf("Shipping zone deleted successfully (ID: %d)", deletedID)
+	return nil
+}
+
+// GetAllZones returns the zones in the order quotes try them.
+func (r *shippingRepository) GetAllZones(ctx context.Context) ([]*domains.ShippingZone, error) {
+	const listQuery = `SELECT` + zoneColumns + ` FROM shipping_zones ORDER BY priority, id`
+
+	rows, err := r.db.Query(ctx, listQuery)
+	if err != nil {
+		logrus.Errorf("Failed to query shipping zones: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	zones := []*domains.ShippingZone{}
+	for rows.Next() {
+		zone, err := scanZone(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan shipping zone row: %v", err)
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating shipping zone rows: %v", err)
+		return nil, err
+	}
+
+	logrus.Debugf("Shipping zones retrieved successfully (Count: %d)", len(zones))
+	return zones, nil
+}
+
+func (r *shippingRepository) CreateMethod(ctx context.Context, req *domains.ShippingMethodRequest) (*domains.ShippingMethod, error) {
+	const insertQuery = `
+        INSERT INTO shipping_methods (zone_id, name, method_type, price, threshold, weight_tiers, pickup_address,
+                                      estimated_days, is_active)
+        VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, COALESCE($9, TRUE))
+        RETURNING` + methodColumns
+
+	method, err := scanMethod(r.db.QueryRow(ctx, insertQuery,
+		req.ZoneID,
+		req.Name,
+		req.Type,
+		req.Price,
+		req.Threshold,
+		weightTiers(req),
+		req.PickupAddress,
+		req.EstimatedDays,
+		req.IsActive,
+	))
+	if err != nil {
+		logrus.WithError(err).WithField("method", req).Error("Failed to insert shipping method")
+		return nil, err
+	}
+
+	logrus.Debugf("Shipping method created successfully (ID: %d)", method.ID)
+	return method, nil
+}
+
+func (r *shippingRepository) GetMethodByID(ctx context.Context, id int) (*domains.ShippingMethod, error) {
+	const getQuery = `SELECT` + methodColumns + ` FROM shipping_methods WHERE id = $1`
+
+	method, err := scanMethod(r.db.QueryRow(ctx, getQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Shipping method not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get shipping method (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Shipping method retrieved successfully (ID: %d)", method.ID)
+	return method, nil
+}
+
+func (r *shippingRepository) UpdateMethod(ctx context.Context, id int, req *domains.ShippingMethodRequest) (*domains.ShippingMethod, error) {
+	const updateQuery = `
+        UPDATE shipping_methods
+        SET zone_id = $1, name = $2, method_type = $3, price = $4, threshold = $5, weight_tiers = $6,
+            pickup_address = NULLIF($7, ''), estimated_days = $8, is_active = COALESCE($9, is_active),
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $10
+        RETURNING` + methodColumns
+
+	method, err := scanMethod(r.db.QueryRow(ctx, updateQuery,
+		req.ZoneID,
+		req.Name,
+		req.Type,
+		req.Price,
+		req.Threshold,
+		weightTiers(req),
+		req.PickupAddress,
+		req.EstimatedDays,
+		req.IsActive,
+		id,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to update non-existent shipping method (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to update shipping method (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Shipping method updated successfully (ID: %d)", method.ID)
+	return method, nil
+}
+
+func (r *shippingRepository) DeleteMethod(ctx context.Context, id int) error {
+	const deleteQuery = `DELETE FROM shipping_methods WHERE id = $1 RETURNING id`
+
+	var deletedID int
+	err := r.db.QueryRow(ctx, deleteQuery, id).Scan(&deletedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to delete non-existent shipping method (ID: %d)", id)
+			return sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to delete shipping method (ID: %d): %v", id, err)
+		return err
+	}
+
+	logrus.Debugf("Shipping method deleted successfully (ID: %d)", deletedID)
+	return nil
+}
+
+func (r *shippingRepository) GetMethods(ctx context.Context, filter *domains.ShippingMethodFilter) ([]*domains.ShippingMethod, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if filter.ZoneID > 0 {
+		args = append(args, filter.ZoneID)
+		conditions = append(conditions, fmt.Sprintf("zone_id = $%d", len(args)))
+	}
+	if filter.ActiveOnly {
+		conditions = append(conditions, "is_active")
+	}
+
+	query := `SELECT` + methodColumns + ` FROM shipping_methods`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY zone_id, id"
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("Failed to query shipping methods: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	methods := []*domains.ShippingMethod{}
+	for rows.Next() {
+		method, err := scanMethod(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan shipping method row: %v", err)
+			return nil, err
+		}
+		methods = append(methods, method)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating shipping method rows: %v", err)
+		return nil, err
+	}
+
+	logrus.Debugf("Shipping methods retrieved successfully (Count: %d)", len(methods))
+	return methods, nil
+}
+
+// GetVariantWeights returns the weight of each variant that has one set.
+func (r *shippingRepository) GetVariantWeights(ctx context.Context, variantIDs []int) (map[int]float64, error) {
+	const weightsQuery = `
+        SELECT id, weight_grams
+        FROM product_variants
+        WHERE id = ANY($1) AND weight_grams IS NOT NULL`
+
+	rows, err := r.db.Query(ctx, weightsQuery, variantIDs)
+	if err != nil {
+		logrus.Errorf("Failed to query variant weights: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	weights := make(map[int]float64, len(variantIDs))
+	for rows.Next() {
+		var id int
+		var weight float64
+		if err := rows.Scan(&id, &weight); err != nil {
+			logrus.Errorf("Failed to scan variant weight: %v", err)
+			return nil, err
+		}
+		weights[id] = weight
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating variant weight rows: %v", err)
+		return nil, err
+	}
+
+	return weights, nil
+}
@@ -0,0 +1,165 @@
+package shipping
+
+import (
+	"cmp"
+	"context"
+	"e-commerce/internal/cart"
+	"e-commerce/internal/domains"
+	"fmt"
+	"slices"
+)
+
+type ShippingService interface {
+	CreateZone(ctx context.Context, req *domains.ShippingZoneRequest) (*domains.ShippingZone, error)
+	GetZoneByID(ctx context.Context, id int) (*domains.ShippingZone, error)
+	UpdateZone(ctx context.Context, id int, req *domains.ShippingZoneRequest) (*domains.ShippingZone, error)
+	DeleteZone(ctx context.Context, id int) error
+	GetAllZones(ctx context.Context) ([]*domains.ShippingZone, error)
+	CreateMethod(ctx context.Context, req *domains.ShippingMethodRequest) (*domains.ShippingMethod, error)
+	GetMethodByID(ctx context.Context, id int) (*domains.ShippingMethod, error)
+	UpdateMethod(ctx context.Context, id int, req *domains.ShippingMethodRequest) (*domains.ShippingMethod, error)
+	DeleteMethod(ctx context.Context, id int) error
+	GetMethods(ctx context.Context, filter *domains.ShippingMethodFilter) ([]*domains.ShippingMethod, error)
+	Quote(ctx context.Context, req *domains.ShippingQuoteRequest) (*domains.ShippingQuote, error)
+}
+
+type shippingService struct {
+	repo  ShippingRepository
+	carts cart.CartService
+}
+
+func NewShippingService(repo ShippingRepository, carts cart.CartService) ShippingService {
+	return &shippingService{repo: repo, carts: carts}
+}
+
+func (s *shippingService) CreateZone(ctx context.Context, req *domains.ShippingZoneRequest) (*domains.ShippingZone, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateZone(ctx, req)
+}
+
+func (s *shippingService) GetZoneByID(ctx context.Context, id int) (*domains.ShippingZone, error) {
+	return s.repo.GetZoneByID(ctx, id)
+}
+
+func (s *shippingService) UpdateZone(ctx context.Context, id int, req *domains.ShippingZoneRequest) (*domains.ShippingZone, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateZone(ctx, id, req)
+}
+
+func (s *shippingService) DeleteZone(ctx context.Context, id int) error {
+	return s.repo.DeleteZone(ctx, id)
+}
+
+func (s *shippingService) GetAllZones(ctx context.Context) ([]*domains.ShippingZone, error) {
+	return s.repo.GetAllZones(ctx)
+}
+
+func (s *shippingService) CreateMethod(ctx context.Context, req *domains.ShippingMethodRequest) (*domains.ShippingMethod, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateMethod(ctx, req)
+}
+
+func (s *shippingService) GetMethodByID(ctx context.Context, id int) (*domains.ShippingMethod, error) {
+	return s.repo.GetMethodByID(ctx, id)
+}
+
+func (s *shippingService) UpdateMethod(ctx context.Context, id int, req *domains.ShippingMethodRequest) (*domains.ShippingMethod, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateMethod(ctx, id, req)
+}
+
+func (s *shippingService) DeleteMethod(ctx context.Context, id int) error {
+	return s.repo.DeleteMethod(ctx, id)
+}
+
+func (s *shippingService) GetMethods(ctx context.Context, filter *domains.ShippingMethodFilter) ([]*domains.ShippingMethod, error) {
+	return s.repo.GetMethods(ctx, filter)
+}
+
+// Quote finds the first zone covering the address and prices its active
+// methods for the cart. Items without a variant weight count as
+// weightless.
+func (s *shippingService) Quote(ctx context.Context, req *domains.ShippingQuoteRequest) (*domains.ShippingQuote, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	ref := cart.CartRef{Token: req.CartToken}
+	if req.UserID != nil {
+		ref.UserID = *req.UserID
+	}
+	c, err := s.carts.GetCart(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Items) == 0 {
+		return nil, fmt.Errorf("%w: cart is empty", domains.ErrInvalidShipping)
+	}
+
+	quote := &domains.ShippingQuote{
+		Address:  req.Address,
+		Subtotal: c.Subtotal,
+		Currency: c.Currency,
+		Options:  []domains.ShippingOption{},
+	}
+
+	var variantIDs []int
+	for _, item := range c.Items {
+		if item.VariantID != nil {
+			variantIDs = append(variantIDs, *item.VariantID)
+		}
+	}
+	if len(variantIDs) > 0 {
+		weights, err := s.repo.GetVariantWeights(ctx, variantIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range c.Items {
+			if item.VariantID != nil {
+				quote.WeightGrams += weights[*item.VariantID] * float64(item.Quantity)
+			}
+		}
+	}
+
+	zones, err := s.repo.GetAllZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(zones, func(z *domains.ShippingZone) bool { return z.Matches(&req.Address) })
+	if i < 0 {
+		return quote, nil
+	}
+	quote.ZoneID = &zones[i].ID
+
+	methods, err := s.repo.GetMethods(ctx, &domains.ShippingMethodFilter{ZoneID: zones[i].ID, ActiveOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	for _, method := range methods {
+		price, ok := method.Quote(c.Subtotal, quote.WeightGrams)
+		if !ok {
+			continue
+		}
+		quote.Options = append(quote.Options, domains.ShippingOption{
+			MethodID:      method.ID,
+			Name:          method.Name,
+			Type:          method.Type,
+			Price:         price,
+			PickupAddress: method.PickupAddress,
+			EstimatedDays: method.EstimatedDays,
+		})
+	}
+	slices.SortStableFunc(quote.Options, func(a, b domains.ShippingOption) int {
+		return cmp.Compare(a.Price, b.Price)
+	})
+
+	return quote, nil
+}
@@ -26,10 +26,10 @@ type skinTypeRepository struct {
 	cache cache.CacheRepository[domains.SkinType]
 }
 
-func NewSkinTypeRepository(db *pgxpool.Pool, redisClient *redis.Client) SkinTypeRepository {
+func NewSkinTypeRepository(db *pgxpool.Pool, cacheClient *cache.Cache) SkinTypeRepository {
 	return &skinTypeRepository{
 		db:    db,
-		cache: cache.NewCacheRepository[domains.SkinType](redisClient, "skintype"),
+		cache: cache.NewCacheRepository[domains.SkinType](cacheClient, "skintype"),
 	}
 }
 
@@ -71,7 +71,7 @@ func (r *skinTypeRepository) GetByID(ctx context.Context, id int) (*domains.Skin
 		logrus.Debugf("Cache hit for skin type (ID: %d)", id)
 		return skinType, nil
 	}
-	if !errors.Is(err, redis.Nil) {
+	if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
 		logrus.Errorf("Cache lookup failed for skin type (ID: %d): %v", id, err)
 	}
 
@@ -176,7 +176,7 @@ func (r *skinTypeRepository) GetAll(ctx context.Context) ([]*domains.SkinType, e
 		logrus.Debug("Cache hit for all skin types")
 		return skinTypes, nil
 	}
-	if !errors.Is(err, redis.Nil) {
+	if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
 		logrus.Errorf("Cache lookup failed for all skin types: %v", err)
 	}
 
@@ -0,0 +1,294 @@
+package tax
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type TaxHandler struct {
+	service TaxService
+}
+
+func NewTaxHandler(service TaxService) *TaxHandler {
+	return &TaxHandler{service: service}
+}
+
+func (h *TaxHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/tax-classes", h.CreateTaxClass)
+	router.GET("/tax-classes/:id", h.GetTaxClassByID)
+	router.PUT("/tax-classes/:id", h.UpdateTaxClass)
+	router.DELETE("/tax-classes/:id", h.DeleteTaxClass)
+	router.GET("/tax-classes", h.GetAllTaxClasses)
+	router.POST("/tax-rates", h.CreateTaxRate)
+	router.GET("/tax-rates", h.GetTaxRates)
+	router.PUT("/tax-rates/:id", h.UpdateTaxRate)
+	router.DELETE("/tax-rates/:id", h.DeleteTaxRate)
+}
+
+// @Summary Create a new tax class
+// @Description Create a tax class; marking it as default takes the flag from the previous default class
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param class body domains.TaxClassRequest true "Tax class object"
+// @Success 201 {object} domains.TaxClass
+// @Failure 400 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /tax-classes [post]
+func (h *TaxHandler) CreateTaxClass(c *gin.Context) {
+	var req domains.TaxClassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdClass, err := h.service.CreateTaxClass(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(taxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdClass)
+}
+
+// @Summary Get tax class by ID
+// @Description Get a tax class by its ID
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param id path int true "Tax class ID"
+// @Success 200 {object} domains.TaxClass
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /tax-classes/{id} [get]
+func (h *TaxHandler) GetTaxClassByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tax class id"})
+		return
+	}
+
+	class, err := h.service.GetTaxClassByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(taxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, class)
+}
+
+// @Summary Update tax class
+// @Description Replace an existing tax class
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param id path int true "Tax class ID"
+// @Param class body domains.TaxClassRequest true "Tax class object"
+// @Success 200 {object} domains.TaxClass
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Router /tax-classes/{id} [put]
+func (h *TaxHandler) UpdateTaxClass(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tax class id"})
+		return
+	}
+
+	var req domains.TaxClassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedClass, err := h.service.UpdateTaxClass(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(taxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedClass)
+}
+
+// @Summary Delete tax class
+// @Description Delete a tax class and its rates; products and categories using it fall back to the default class
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param id path int true "Tax class ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /tax-classes/{id} [delete]
+func (h *TaxHandler) DeleteTaxClass(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tax class id"})
+		return
+	}
+
+	if err := h.service.DeleteTaxClass(c.Request.Context(), id); err != nil {
+		c.JSON(taxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get all tax classes
+// @Description Get every tax class
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Success 200 {array} domains.TaxClass
+// @Failure 500 {object} domains.Error
+// @Router /tax-classes [get]
+func (h *TaxHandler) GetAllTaxClasses(c *gin.Context) {
+	classes, err := h.service.GetAllTaxClasses(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, classes)
+}
+
+// @Summary Create a new tax rate
+// @Description Set the rate of a tax class for a country, or for one of its regions
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param rate body domains.TaxRateRequest true "Tax rate object"
+// @Success 201 {object} domains.TaxRate
+// @Failure 400 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /tax-rates [post]
+func (h *TaxHandler) CreateTaxRate(c *gin.Context) {
+	var req domains.TaxRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdRate, err := h.service.CreateTaxRate(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(taxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdRate)
+}
+
+// @Summary Get tax rates
+// @Description Get tax rates, optionally only those of a tax class or a country
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param tax_class_id query int false "Tax class ID"
+// @Param country query string false "ISO 3166-1 alpha-2 country code"
+// @Success 200 {array} domains.TaxRate
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /tax-rates [get]
+func (h *TaxHandler) GetTaxRates(c *gin.Context) {
+	filter := domains.TaxRateFilter{Country: c.Query("country")}
+	if classID := c.Query("tax_class_id"); classID != "" {
+		id, err := strconv.Atoi(classID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tax class id"})
+			return
+		}
+		filter.TaxClassID = id
+	}
+
+	rates, err := h.service.GetTaxRates(c.Request.Context(), &filter)
+	if err != nil {
+		c.JSON(taxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rates)
+}
+
+// @Summary Update tax rate
+// @Description Replace an existing tax rate
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param id path int true "Tax rate ID"
+// @Param rate body domains.TaxRateRequest true "Tax rate object"
+// @Success 200 {object} domains.TaxRate
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Router /tax-rates/{id} [put]
+func (h *TaxHandler) UpdateTaxRate(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tax rate id"})
+		return
+	}
+
+	var req domains.TaxRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedRate, err := h.service.UpdateTaxRate(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(taxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedRate)
+}
+
+// @Summary Delete tax rate
+// @Description Delete a tax rate by its ID
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Param id path int true "Tax rate ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /tax-rates/{id} [delete]
+func (h *TaxHandler) DeleteTaxRate(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tax rate id"})
+		return
+	}
+
+	if err := h.service.DeleteTaxRate(c.Request.Context(), id); err != nil {
+		c.JSON(taxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func taxErrorStatus(err error) int {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, domains.ErrInvalidTax):
+		return http.StatusBadRequest
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && (pgErr.Code == "23503" || pgErr.Code == "23505"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,371 @@
+package tax
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/domains"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type TaxRepository interface {
+	CreateClass(ctx context.Context, req *domains.TaxClassRequest) (*domains.TaxClass, error)
+	GetClassByID(ctx context.Context, id int) (*domains.TaxClass, error)
+	UpdateClass(ctx context.Context, id int, req *domains.TaxClassRequest) (*domains.TaxClass, error)
+	DeleteClass(ctx context.Context, id int) error
+	GetAllClasses(ctx context.Context) ([]*domains.TaxClass, error)
+	CreateRate(ctx context.Context, req *domains.TaxRateRequest) (*domains.TaxRate, error)
+	UpdateRate(ctx context.Context, id int, req *domains.TaxRateRequest) (*domains.TaxRate, error)
+	DeleteRate(ctx context.Context, id int) error
+	GetRates(ctx context.Context, filter *domains.TaxRateFilter) ([]*domains.TaxRate, error)
+	GetRatesFor(ctx context.Context, address *domains.TaxAddress) ([]*domains.TaxRate, error)
+	GetProductClasses(ctx context.Context, productIDs []int) (map[int]*int, error)
+}
+
+type taxRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTaxRepository(db *pgxpool.Pool) TaxRepository {
+	return &taxRepository{db: db}
+}
+
+const taxClassColumns = `
+        id, name, COALESCE(description, ''), is_default, created_at, updated_at`
+
+func scanTaxClass(row pgx.Row) (*domains.TaxClass, error) {
+	class := &domains.TaxClass{}
+	err := row.Scan(
+		&class.ID,
+		&class.Name,
+		&class.Description,
+		&class.IsDefault,
+		&class.CreatedAt,
+		&class.UpdatedAt,
+	)
+	return class, err
+}
+
+const taxRateColumns = `
+        id, tax_class_id, country, region, rate, COALESCE(name, ''), created_at, updated_at`
+
+func scanTaxRate(row pgx.Row) (*domains.TaxRate, error) {
+	rate := &domains.TaxRate{}
+	err := row.Scan(
+		&rate.ID,
+		&rate.TaxClassID,
+		&rate.Country,
+		&rate.Region,
+		&rate.Rate,
+		&rate.Name,
+		&rate.CreatedAt,
+		&rate.UpdatedAt,
+	)
+	return rate, err
+}
+
+// CreateClass inserts the class. A new default class replaces the previous
+// one in the same transaction.
+func (r *taxRepository) CreateClass(ctx context.Context, req *domains.TaxClassRequest) (*domains.TaxClass, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	if req.IsDefault {
+		if _, err = tx.Exec(ctx, `UPDATE tax_classes SET is_default = FALSE WHERE is_default`); err != nil {
+			logrus.Errorf("Failed to clear default tax class: %v", err)
+			return nil, err
+		}
+	}
+
+	const insertQuery = `
+        INSERT INTO tax_classes (name, description, is_default)
+        VALUES ($1, NULLIF($2, ''), $3)
+        RETURNING` + taxClassColumns
+
+	class, err := scanTaxClass(tx.QueryRow(ctx, insertQuery, req.Name, req.Description, req.IsDefault))
+	if err != nil {
+		logrus.WithError(err).WithField("tax_class", req).Error("Failed to insert tax class")
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+
+	logrus.Debugf("Tax class created successfully (ID: %d)", class.ID)
+	return class, nil
+}
+
+func (r *taxRepository) GetClassByID(ctx context.Context, id int) (*domains.TaxClass, error) {
+	const getQuery = `SELECT` + taxClassColumns + ` FROM tax_classes WHERE id = $1`
+
+	class, err := scanTaxClass(r.db.QueryRow(ctx, getQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Tax class not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get tax class (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Tax class retrieved successfully (ID: %d)", class.ID)
+	return class, nil
+}
+
+func (r *taxRepository) UpdateClass(ctx context.Context, id int, req *domains.TaxClassRequest) (*domains.TaxClass, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to begin transaction")
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	if req.IsDefault {
+		if _, err = tx.Exec(ctx, `UPDATE tax_classes SET is_default = FALSE WHERE is_default AND id <> $1`, id); err != nil {
+			logrus.Errorf("Failed to clear default tax class: %v", err)
+			return nil, err
+		}
+	}
+
+	const updateQuery = `
+        UPDATE tax_classes
+        SET name = $1, description = NULLIF($2, ''), is_default = $3, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $4
+        RETURNING` + taxClassColumns
+
+	class, err := scanTaxClass(tx.QueryRow(ctx, updateQuery, req.Name, req.Description, req.IsDefault, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to update non-existent tax class (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to update tax class (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logrus.WithError(err).Error("Failed to commit transaction")
+		return nil, err
+	}
+
+	logrus.Debugf("Tax class updated successfully (ID: %d)", class.ID)
+	return class, nil
+}
+
+// DeleteClass removes the class and its rates. Products and categories that
+// used it fall back to the next class in line.
+func (r *taxRepository) DeleteClass(ctx context.Context, id int) error {
+	const deleteQuery = `DELETE FROM tax_classes WHERE id = $1 RETURNING id`
+
+	var deletedID int
+	err := r.db.QueryRow(ctx, deleteQuery, id).Scan(&deletedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to delete non-existent tax class (ID: %d)", id)
+			return sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to delete tax class (ID: %d): %v", id, err)
+		return err
+	}
+
+	logrus.Debugf("Tax class deleted successfully (ID: %d)", deletedID)
+	return nil
+}
+
+func (r *taxRepository) GetAllClasses(ctx context.Context) ([]*domains.TaxClass, error) {
+	const listQuery = `SELECT` + taxClassColumns + ` FROM tax_classes ORDER BY id`
+
+	rows, err := r.db.Query(ctx, listQuery)
+	if err != nil {
+		logrus.Errorf("Failed to query tax classes: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	classes := []*domains.TaxClass{}
+	for rows.Next() {
+		class, err := scanTaxClass(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan tax class row: %v", err)
+			return nil, err
+		}
+		classes = append(classes, class)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating tax class rows: %v", err)
+		return nil, err
+	}
+
+	logrus.Debugf("Tax classes retrieved successfully (Count: %d)", len(classes))
+	return classes, nil
+}
+
+func (r *taxRepository) CreateRate(ctx context.Context, req *domains.TaxRateRequest) (*domains.TaxRate, error) {
+	const insertQuery = `
+        INSERT INTO tax_rates (tax_class_id, country, region, rate, name)
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+        RETURNING` + taxRateColumns
+
+	rate, err := scanTaxRate(r.db.QueryRow(ctx, insertQuery, req.TaxClassID, req.Country, req.Region, req.Rate, req.Name))
+	if err != nil {
+		logrus.WithError(err).WithField("tax_rate", req).Error("Failed to insert tax rate")
+		return nil, err
+	}
+
+	logrus.Debugf("Tax rate created successfully (ID: %d)", rate.ID)
+	return rate, nil
+}
+
+func (r *taxRepository) UpdateRate(ctx context.Context, id int, req *domains.TaxRateRequest) (*domains.TaxRate, error) {
+	const updateQuery = `
+        UPDATE tax_rates
+        SET tax_class_id = $1, country = $2, region = $3, rate = $4, name = NULLIF($5, ''),
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $6
+        RETURNING` + taxRateColumns
+
+	rate, err := scanTaxRate(r.db.QueryRow(ctx, updateQuery, req.TaxClassID, req.Country, req.Region, req.Rate, req.Name, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to update non-existent tax rate (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to update tax rate (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Tax rate updated successfully (ID: %d)", rate.ID)
+	return rate, nil
+}
+
+func (r *taxRepository) DeleteRate(ctx context.Context, id int) error {
+	const deleteQuery = `DELETE FROM tax_rates WHERE id = $1 RETURNING id`
+
+	var deletedID int
+	err := r.db.QueryRow(ctx, deleteQuery, id).Scan(&deletedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to delete non-existent tax rate (ID: %d)", id)
+			return sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to delete tax rate (ID: %d): %v", id, err)
+		return err
+	}
+
+	logrus.Debugf("Tax rate deleted successfully (ID: %d)", deletedID)
+	return nil
+}
+
+func (r *taxRepository) GetRates(ctx context.Context, filter *domains.TaxRateFilter) ([]*domains.TaxRate, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if filter.TaxClassID > 0 {
+		args = append(args, filter.TaxClassID)
+		conditions = append(conditions, fmt.Sprintf("tax_class_id = $%d", len(args)))
+	}
+	if filter.Country != "" {
+		args = append(args, filter.Country)
+		conditions = append(conditions, fmt.Sprintf("country = $%d", len(args)))
+	}
+
+	query := `SELECT` + taxRateColumns + ` FROM tax_rates`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY tax_class_id, country, region"
+
+	return r.queryRates(ctx, query, args...)
+}
+
+// GetRatesFor returns the country-wide rates of the address's country and
+// the rates of its region.
+func (r *taxRepository) GetRatesFor(ctx context.Context, address *domains.TaxAddress) ([]*domains.TaxRate, error) {
+	const ratesQuery = `
+        SELECT` + taxRateColumns + `
+        FROM tax_rates
+        WHERE country = $1 AND region IN ('', $2)
+        ORDER BY tax_class_id, region`
+	return r.queryRates(ctx, ratesQuery, address.Country, address.Region)
+}
+
+func (r *taxRepository) queryRates(ctx context.Context, query string, args ...interface{}) ([]*domains.TaxRate, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("Failed to query tax rates: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := []*domains.TaxRate{}
+	for rows.Next() {
+		rate, err := scanTaxRate(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan tax rate row: %v", err)
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating tax rate rows: %v", err)
+		return nil, err
+	}
+
+	logrus.Debugf("Tax rates retrieved successfully (Count: %d)", len(rates))
+	return rates, nil
+}
+
+// GetProductClasses resolves the tax class of each product: its own, then
+// its category's, then the default class. Products without any class map
+// to nil.
+func (r *taxRepository) GetProductClasses(ctx context.Context, productIDs []int) (map[int]*int, error) {
+	const classesQuery = `
+        SELECT p.id, COALESCE(p.tax_class_id, c.tax_class_id, (SELECT id FROM tax_classes WHERE is_default))
+        FROM products p
+        LEFT JOIN categories c ON c.id = p.category_id
+        WHERE p.id = ANY($1)`
+
+	rows, err := r.db.Query(ctx, classesQuery, productIDs)
+	if err != nil {
+		logrus.Errorf("Failed to query product tax classes: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	classes := make(map[int]*int, len(productIDs))
+	for rows.Next() {
+		var productID int
+		var classID *int
+		if err := rows.Scan(&productID, &classID); err != nil {
+			logrus.Errorf("Failed to scan product tax class: %v", err)
+			return nil, err
+		}
+		classes[productID] = classID
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating product tax class rows: %v", err)
+		return nil, err
+	}
+
+	return classes, nil
+}
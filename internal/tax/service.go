@@ -0,0 +1,180 @@
+package tax
+
+import (
+	"context"
+	"e-commerce/internal/config"
+	"e-commerce/internal/domains"
+
+	"github.com/sirupsen/logrus"
+)
+
+const defaultCountry = "RU"
+
+type TaxService interface {
+	CreateTaxClass(ctx context.Context, req *domains.TaxClassRequest) (*domains.TaxClass, error)
+	GetTaxClassByID(ctx context.Context, id int) (*domains.TaxClass, error)
+	UpdateTaxClass(ctx context.Context, id int, req *domains.TaxClassRequest) (*domains.TaxClass, error)
+	DeleteTaxClass(ctx context.Context, id int) error
+	GetAllTaxClasses(ctx context.Context) ([]*domains.TaxClass, error)
+	CreateTaxRate(ctx context.Context, req *domains.TaxRateRequest) (*domains.TaxRate, error)
+	UpdateTaxRate(ctx context.Context, id int, req *domains.TaxRateRequest) (*domains.TaxRate, error)
+	DeleteTaxRate(ctx context.Context, id int) error
+	GetTaxRates(ctx context.Context, filter *domains.TaxRateFilter) ([]*domains.TaxRate, error)
+	Calculate(ctx context.Context, lines []domains.TaxableLine, address *domains.TaxAddress) (*domains.TaxResult, error)
+}
+
+type taxService struct {
+	repo    TaxRepository
+	mode    domains.TaxMode
+	address domains.TaxAddress
+}
+
+func NewTaxService(repo TaxRepository, cfg *config.TaxConfig) TaxService {
+	s := &taxService{
+		repo:    repo,
+		mode:    domains.TaxMode(cfg.Mode),
+		address: domains.TaxAddress{Country: defaultCountry, Region: domains.NormalizeRegion(cfg.Region)},
+	}
+	if !s.mode.Valid() {
+		if s.mode != "" {
+			logrus.Warnf("Unknown tax mode %q, using %s", s.mode, domains.TaxInclusive)
+		}
+		s.mode = domains.TaxInclusive
+	}
+	if country, err := domains.NormalizeCountry(cfg.Country); err == nil {
+		s.address.Country = country
+	} else if cfg.Country != "" {
+		logrus.Warnf("Invalid tax country %q, using %s", cfg.Country, defaultCountry)
+	}
+	return s
+}
+
+func (s *taxService) CreateTaxClass(ctx context.Context, req *domains.TaxClassRequest) (*domains.TaxClass, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateClass(ctx, req)
+}
+
+func (s *taxService) GetTaxClassByID(ctx context.Context, id int) (*domains.TaxClass, error) {
+	return s.repo.GetClassByID(ctx, id)
+}
+
+func (s *taxService) UpdateTaxClass(ctx context.Context, id int, req *domains.TaxClassRequest) (*domains.TaxClass, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateClass(ctx, id, req)
+}
+
+func (s *taxService) DeleteTaxClass(ctx context.Context, id int) error {
+	return s.repo.DeleteClass(ctx, id)
+}
+
+func (s *taxService) GetAllTaxClasses(ctx context.Context) ([]*domains.TaxClass, error) {
+	return s.repo.GetAllClasses(ctx)
+}
+
+func (s *taxService) CreateTaxRate(ctx context.Context, req *domains.TaxRateRequest) (*domains.TaxRate, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateRate(ctx, req)
+}
+
+func (s *taxService) UpdateTaxRate(ctx context.Context, id int, req *domains.TaxRateRequest) (*domains.TaxRate, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateRate(ctx, id, req)
+}
+
+func (s *taxService) DeleteTaxRate(ctx context.Context, id int) error {
+	return s.repo.DeleteRate(ctx, id)
+}
+
+func (s *taxService) GetTaxRates(ctx context.Context, filter *domains.TaxRateFilter) ([]*domains.TaxRate, error) {
+	if filter.Country != "" {
+		country, err := domains.NormalizeCountry(filter.Country)
+		if err != nil {
+			return nil, err
+		}
+		filter.Country = country
+	}
+	return s.repo.GetRates(ctx, filter)
+}
+
+// Calculate taxes each line at the rate of its product's tax class for the
+// address, or the configured default address when address is nil or has no
+// country. Tax is rounded per line.
+func (s *taxService) Calculate(ctx context.Context, lines []domains.TaxableLine, address *domains.TaxAddress) (*domains.TaxResult, error) {
+	dest := s.address
+	if address != nil && address.Country != "" {
+		country, err := domains.NormalizeCountry(address.Country)
+		if err != nil {
+			return nil, err
+		}
+		dest = domains.TaxAddress{Country: country, Region: domains.NormalizeRegion(address.Region)}
+	}
+
+	result := &domains.TaxResult{
+		Mode:    s.mode,
+		Country: dest.Country,
+		Region:  dest.Region,
+		Lines:   make([]domains.TaxLine, 0, len(lines)),
+	}
+	if len(lines) == 0 {
+		return result, nil
+	}
+
+	productIDs := make([]int, 0, len(lines))
+	for _, line := range lines {
+		productIDs = append(productIDs, line.ProductID)
+	}
+	classes, err := s.repo.GetProductClasses(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := s.repo.GetRatesFor(ctx, &dest)
+	if err != nil {
+		return nil, err
+	}
+	// Rates come country-wide first, so a regional rate overwrites them.
+	classRates := make(map[int]domains.TaxPercent, len(rates))
+	for _, rate := range rates {
+		classRates[rate.TaxClassID] = rate.Rate
+	}
+
+	for _, line := range lines {
+		taxLine := domains.TaxLine{
+			ItemID:     line.ItemID,
+			ProductID:  line.ProductID,
+			TaxClassID: classes[line.ProductID],
+			Taxable:    line.Amount,
+		}
+		if taxLine.TaxClassID != nil {
+			taxLine.Rate = classRates[*taxLine.TaxClassID]
+		}
+		taxLine.Tax = s.tax(line.Amount, taxLine.Rate)
+
+		result.Lines = append(result.Lines, taxLine)
+		result.Tax += taxLine.Tax
+		result.Total += line.Amount
+	}
+	if s.mode == domains.TaxExclusive {
+		result.Total += result.Tax
+	}
+	return result, nil
+}
+
+// tax returns the tax on amount at rate, rounded half up to the minor unit.
+// In inclusive mode amount already contains the tax.
+func (s *taxService) tax(amount domains.Money, rate domains.TaxPercent) domains.Money {
+	if rate == 0 {
+		return 0
+	}
+	if s.mode == domains.TaxInclusive {
+		return amount.MulDiv(int64(rate), int64(domains.MaxTaxRate+rate))
+	}
+	return amount.MulDiv(int64(rate), int64(domains.MaxTaxRate))
+}
@@ -0,0 +1,34 @@
+package tax
+
+import (
+	"e-commerce/internal/domains"
+	"testing"
+)
+
+func TestTax(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   domains.TaxMode
+		amount domains.Money
+		rate   domains.TaxPercent
+		want   domains.Money
+	}{
+		{name: "exclusive whole rate", mode: domains.TaxExclusive, amount: 10000, rate: 200000, want: 2000},
+		{name: "exclusive fractional rate", mode: domains.TaxExclusive, amount: 10000, rate: 88750, want: 888},
+		{name: "exclusive smallest rate below half a unit", mode: domains.TaxExclusive, amount: 10000, rate: 1, want: 0},
+		{name: "exclusive largest amount", mode: domains.TaxExclusive, amount: domains.MaxMoney, rate: 200000, want: 2_000_000_000_00},
+		{name: "inclusive whole rate", mode: domains.TaxInclusive, amount: 12000, rate: 200000, want: 2000},
+		{name: "inclusive fractional rate", mode: domains.TaxInclusive, amount: 10888, rate: 88750, want: 888},
+		{name: "inclusive largest amount rounds half up", mode: domains.TaxInclusive, amount: domains.MaxMoney, rate: 200000, want: 1_666_666_666_67},
+		{name: "zero rate", mode: domains.TaxInclusive, amount: 10000, rate: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &taxService{mode: tt.mode}
+			if got := s.tax(tt.amount, tt.rate); got != tt.want {
+				t.Errorf("tax(%s, %s) in %s mode = %s, want %s", tt.amount, tt.rate, tt.mode, got, tt.want)
+			}
+		})
+	}
+}
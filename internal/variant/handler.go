@@ -0,0 +1,206 @@
+package variant
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type VariantHandler struct {
+	service VariantService
+}
+
+func NewVariantHandler(service VariantService) *VariantHandler {
+	return &VariantHandler{service: service}
+}
+
+func (h *VariantHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/products/:id/variants", h.CreateVariant)
+	router.GET("/products/:id/variants", h.GetProductVariants)
+	router.GET("/products/:id/variants/:variantID", h.GetVariantByID)
+	router.PUT("/products/:id/variants/:variantID", h.UpdateVariant)
+	router.DELETE("/products/:id/variants/:variantID", h.DeleteVariant)
+}
+
+// @Summary Create a product variant
+// @Description Create a variant (SKU) of a product, e.g. a volume or shade
+// @Tags variants
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param variant body domains.ProductVariantRequest true "Variant object"
+// @Success 201 {object} domains.ProductVariant
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /products/{id}/variants [post]
+func (h *VariantHandler) CreateVariant(c *gin.Context) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	var req domains.ProductVariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	variant, err := h.service.CreateVariant(c.Request.Context(), productID, &req)
+	if err != nil {
+		c.JSON(variantErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, variant)
+}
+
+// @Summary Get product variants
+// @Description Get all variants of a product ordered by price
+// @Tags variants
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {array} domains.ProductVariant
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /products/{id}/variants [get]
+func (h *VariantHandler) GetProductVariants(c *gin.Context) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	variants, err := h.service.GetProductVariants(c.Request.Context(), productID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, variants)
+}
+
+// @Summary Get product variant by ID
+// @Description Get a single variant of a product
+// @Tags variants
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param variantID path int true "Variant ID"
+// @Success 200 {object} domains.ProductVariant
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /products/{id}/variants/{variantID} [get]
+func (h *VariantHandler) GetVariantByID(c *gin.Context) {
+	productID, variantID, ok := parseVariantPath(c)
+	if !ok {
+		return
+	}
+
+	variant, err := h.service.GetVariantByID(c.Request.Context(), productID, variantID)
+	if err != nil {
+		c.JSON(variantErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, variant)
+}
+
+// @Summary Update product variant
+// @Description Update an existing variant of a product
+// @Tags variants
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param variantID path int true "Variant ID"
+// @Param variant body domains.ProductVariantRequest true "Variant object"
+// @Success 200 {object} domains.ProductVariant
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /products/{id}/variants/{variantID} [put]
+func (h *VariantHandler) UpdateVariant(c *gin.Context) {
+	productID, variantID, ok := parseVariantPath(c)
+	if !ok {
+		return
+	}
+
+	var req domains.ProductVariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	variant, err := h.service.UpdateVariant(c.Request.Context(), productID, variantID, &req)
+	if err != nil {
+		c.JSON(variantErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, variant)
+}
+
+// @Summary Delete product variant
+// @Description Delete a variant of a product
+// @Tags variants
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param variantID path int true "Variant ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /products/{id}/variants/{variantID} [delete]
+func (h *VariantHandler) DeleteVariant(c *gin.Context) {
+	productID, variantID, ok := parseVariantPath(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteVariant(c.Request.Context(), productID, variantID); err != nil {
+		c.JSON(variantErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func parseVariantPath(c *gin.Context) (int, int, bool) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return 0, 0, false
+	}
+	variantID, err := strconv.Atoi(c.Param("variantID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid variant id"})
+		return 0, 0, false
+	}
+	return productID, variantID, true
+}
+
+func variantErrorStatus(err error) int {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, domains.ErrInvalidVariant):
+		return http.StatusBadRequest
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23503":
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,201 @@
+package variant
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/cache"
+	"e-commerce/internal/domains"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type VariantRepository interface {
+	Create(ctx context.Context, productID int, req *domains.ProductVariantRequest) (*domains.ProductVariant, error)
+	GetByID(ctx context.Context, productID, id int) (*domains.ProductVariant, error)
+	Update(ctx context.Context, productID, id int, req *domains.ProductVariantRequest) (*domains.ProductVariant, error)
+	Delete(ctx context.Context, productID, id int) error
+	GetByProductID(ctx context.Context, productID int) ([]*domains.ProductVariant, error)
+}
+
+type variantRepository struct {
+	db           *pgxpool.Pool
+	productCache cache.CacheRepository[domains.ProductResponse]
+}
+
+func NewVariantRepository(db *pgxpool.Pool, cacheClient *cache.Cache) VariantRepository {
+	return &variantRepository{
+		db:           db,
+		productCache: cache.NewCacheRepository[domains.ProductResponse](cacheClient, "product"),
+	}
+}
+
+const variantColumns = `
+        id, product_id, sku, COALESCE(barcode, ''), volume, COALESCE(volume_unit, ''),
+        COALESCE(shade, ''), price, weight_grams,
+        COALESCE((SELECT SUM(sl.quantity) FROM stock_levels sl JOIN warehouses w ON w.id = sl.warehouse_id
+                  WHERE sl.variant_id = product_variants.id AND w.is_active), 0),
+        created_at, updated_at`
+
+func scanVariant(row pgx.Row) (*domains.ProductVariant, error) {
+	variant := &domains.ProductVariant{}
+	err := row.Scan(
+		&variant.ID,
+		&variant.ProductID,
+		&variant.SKU,
+		&variant.Barcode,
+		&variant.Volume,
+		&variant.VolumeUnit,
+		&variant.Shade,
+		&variant.Price,
+		&variant.WeightGrams,
+		&variant.StockQuantity,
+		&variant.CreatedAt,
+		&variant.UpdatedAt,
+	)
+	variant.InStock = variant.StockQuantity > 0
+	return variant, err
+}
+
+func (r *variantRepository) Create(ctx context.Context, productID int, req *domains.ProductVariantRequest) (*domains.ProductVariant, error) {
+	const insertQuery = `
+        INSERT INTO product_variants (product_id, sku, barcode, volume, volume_unit, shade, price, weight_grams)
+        VALUES ($1, $2, NULLIF($3, ''), $4, NULLIF($5, ''), NULLIF($6, ''), $7, $8)
+        RETURNING` + variantColumns
+
+	variant, err := scanVariant(r.db.QueryRow(ctx, insertQuery,
+		productID,
+		req.SKU,
+		req.Barcode,
+		req.Volume,
+		req.VolumeUnit,
+		req.Shade,
+		req.Price,
+		req.WeightGrams,
+	))
+	if err != nil {
+		logrus.WithError(err).WithField("req", req).Errorf("Failed to insert variant (product_id: %d)", productID)
+		return nil, err
+	}
+
+	r.invalidateProduct(ctx, productID)
+
+	logrus.Debugf("Variant created successfully (ID: %d, product_id: %d)", variant.ID, productID)
+	return variant, nil
+}
+
+func (r *variantRepository) GetByID(ctx context.Context, productID, id int) (*domains.ProductVariant, error) {
+	const getQuery = `SELECT` + variantColumns + ` FROM product_variants WHERE id = $1 AND product_id = $2`
+
+	variant, err := scanVariant(r.db.QueryRow(ctx, getQuery, id, productID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Variant not found (ID: %d, product_id: %d)", id, productID)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get variant (ID: %d, product_id: %d): %v", id, productID, err)
+		return nil, err
+	}
+
+	logrus.Debugf("Variant retrieved successfully (ID: %d)", variant.ID)
+	return variant, nil
+}
+
+func (r *variantRepository) Update(ctx context.Context, productID, id int, req *domains.ProductVariantRequest) (*domains.ProductVariant, error) {
+	const updateQuery = `
+        UPDATE product_variants
+        SET sku = $1, barcode = NULLIF($2, ''), volume = $3, volume_unit = NULLIF($4, ''),
+            shade = NULLIF($5, ''), price = $6, weight_grams = $7, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $8 AND product_id = $9
+        RETURNING` + variantColumns
+
+	variant, err := scanVariant(r.db.QueryRow(ctx, updateQuery,
+		req.SKU,
+		req.Barcode,
+		req.Volume,
+		req.VolumeUnit,
+		req.Shade,
+		req.Price,
+		req.WeightGrams,
+		id,
+		productID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to update non-existent variant (ID: %d, product_id: %d)", id, productID)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to update variant (ID: %d, product_id: %d): %v", id, productID, err)
+		return nil, err
+	}
+
+	r.invalidateProduct(ctx, productID)
+
+	logrus.Debugf("Variant updated successfully (ID: %d)", variant.ID)
+	return variant, nil
+}
+
+func (r *variantRepository) Delete(ctx context.Context, productID, id int) error {
+	const deleteQuery = `DELETE FROM product_variants WHERE id = $1 AND product_id = $2 RETURNING id`
+
+	var deletedID int
+	err := r.db.QueryRow(ctx, deleteQuery, id, productID).Scan(&deletedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to delete non-existent variant (ID: %d, product_id: %d)", id, productID)
+			return sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to delete variant (ID: %d, product_id: %d): %v", id, productID, err)
+		return err
+	}
+
+	r.invalidateProduct(ctx, productID)
+
+	logrus.Debugf("Variant deleted successfully (ID: %d)", deletedID)
+	return nil
+}
+
+func (r *variantRepository) GetByProductID(ctx context.Context, productID int) ([]*domains.ProductVariant, error) {
+	const listQuery = `SELECT` + variantColumns + ` FROM product_variants WHERE product_id = $1 ORDER BY price, id`
+
+	rows, err := r.db.Query(ctx, listQuery, productID)
+	if err != nil {
+		logrus.Errorf("Failed to query variants (product_id: %d): %v", productID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var variants []*domains.ProductVariant
+	for rows.Next() {
+		variant, err := scanVariant(rows)
+		if err != nil {
+			logrus.Errorf("Failed to scan variant row: %v", err)
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating variant rows: %v", err)
+		return nil, err
+	}
+
+	logrus.Debugf("Variants retrieved successfully (product_id: %d, Count: %d)", productID, len(variants))
+	return variants, nil
+}
+
+// invalidateProduct drops the cached product so its embedded variants are
+// reloaded on the next read, along with cached filter results, which match
+// on variant prices.
+func (r *variantRepository) invalidateProduct(ctx context.Context, productID int) {
+	if err := r.productCache.Delete(ctx, productID); err != nil {
+		logrus.Warnf("Failed to remove product from cache after variant change (ID: %d): %v", productID, err)
+	}
+	if err := r.productCache.DeleteAll(ctx); err != nil {
+		logrus.Warnf("Failed to clear all products cache after variant change (ID: %d): %v", productID, err)
+	}
+	if err := r.productCache.DeleteByPrefix(ctx, domains.ProductFilterKeyPrefix); err != nil {
+		logrus.Warnf("Failed to clear product filter cache after variant change (ID: %d): %v", productID, err)
+	}
+}
@@ -0,0 +1,66 @@
+package variant
+
+import (
+	"context"
+	"e-commerce/internal/domains"
+)
+
+type VariantService interface {
+	CreateVariant(ctx context.Context, productID int, req *domains.ProductVariantRequest) (*domains.ProductVariant, error)
+	GetVariantByID(ctx context.Context, productID, id int) (*domains.ProductVariant, error)
+	UpdateVariant(ctx context.Context, productID, id int, req *domains.ProductVariantRequest) (*domains.ProductVariant, error)
+	DeleteVariant(ctx context.Context, productID, id int) error
+	GetProductVariants(ctx context.Context, productID int) ([]*domains.ProductVariant, error)
+}
+
+type variantService struct {
+	repo     VariantRepository
+	currency string
+}
+
+func NewVariantService(repo VariantRepository, currency string) VariantService {
+	return &variantService{repo: repo, currency: currency}
+}
+
+func (s *variantService) CreateVariant(ctx context.Context, productID int, req *domains.ProductVariantRequest) (*domains.ProductVariant, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.withCurrency(s.repo.Create(ctx, productID, req))
+}
+
+func (s *variantService) GetVariantByID(ctx context.Context, productID, id int) (*domains.ProductVariant, error) {
+	return s.withCurrency(s.repo.GetByID(ctx, productID, id))
+}
+
+func (s *variantService) UpdateVariant(ctx context.Context, productID, id int, req *domains.ProductVariantRequest) (*domains.ProductVariant, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.withCurrency(s.repo.Update(ctx, productID, id, req))
+}
+
+func (s *variantService) DeleteVariant(ctx context.Context, productID, id int) error {
+	return s.repo.Delete(ctx, productID, id)
+}
+
+func (s *variantService) GetProductVariants(ctx context.Context, productID int) ([]*domains.ProductVariant, error) {
+	variants, err := s.repo.GetByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	for _, variant := range variants {
+		variant.Currency = s.currency
+	}
+	return variants, nil
+}
+
+// withCurrency labels a variant price with the base currency, which variant
+// prices are stored in.
+func (s *variantService) withCurrency(variant *domains.ProductVariant, err error) (*domains.ProductVariant, error) {
+	if err != nil {
+		return nil, err
+	}
+	variant.Currency = s.currency
+	return variant, nil
+}
@@ -0,0 +1,175 @@
+package warehouse
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type WarehouseHandler struct {
+	service WarehouseService
+}
+
+func NewWarehouseHandler(service WarehouseService) *WarehouseHandler {
+	return &WarehouseHandler{service: service}
+}
+
+func (h *WarehouseHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/warehouses", h.CreateWarehouse)
+	router.GET("/warehouses/:id", h.GetWarehouseByID)
+	router.PUT("/warehouses/:id", h.UpdateWarehouse)
+	router.DELETE("/warehouses/:id", h.DeleteWarehouse)
+	router.GET("/warehouses", h.GetAllWarehouses)
+}
+
+// @Summary Create a new warehouse
+// @Description Create a new warehouse with the provided details
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Param warehouse body domains.WarehouseRequest true "Warehouse object"
+// @Success 201 {object} domains.Warehouse
+// @Failure 400 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /warehouses [post]
+func (h *WarehouseHandler) CreateWarehouse(c *gin.Context) {
+	var req domains.WarehouseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdWarehouse, err := h.service.CreateWarehouse(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(warehouseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdWarehouse)
+}
+
+// @Summary Get warehouse by ID
+// @Description Get a warehouse by its ID
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Success 200 {object} domains.Warehouse
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Router /warehouses/{id} [get]
+func (h *WarehouseHandler) GetWarehouseByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid warehouse id"})
+		return
+	}
+
+	warehouse, err := h.service.GetWarehouseByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, warehouse)
+}
+
+// @Summary Update warehouse
+// @Description Update an existing warehouse
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Param warehouse body domains.WarehouseRequest true "Warehouse object"
+// @Success 200 {object} domains.Warehouse
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Router /warehouses/{id} [put]
+func (h *WarehouseHandler) UpdateWarehouse(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid warehouse id"})
+		return
+	}
+
+	var req domains.WarehouseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedWarehouse, err := h.service.UpdateWarehouse(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(warehouseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedWarehouse)
+}
+
+// @Summary Delete warehouse
+// @Description Delete a warehouse by its ID. Warehouses that still hold stock cannot be deleted.
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 409 {object} domains.Error
+// @Router /warehouses/{id} [delete]
+func (h *WarehouseHandler) DeleteWarehouse(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid warehouse id"})
+		return
+	}
+
+	if err := h.service.DeleteWarehouse(c.Request.Context(), id); err != nil {
+		c.JSON(warehouseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get all warehouses
+// @Description Get a list of all warehouses
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Success 200 {array} domains.Warehouse
+// @Failure 500 {object} domains.Error
+// @Router /warehouses [get]
+func (h *WarehouseHandler) GetAllWarehouses(c *gin.Context) {
+	warehouses, err := h.service.GetAllWarehouses(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, warehouses)
+}
+
+func warehouseErrorStatus(err error) int {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && (pgErr.Code == "23503" || pgErr.Code == "23505"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,242 @@
+package warehouse
+
+import (
+	"context"
+	"database/sql"
+	"e-commerce/internal/cache"
+	"e-commerce/internal/domains"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+type WarehouseRepository interface {
+	Create(ctx context.Context, req *domains.WarehouseRequest) (*domains.Warehouse, error)
+	GetByID(ctx context.Context, id int) (*domains.Warehouse, error)
+	Update(ctx context.Context, id int, req *domains.WarehouseRequest) (*domains.Warehouse, error)
+	Delete(ctx context.Context, id int) error
+	GetAll(ctx context.Context) ([]*domains.Warehouse, error)
+}
+
+type warehouseRepository struct {
+	db           *pgxpool.Pool
+	cache        cache.CacheRepository[domains.Warehouse]
+	productCache cache.CacheRepository[domains.ProductResponse]
+}
+
+func NewWarehouseRepository(db *pgxpool.Pool, cacheClient *cache.Cache) WarehouseRepository {
+	return &warehouseRepository{
+		db:           db,
+		cache:        cache.NewCacheRepository[domains.Warehouse](cacheClient, "warehouse"),
+		productCache: cache.NewCacheRepository[domains.ProductResponse](cacheClient, "product"),
+	}
+}
+
+func (r *warehouseRepository) Create(ctx context.Context, req *domains.WarehouseRequest) (*domains.Warehouse, error) {
+	const insertQuery = `
+        INSERT INTO warehouses (code, name, address, priority, is_active)
+        VALUES ($1, $2, NULLIF($3, ''), $4, COALESCE($5, TRUE))
+        RETURNING id, code, name, COALESCE(address, ''), priority, is_active`
+
+	createdWarehouse := &domains.Warehouse{}
+	err := r.db.QueryRow(ctx, insertQuery, req.Code, req.Name, req.Address, req.Priority, req.IsActive).Scan(
+		&createdWarehouse.ID,
+		&createdWarehouse.Code,
+		&createdWarehouse.Name,
+		&createdWarehouse.Address,
+		&createdWarehouse.Priority,
+		&createdWarehouse.IsActive,
+	)
+	if err != nil {
+		logrus.WithError(err).WithField("warehouse", req).Error("Failed to insert warehouse")
+		return nil, err
+	}
+
+	if err := r.cache.DeleteAll(ctx); err != nil {
+		logrus.Warnf("Failed to clear warehouse cache after creation (ID: %d): %v", createdWarehouse.ID, err)
+	}
+	go func(w *domains.Warehouse) {
+		if err := r.cache.SetByID(context.Background(), w.ID, w); err != nil {
+			logrus.Warnf("Failed to cache created warehouse asynchronously (ID: %d): %v", w.ID, err)
+		} else {
+			logrus.Debugf("Successfully cached created warehouse asynchronously (ID: %d)", w.ID)
+		}
+	}(createdWarehouse)
+
+	logrus.Debugf("Warehouse created successfully (ID: %d)", createdWarehouse.ID)
+	return createdWarehouse, nil
+}
+
+func (r *warehouseRepository) GetByID(ctx context.Context, id int) (*domains.Warehouse, error) {
+	warehouse, err := r.cache.GetByID(ctx, id)
+	if err == nil {
+		logrus.Debugf("Cache hit for warehouse (ID: %d)", id)
+		return warehouse, nil
+	}
+	if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
+		logrus.Errorf("Cache lookup failed for warehouse (ID: %d): %v", id, err)
+	}
+
+	const getQuery = `SELECT id, code, name, COALESCE(address, ''), priority, is_active FROM warehouses WHERE id = $1`
+	warehouse = &domains.Warehouse{}
+	err = r.db.QueryRow(ctx, getQuery, id).Scan(
+		&warehouse.ID,
+		&warehouse.Code,
+		&warehouse.Name,
+		&warehouse.Address,
+		&warehouse.Priority,
+		&warehouse.IsActive,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Warehouse not found (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get warehouse (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	go func(w *domains.Warehouse) {
+		if err := r.cache.SetByID(context.Background(), w.ID, w); err != nil {
+			logrus.Warnf("Failed to cache warehouse asynchronously (ID: %d): %v", w.ID, err)
+		} else {
+			logrus.Debugf("Successfully cached warehouse asynchronously (ID: %d)", w.ID)
+		}
+	}(warehouse)
+
+	logrus.Debugf("Warehouse retrieved successfully (ID: %d)", warehouse.ID)
+	return warehouse, nil
+}
+
+func (r *warehouseRepository) Update(ctx context.Context, id int, req *domains.WarehouseRequest) (*domains.Warehouse, error) {
+	const updateQuery = `
+        UPDATE warehouses
+        SET code = $1, name = $2, address = NULLIF($3, ''), priority = $4, is_active = COALESCE($5, is_active)
+        WHERE id = $6
+        RETURNING id, code, name, COALESCE(address, ''), priority, is_active`
+
+	updatedWarehouse := &domains.Warehouse{}
+	err := r.db.QueryRow(ctx, updateQuery,
+		req.Code,
+		req.Name,
+		req.Address,
+		req.Priority,
+		req.IsActive,
+		id,
+	).Scan(
+		&updatedWarehouse.ID,
+		&updatedWarehouse.Code,
+		&updatedWarehouse.Name,
+		&updatedWarehouse.Address,
+		&updatedWarehouse.Priority,
+		&updatedWarehouse.IsActive,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to update non-existent warehouse (ID: %d)", id)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to update warehouse (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	if err := r.cache.DeleteAll(ctx); err != nil {
+		logrus.Warnf("Failed to clear warehouse cache after update (ID: %d): %v", id, err)
+	}
+	// Product stock only counts active warehouses.
+	if err := r.productCache.DeleteAll(ctx); err != nil {
+		logrus.Warnf("Failed to clear all products cache after warehouse update (ID: %d): %v", id, err)
+	}
+	go func(w *domains.Warehouse) {
+		if err := r.cache.SetByID(context.Background(), w.ID, w); err != nil {
+			logrus.Warnf("Failed to cache updated warehouse asynchronously (ID: %d): %v", w.ID, err)
+		} else {
+			logrus.Debugf("Successfully cached updated warehouse asynchronously (ID: %d)", w.ID)
+		}
+	}(updatedWarehouse)
+
+	logrus.Debugf("Warehouse updated successfully (ID: %d)", updatedWarehouse.ID)
+	return updatedWarehouse, nil
+}
+
+func (r *warehouseRepository) Delete(ctx context.Context, id int) error {
+	const deleteQuery = `DELETE FROM warehouses WHERE id = $1 RETURNING id`
+
+	var deletedID int
+	err := r.db.QueryRow(ctx, deleteQuery, id).Scan(&deletedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Attempted to delete non-existent warehouse (ID: %d)", id)
+			return sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to delete warehouse (ID: %d): %v", id, err)
+		return err
+	}
+
+	if err := r.cache.Delete(ctx, id); err != nil {
+		logrus.Warnf("Failed to remove warehouse from cache (ID: %d): %v", id, err)
+	}
+	if err := r.cache.DeleteAll(ctx); err != nil {
+		logrus.Warnf("Failed to clear all warehouses cache after deletion (ID: %d): %v", id, err)
+	}
+
+	logrus.Debugf("Warehouse deleted successfully (ID: %d)", deletedID)
+	return nil
+}
+
+func (r *warehouseRepository) GetAll(ctx context.Context) ([]*domains.Warehouse, error) {
+	warehouses, err := r.cache.GetAll(ctx)
+	if err == nil {
+		logrus.Debug("Cache hit for all warehouses")
+		return warehouses, nil
+	}
+	if !errors.Is(err, redis.Nil) && !errors.Is(err, cache.ErrUnavailable) {
+		logrus.Errorf("Cache lookup failed for all warehouses: %v", err)
+	}
+
+	const getAllQuery = `
+        SELECT id, code, name, COALESCE(address, ''), priority, is_active
+        FROM warehouses
+        ORDER BY priority, id`
+	rows, err := r.db.Query(ctx, getAllQuery)
+	if err != nil {
+		logrus.Errorf("Failed to get all warehouses: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var warehousesList []*domains.Warehouse
+	for rows.Next() {
+		warehouse := &domains.Warehouse{}
+		if err = rows.Scan(
+			&warehouse.ID,
+			&warehouse.Code,
+			&warehouse.Name,
+			&warehouse.Address,
+			&warehouse.Priority,
+			&warehouse.IsActive,
+		); err != nil {
+			logrus.Errorf("Failed to scan warehouse record: %v", err)
+			return nil, err
+		}
+		warehousesList = append(warehousesList, warehouse)
+	}
+	if rows.Err() != nil {
+		logrus.Errorf("Error occurred during iteration of rows: %v", rows.Err())
+		return nil, rows.Err()
+	}
+
+	go func(wl []*domains.Warehouse) {
+		if err := r.cache.SetAll(context.Background(), wl); err != nil {
+			logrus.Warnf("Failed to cache all warehouses asynchronously: %v", err)
+		} else {
+			logrus.Debugf("Successfully cached all warehouses asynchronously (Count: %d)", len(wl))
+		}
+	}(warehousesList)
+
+	logrus.Debugf("All warehouses retrieved successfully (Count: %d)", len(warehousesList))
+	return warehousesList, nil
+}
@@ -0,0 +1,42 @@
+package warehouse
+
+import (
+	"context"
+	"e-commerce/internal/domains"
+)
+
+type WarehouseService interface {
+	CreateWarehouse(ctx context.Context, req *domains.WarehouseRequest) (*domains.Warehouse, error)
+	GetWarehouseByID(ctx context.Context, id int) (*domains.Warehouse, error)
+	UpdateWarehouse(ctx context.Context, id int, req *domains.WarehouseRequest) (*domains.Warehouse, error)
+	DeleteWarehouse(ctx context.Context, id int) error
+	GetAllWarehouses(ctx context.Context) ([]*domains.Warehouse, error)
+}
+
+type warehouseService struct {
+	repo WarehouseRepository
+}
+
+func NewWarehouseService(repo WarehouseRepository) WarehouseService {
+	return &warehouseService{repo: repo}
+}
+
+func (s *warehouseService) CreateWarehouse(ctx context.Context, req *domains.WarehouseRequest) (*domains.Warehouse, error) {
+	return s.repo.Create(ctx, req)
+}
+
+func (s *warehouseService) GetWarehouseByID(ctx context.Context, id int) (*domains.Warehouse, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *warehouseService) UpdateWarehouse(ctx context.Context, id int, req *domains.WarehouseRequest) (*domains.Warehouse, error) {
+	return s.repo.Update(ctx, id, req)
+}
+
+func (s *warehouseService) DeleteWarehouse(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *warehouseService) GetAllWarehouses(ctx context.Context) ([]*domains.Warehouse, error) {
+	return s.repo.GetAll(ctx)
+}
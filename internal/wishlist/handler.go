@@ -0,0 +1,216 @@
+package wishlist
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"e-commerce/internal/domains"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type WishlistHandler struct {
+	service WishlistService
+}
+
+func NewWishlistHandler(service WishlistService) *WishlistHandler {
+	return &WishlistHandler{service: service}
+}
+
+func (h *WishlistHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/wishlists/users/:id", h.GetWishlist)
+	router.POST("/wishlists/users/:id/items", h.AddItem)
+	router.DELETE("/wishlists/users/:id/items/:productID", h.RemoveItem)
+	router.POST("/wishlists/users/:id/share", h.Share)
+	router.DELETE("/wishlists/users/:id/share", h.Unshare)
+	router.GET("/wishlists/shared/:token", h.GetSharedWishlist)
+}
+
+// @Summary Get customer wishlist
+// @Description Get the products a customer saved, with current prices and stock
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} domains.Wishlist
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /wishlists/users/{id} [get]
+func (h *WishlistHandler) GetWishlist(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	wishlist, err := h.service.GetWishlist(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(wishlistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wishlist)
+}
+
+// @Summary Add product to wishlist
+// @Description Save a product to a customer's wishlist. Saving a product twice has no effect.
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param item body domains.WishlistItemRequest true "Product to save"
+// @Success 200 {object} domains.Wishlist
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /wishlists/users/{id}/items [post]
+func (h *WishlistHandler) AddItem(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req domains.WishlistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	wishlist, err := h.service.AddItem(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.JSON(wishlistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wishlist)
+}
+
+// @Summary Remove product from wishlist
+// @Description Remove a saved product from a customer's wishlist
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param productID path int true "Product ID"
+// @Success 200 {object} domains.Wishlist
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /wishlists/users/{id}/items/{productID} [delete]
+func (h *WishlistHandler) RemoveItem(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	productID, err := strconv.Atoi(c.Param("productID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	wishlist, err := h.service.RemoveItem(c.Request.Context(), userID, productID)
+	if err != nil {
+		c.JSON(wishlistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wishlist)
+}
+
+// @Summary Share wishlist
+// @Description Create a public link token for a customer's wishlist. Sharing again replaces the token and revokes the old link.
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} domains.Wishlist
+// @Failure 400 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /wishlists/users/{id}/share [post]
+func (h *WishlistHandler) Share(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	wishlist, err := h.service.Share(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(wishlistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wishlist)
+}
+
+// @Summary Stop sharing wishlist
+// @Description Revoke the public link of a customer's wishlist
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} domains.Error
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /wishlists/users/{id}/share [delete]
+func (h *WishlistHandler) Unshare(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Unshare(c.Request.Context(), userID); err != nil {
+		c.JSON(wishlistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get shared wishlist
+// @Description Get a wishlist through its public link, with current prices and stock
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} domains.Wishlist
+// @Failure 404 {object} domains.Error
+// @Failure 500 {object} domains.Error
+// @Router /wishlists/shared/{token} [get]
+func (h *WishlistHandler) GetSharedWishlist(c *gin.Context) {
+	wishlist, err := h.service.GetSharedWishlist(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		c.JSON(wishlistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, wishlist)
+}
+
+func userIDParam(c *gin.Context) (int, bool) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return 0, false
+	}
+	return userID, true
+}
+
+// wishlistErrorStatus maps a foreign key violation to 404: the only
+// reference a request can break is the saved product.
+func wishlistErrorStatus(err error) int {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, domains.ErrInvalidWishlist):
+		return http.StatusBadRequest
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23503":
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,206 @@
+package wishlist
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"e-commerce/internal/domains"
+	"encoding/hex"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+type WishlistRepository interface {
+	GetByUser(ctx context.Context, userID int) (*domains.Wishlist, error)
+	GetByShareToken(ctx context.Context, token string) (*domains.Wishlist, error)
+	AddItem(ctx context.Context, userID, productID int) error
+	RemoveItem(ctx context.Context, userID, productID int) error
+	Share(ctx context.Context, userID int) error
+	Unshare(ctx context.Context, userID int) error
+}
+
+type wishlistRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWishlistRepository(db *pgxpool.Pool) WishlistRepository {
+	return &wishlistRepository{db: db}
+}
+
+// GetByUser returns the customer's wishlist, or an empty one when they have
+// not saved anything yet.
+func (r *wishlistRepository) GetByUser(ctx context.Context, userID int) (*domains.Wishlist, error) {
+	const getQuery = `SELECT id, COALESCE(share_token, ''), updated_at FROM wishlists WHERE user_id = $1`
+
+	wishlist := &domains.Wishlist{UserID: &userID, Items: []domains.WishlistItem{}}
+	var id int
+	err := r.db.QueryRow(ctx, getQuery, userID).Scan(&id, &wishlist.ShareToken, &wishlist.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return wishlist, nil
+		}
+		logrus.Errorf("Failed to get wishlist (user_id: %d): %v", userID, err)
+		return nil, err
+	}
+
+	if wishlist.Items, err = r.getItems(ctx, id); err != nil {
+		return nil, err
+	}
+	return wishlist, nil
+}
+
+func (r *wishlistRepository) GetByShareToken(ctx context.Context, token string) (*domains.Wishlist, error) {
+	const getQuery = `SELECT id, user_id, share_token, updated_at FROM wishlists WHERE share_token = $1`
+
+	wishlist := &domains.Wishlist{}
+	var id int
+	err := r.db.QueryRow(ctx, getQuery, token).Scan(&id, &wishlist.UserID, &wishlist.ShareToken, &wishlist.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Infof("Shared wishlist not found (token: %s)", token)
+			return nil, sql.ErrNoRows
+		}
+		logrus.Errorf("Failed to get shared wishlist (token: %s): %v", token, err)
+		return nil, err
+	}
+
+	if wishlist.Items, err = r.getItems(ctx, id); err != nil {
+		return nil, err
+	}
+	return wishlist, nil
+}
+
+// getItems lists the saved products with their current price, including a
+// running sale, and their stock in active warehouses.
+func (r *wishlistRepository) getItems(ctx context.Context, wishlistID int) ([]domains.WishlistItem, error) {
+	const itemsQuery = `
+        SELECT wi.product_id, p.name,
+               product_current_price(p.price, p.sale_price, p.sale_starts_at, p.sale_ends_at), p.price,
+               COALESCE((SELECT SUM(sl.quantity) FROM stock_levels sl JOIN warehouses w ON w.id = sl.warehouse_id WHERE sl.product_id = p.id AND w.is_active), 0),
+               wi.created_at
+        FROM wishlist_items wi
+        JOIN products p ON p.id = wi.product_id
+        WHERE wi.wishlist_id = $1
+        ORDER BY wi.created_at DESC, wi.product_id`
+
+	rows, err := r.db.Query(ctx, itemsQuery, wishlistID)
+	if err != nil {
+		logrus.Errorf("Failed to query wishlist items (wishlist_id: %d): %v", wishlistID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []domains.WishlistItem{}
+	for rows.Next() {
+		var item domains.WishlistItem
+		if err := rows.Scan(
+			&item.ProductID,
+			&item.Name,
+			&item.Price,
+			&item.OriginalPrice,
+			&item.StockQuantity,
+			&item.AddedAt,
+		); err != nil {
+			logrus.Errorf("Failed to scan wishlist item row: %v", err)
+			return nil, err
+		}
+		item.InStock = item.StockQuantity > 0
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		logrus.Errorf("Error iterating wishlist item rows: %v", err)
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// AddItem saves the product, creating the wishlist on first use. Saving a
+// product twice is a no-op.
+func (r *wishlistRepository) AddItem(ctx context.Context, userID, productID int) error {
+	const addQuery = `
+        WITH w AS (
+            INSERT INTO wishlists (user_id) VALUES ($1)
+            ON CONFLICT (user_id) DO UPDATE SET updated_at = CURRENT_TIMESTAMP
+            RETURNING id
+        )
+        INSERT INTO wishlist_items (wishlist_id, product_id)
+        SELECT id, $2 FROM w
+        ON CONFLICT DO NOTHING`
+
+	if _, err := r.db.Exec(ctx, addQuery, userID, productID); err != nil {
+		logrus.Errorf("Failed to add product to wishlist (user_id: %d, product_id: %d): %v", userID, productID, err)
+		return err
+	}
+
+	logrus.Debugf("Product added to wishlist successfully (user_id: %d, product_id: %d)", userID, productID)
+	return nil
+}
+
+func (r *wishlistRepository) RemoveItem(ctx context.Context, userID, productID int) error {
+	const removeQuery = `
+        WITH removed AS (
+            DELETE FROM wishlist_items wi
+            USING wishlists w
+            WHERE wi.wishlist_id = w.id AND w.user_id = $1 AND wi.product_id = $2
+            RETURNING wi.wishlist_id
+        )
+        UPDATE wishlists SET updated_at = CURRENT_TIMESTAMP
+        WHERE id IN (SELECT wishlist_id FROM removed)`
+
+	result, err := r.db.Exec(ctx, removeQuery, userID, productID)
+	if err != nil {
+		logrus.Errorf("Failed to remove product from wishlist (user_id: %d, product_id: %d): %v", userID, productID, err)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		logrus.Infof("Wishlist item not found (user_id: %d, product_id: %d)", userID, productID)
+		return sql.ErrNoRows
+	}
+
+	logrus.Debugf("Product removed from wishlist successfully (user_id: %d, product_id: %d)", userID, productID)
+	return nil
+}
+
+// Share gives the wishlist a new share token, creating the wishlist if
+// needed. Links with the previous token stop working.
+func (r *wishlistRepository) Share(ctx context.Context, userID int) error {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		logrus.Errorf("Failed to generate wishlist share token: %v", err)
+		return err
+	}
+	token := hex.EncodeToString(buf)
+
+	const shareQuery = `
+        INSERT INTO wishlists (user_id, share_token) VALUES ($1, $2)
+        ON CONFLICT (user_id) DO UPDATE SET share_token = EXCLUDED.share_token, updated_at = CURRENT_TIMESTAMP`
+
+	if _, err := r.db.Exec(ctx, shareQuery, userID, token); err != nil {
+		logrus.Errorf("Failed to share wishlist (user_id: %d): %v", userID, err)
+		return err
+	}
+
+	logrus.Debugf("Wishlist shared successfully (user_id: %d)", userID)
+	return nil
+}
+
+func (r *wishlistRepository) Unshare(ctx context.Context, userID int) error {
+	const unshareQuery = `UPDATE wishlists SET share_token = NULL, updated_at = CURRENT_TIMESTAMP WHERE user_id = $1`
+
+	result, err := r.db.Exec(ctx, unshareQuery, userID)
+	if err != nil {
+		logrus.Errorf("Failed to unshare wishlist (user_id: %d): %v", userID, err)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		logrus.Infof("Wishlist not found (user_id: %d)", userID)
+		return sql.ErrNoRows
+	}
+
+	logrus.Debugf("Wishlist unshared successfully (user_id: %d)", userID)
+	return nil
+}
@@ -0,0 +1,80 @@
+package wishlist
+
+import (
+	"context"
+	"e-commerce/internal/domains"
+)
+
+type WishlistService interface {
+	GetWishlist(ctx context.Context, userID int) (*domains.Wishlist, error)
+	AddItem(ctx context.Context, userID int, req *domains.WishlistItemRequest) (*domains.Wishlist, error)
+	RemoveItem(ctx context.Context, userID, productID int) (*domains.Wishlist, error)
+	Share(ctx context.Context, userID int) (*domains.Wishlist, error)
+	Unshare(ctx context.Context, userID int) error
+	GetSharedWishlist(ctx context.Context, token string) (*domains.Wishlist, error)
+}
+
+type wishlistService struct {
+	repo     WishlistRepository
+	currency string
+}
+
+func NewWishlistService(repo WishlistRepository, currency string) WishlistService {
+	return &wishlistService{repo: repo, currency: currency}
+}
+
+func (s *wishlistService) GetWishlist(ctx context.Context, userID int) (*domains.Wishlist, error) {
+	wishlist, err := s.repo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.present(wishlist), nil
+}
+
+func (s *wishlistService) AddItem(ctx context.Context, userID int, req *domains.WishlistItemRequest) (*domains.Wishlist, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.AddItem(ctx, userID, req.ProductID); err != nil {
+		return nil, err
+	}
+	return s.GetWishlist(ctx, userID)
+}
+
+func (s *wishlistService) RemoveItem(ctx context.Context, userID, productID int) (*domains.Wishlist, error) {
+	if err := s.repo.RemoveItem(ctx, userID, productID); err != nil {
+		return nil, err
+	}
+	return s.GetWishlist(ctx, userID)
+}
+
+// Share returns the wishlist with a fresh share token. Sharing again
+// replaces the token, which revokes links handed out before.
+func (s *wishlistService) Share(ctx context.Context, userID int) (*domains.Wishlist, error) {
+	if err := s.repo.Share(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.GetWishlist(ctx, userID)
+}
+
+func (s *wishlistService) Unshare(ctx context.Context, userID int) error {
+	return s.repo.Unshare(ctx, userID)
+}
+
+// GetSharedWishlist returns a shared wishlist without the owner's user ID or
+// the token.
+func (s *wishlistService) GetSharedWishlist(ctx context.Context, token string) (*domains.Wishlist, error) {
+	wishlist, err := s.repo.GetByShareToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	wishlist.UserID = nil
+	wishlist.ShareToken = ""
+	return s.present(wishlist), nil
+}
+
+func (s *wishlistService) present(wishlist *domains.Wishlist) *domains.Wishlist {
+	wishlist.ItemCount = len(wishlist.Items)
+	wishlist.Currency = s.currency
+	return wishlist
+}
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_product_variants_price;
+DROP INDEX IF EXISTS idx_product_variants_product_id;
+
+DROP TABLE IF EXISTS product_variants;
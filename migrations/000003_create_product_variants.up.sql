@@ -0,0 +1,22 @@
+CREATE TABLE product_variants (
+    id SERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    sku VARCHAR(64) NOT NULL UNIQUE,
+    barcode VARCHAR(14) UNIQUE,
+    volume NUMERIC(10, 2),
+    volume_unit VARCHAR(10),
+    shade VARCHAR(100),
+    price NUMERIC(10, 2) NOT NULL,
+    weight_grams NUMERIC(10, 2),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (sku <> ''),
+    CHECK (barcode IS NULL OR barcode ~ '^[0-9]{8,14}$'),
+    CHECK (volume IS NULL OR volume > 0),
+    CHECK (volume IS NULL OR volume_unit IS NOT NULL),
+    CHECK (price >= 0),
+    CHECK (weight_grams IS NULL OR weight_grams > 0)
+);
+
+CREATE INDEX idx_product_variants_product_id ON product_variants(product_id);
+CREATE INDEX idx_product_variants_price ON product_variants(product_id, price);
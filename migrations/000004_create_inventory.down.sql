@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS trg_stock_movements_append_only ON stock_movements;
+DROP FUNCTION IF EXISTS prevent_stock_movement_update();
+
+DROP INDEX IF EXISTS idx_stock_movements_product_id;
+DROP INDEX IF EXISTS idx_stock_levels_variant_id;
+DROP INDEX IF EXISTS idx_stock_levels_product_variant;
+
+DROP TABLE IF EXISTS stock_movements;
+DROP TABLE IF EXISTS stock_levels;
@@ -0,0 +1,37 @@
+CREATE TABLE stock_levels (
+    id SERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id INT REFERENCES product_variants(id) ON DELETE CASCADE,
+    quantity INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (quantity >= 0)
+);
+
+CREATE TABLE stock_movements (
+    id SERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id INT REFERENCES product_variants(id) ON DELETE CASCADE,
+    movement_type VARCHAR(20) NOT NULL,
+    quantity INT NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (movement_type IN ('receipt', 'sale', 'adjustment', 'return')),
+    CHECK (quantity <> 0)
+);
+
+-- Movements form an append-only ledger: rows may only disappear together
+-- with their product or variant.
+CREATE FUNCTION prevent_stock_movement_update() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_stock_movements_append_only
+    BEFORE UPDATE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION prevent_stock_movement_update();
+
+CREATE UNIQUE INDEX idx_stock_levels_product_variant
+    ON stock_levels(product_id, (COALESCE(variant_id, 0)));
+CREATE INDEX idx_stock_levels_variant_id ON stock_levels(variant_id);
+CREATE INDEX idx_stock_movements_product_id ON stock_movements(product_id, created_at);
@@ -0,0 +1,30 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_warehouses_priority;
+DROP INDEX IF EXISTS idx_stock_levels_product_id;
+DROP INDEX IF EXISTS idx_stock_levels_warehouse_product_variant;
+
+-- Fold per-warehouse stock back into a single level per product/variant.
+UPDATE stock_levels sl
+SET quantity = totals.quantity
+FROM (
+    SELECT product_id, COALESCE(variant_id, 0) AS variant_key, SUM(quantity) AS quantity
+    FROM stock_levels
+    GROUP BY product_id, COALESCE(variant_id, 0)
+) totals
+WHERE sl.product_id = totals.product_id AND COALESCE(sl.variant_id, 0) = totals.variant_key;
+DELETE FROM stock_levels sl
+USING stock_levels other
+WHERE sl.product_id = other.product_id
+  AND COALESCE(sl.variant_id, 0) = COALESCE(other.variant_id, 0)
+  AND sl.id > other.id;
+
+CREATE UNIQUE INDEX idx_stock_levels_product_variant
+    ON stock_levels(product_id, (COALESCE(variant_id, 0)));
+
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS warehouse_id;
+ALTER TABLE stock_levels DROP COLUMN IF EXISTS warehouse_id;
+
+DROP TABLE IF EXISTS warehouses;
+
+COMMIT;
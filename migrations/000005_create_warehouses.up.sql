@@ -0,0 +1,33 @@
+BEGIN;
+
+CREATE TABLE warehouses (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(20) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    address TEXT,
+    priority INT NOT NULL DEFAULT 100,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    CHECK (code <> ''),
+    CHECK (name <> '')
+);
+
+-- Existing stock is assigned to the main warehouse.
+INSERT INTO warehouses (code, name, priority) VALUES ('MAIN', 'Основной склад', 1);
+
+ALTER TABLE stock_levels ADD COLUMN warehouse_id INT REFERENCES warehouses(id) ON DELETE RESTRICT;
+UPDATE stock_levels SET warehouse_id = (SELECT id FROM warehouses WHERE code = 'MAIN');
+ALTER TABLE stock_levels ALTER COLUMN warehouse_id SET NOT NULL;
+
+ALTER TABLE stock_movements ADD COLUMN warehouse_id INT REFERENCES warehouses(id) ON DELETE RESTRICT;
+ALTER TABLE stock_movements DISABLE TRIGGER trg_stock_movements_append_only;
+UPDATE stock_movements SET warehouse_id = (SELECT id FROM warehouses WHERE code = 'MAIN');
+ALTER TABLE stock_movements ENABLE TRIGGER trg_stock_movements_append_only;
+ALTER TABLE stock_movements ALTER COLUMN warehouse_id SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_stock_levels_product_variant;
+CREATE UNIQUE INDEX idx_stock_levels_warehouse_product_variant
+    ON stock_levels(warehouse_id, product_id, (COALESCE(variant_id, 0)));
+CREATE INDEX idx_stock_levels_product_id ON stock_levels(product_id);
+CREATE INDEX idx_warehouses_priority ON warehouses(priority) WHERE is_active;
+
+COMMIT;
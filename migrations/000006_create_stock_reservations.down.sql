@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_stock_reservations_expires_at;
+DROP INDEX IF EXISTS idx_stock_reservations_active;
+DROP TABLE IF EXISTS stock_reservations;
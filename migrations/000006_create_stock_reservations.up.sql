@@ -0,0 +1,19 @@
+CREATE TABLE stock_reservations (
+    id SERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id INT REFERENCES product_variants(id) ON DELETE CASCADE,
+    quantity INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    released_at TIMESTAMP,
+    CHECK (quantity > 0),
+    CHECK (status IN ('active', 'released', 'expired'))
+);
+
+CREATE INDEX idx_stock_reservations_active
+    ON stock_reservations(product_id, (COALESCE(variant_id, 0)))
+    WHERE status = 'active';
+CREATE INDEX idx_stock_reservations_expires_at
+    ON stock_reservations(expires_at)
+    WHERE status = 'active';
@@ -0,0 +1,12 @@
+-- Carts of signed-in customers. Guest carts live in Redis under their token.
+-- Items hold product/variant references and quantities only; prices are
+-- recalculated from the catalog whenever the cart is read.
+CREATE TABLE carts (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL UNIQUE,
+    items JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (user_id > 0),
+    CHECK (jsonb_typeof(items) = 'array')
+);
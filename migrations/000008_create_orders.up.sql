@@ -0,0 +1,45 @@
+CREATE TABLE orders (
+    id SERIAL PRIMARY KEY,
+    user_id INT,
+    email VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    subtotal NUMERIC(12, 2) NOT NULL,
+    total NUMERIC(12, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('pending', 'paid', 'fulfilled', 'shipped', 'delivered', 'cancelled', 'refunded')),
+    CHECK (user_id IS NOT NULL OR email IS NOT NULL),
+    CHECK (subtotal >= 0),
+    CHECK (total >= 0)
+);
+
+-- Lines keep a snapshot of the product name, SKU and price at checkout, so
+-- later catalog changes or deletions do not alter placed orders.
+CREATE TABLE order_items (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    product_id INT REFERENCES products(id) ON DELETE SET NULL,
+    variant_id INT REFERENCES product_variants(id) ON DELETE SET NULL,
+    product_name VARCHAR(255) NOT NULL,
+    sku VARCHAR(64),
+    quantity INT NOT NULL,
+    unit_price NUMERIC(10, 2) NOT NULL,
+    line_total NUMERIC(12, 2) NOT NULL,
+    CHECK (quantity > 0),
+    CHECK (unit_price >= 0)
+);
+
+CREATE TABLE order_status_history (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_orders_user_id ON orders(user_id, created_at);
+CREATE INDEX idx_orders_status ON orders(status, created_at);
+CREATE INDEX idx_orders_created_at ON orders(created_at);
+CREATE INDEX idx_order_items_order_id ON order_items(order_id);
+CREATE INDEX idx_order_status_history_order_id ON order_status_history(order_id, created_at);
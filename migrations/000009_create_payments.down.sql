@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_payments_live_order;
+DROP INDEX IF EXISTS idx_payments_order_id;
+DROP INDEX IF EXISTS idx_payments_provider_ref;
+
+DROP TABLE IF EXISTS payment_webhook_events;
+DROP TABLE IF EXISTS payments;
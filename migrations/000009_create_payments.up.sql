@@ -0,0 +1,36 @@
+CREATE TABLE payments (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE RESTRICT,
+    provider VARCHAR(50) NOT NULL,
+    provider_ref VARCHAR(100),
+    status VARCHAR(20) NOT NULL,
+    amount NUMERIC(12, 2) NOT NULL,
+    captured_amount NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    refunded_amount NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    failure_reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('authorized', 'captured', 'refunded', 'voided', 'failed')),
+    CHECK (amount >= 0),
+    CHECK (captured_amount >= 0 AND captured_amount <= amount),
+    CHECK (refunded_amount >= 0 AND refunded_amount <= captured_amount)
+);
+
+-- Processed webhook deliveries. Providers retry deliveries, so an event is
+-- applied only once per provider and event ID.
+CREATE TABLE payment_webhook_events (
+    id SERIAL PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    event_id VARCHAR(100) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    processed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, event_id)
+);
+
+CREATE UNIQUE INDEX idx_payments_provider_ref ON payments(provider, provider_ref) WHERE provider_ref IS NOT NULL;
+CREATE INDEX idx_payments_order_id ON payments(order_id);
+
+-- An order has at most one payment that still holds or has taken money;
+-- failed and voided attempts may be retried.
+CREATE UNIQUE INDEX idx_payments_live_order ON payments(order_id) WHERE status IN ('authorized', 'captured');
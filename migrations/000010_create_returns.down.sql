@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_return_status_history_return_id;
+DROP INDEX IF EXISTS idx_return_requests_order_item_id;
+DROP INDEX IF EXISTS idx_return_requests_order_id;
+
+DROP TABLE IF EXISTS return_status_history;
+DROP TABLE IF EXISTS return_requests;
@@ -0,0 +1,33 @@
+-- A return is 'refunding' while its refund is with the payment provider,
+-- so two refund requests cannot both reach the provider.
+CREATE TABLE return_requests (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    order_item_id INT NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    quantity INT NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+    comment TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'requested',
+    restocked BOOLEAN NOT NULL DEFAULT FALSE,
+    refund_amount NUMERIC(12, 2),
+    payment_id INT REFERENCES payments(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (quantity > 0),
+    CHECK (reason IN ('damaged', 'wrong_item', 'allergic_reaction', 'not_as_described', 'changed_mind', 'other')),
+    CHECK (status IN ('requested', 'approved', 'rejected', 'refunding', 'refunded')),
+    CHECK (refund_amount IS NULL OR refund_amount > 0)
+);
+
+CREATE TABLE return_status_history (
+    id SERIAL PRIMARY KEY,
+    return_id INT NOT NULL REFERENCES return_requests(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_return_requests_order_id ON return_requests(order_id);
+CREATE INDEX idx_return_requests_order_item_id ON return_requests(order_item_id);
+CREATE INDEX idx_return_status_history_return_id ON return_status_history(return_id, created_at);
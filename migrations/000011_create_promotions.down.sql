@@ -0,0 +1,7 @@
+ALTER TABLE order_items DROP COLUMN IF EXISTS discount;
+ALTER TABLE orders DROP COLUMN IF EXISTS promotion_ids;
+ALTER TABLE orders DROP COLUMN IF EXISTS discount_total;
+
+DROP INDEX IF EXISTS idx_promotions_active;
+
+DROP TABLE IF EXISTS promotions;
@@ -0,0 +1,37 @@
+-- A promotion without a coupon code applies automatically to every matching
+-- cart. target_ids holds product, brand, category or skin type IDs depending
+-- on target_type and is empty for store-wide promotions.
+CREATE TABLE promotions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    discount_type VARCHAR(20) NOT NULL,
+    discount_value NUMERIC(10, 2) NOT NULL,
+    target_type VARCHAR(20) NOT NULL DEFAULT 'all',
+    target_ids INT[] NOT NULL DEFAULT '{}',
+    min_order_amount NUMERIC(12, 2),
+    coupon_code VARCHAR(50) UNIQUE,
+    usage_limit INT,
+    usage_count INT NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    priority INT NOT NULL DEFAULT 100,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (discount_type IN ('percentage', 'fixed')),
+    CHECK (discount_value > 0),
+    CHECK (discount_type <> 'percentage' OR discount_value <= 100),
+    CHECK (target_type IN ('all', 'product', 'brand', 'category', 'skin_type')),
+    CHECK (coupon_code <> ''),
+    CHECK (usage_limit IS NULL OR usage_limit > 0),
+    CHECK (usage_limit IS NULL OR usage_count <= usage_limit),
+    CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX idx_promotions_active ON promotions(priority, id) WHERE is_active;
+
+ALTER TABLE orders ADD COLUMN discount_total NUMERIC(12, 2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN promotion_ids INT[] NOT NULL DEFAULT '{}';
+ALTER TABLE order_items ADD COLUMN discount NUMERIC(12, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_product_prices_product_id;
+
+DROP FUNCTION IF EXISTS product_current_price(NUMERIC, NUMERIC, TIMESTAMP, TIMESTAMP);
+DROP TRIGGER IF EXISTS trg_products_price_update ON products;
+DROP TRIGGER IF EXISTS trg_products_price_insert ON products;
+DROP FUNCTION IF EXISTS record_product_price();
+
+DROP TABLE IF EXISTS product_prices;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS chk_products_sale_window;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS chk_products_sale_price;
+ALTER TABLE products DROP COLUMN IF EXISTS sale_ends_at;
+ALTER TABLE products DROP COLUMN IF EXISTS sale_starts_at;
+ALTER TABLE products DROP COLUMN IF EXISTS sale_price;
@@ -0,0 +1,58 @@
+ALTER TABLE products ADD COLUMN sale_price NUMERIC(10, 2);
+ALTER TABLE products ADD COLUMN sale_starts_at TIMESTAMP;
+ALTER TABLE products ADD COLUMN sale_ends_at TIMESTAMP;
+ALTER TABLE products ADD CONSTRAINT chk_products_sale_price
+    CHECK (sale_price IS NULL OR (sale_price >= 0 AND sale_price < price));
+ALTER TABLE products ADD CONSTRAINT chk_products_sale_window
+    CHECK (sale_ends_at IS NULL OR sale_starts_at IS NULL OR sale_ends_at > sale_starts_at);
+
+-- Every price a product has had, including scheduled sales. Rows are written
+-- by triggers on products, so no code path can change a price unrecorded.
+CREATE TABLE product_prices (
+    id SERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    price NUMERIC(10, 2) NOT NULL,
+    sale_price NUMERIC(10, 2),
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO product_prices (product_id, price, changed_at)
+SELECT id, price, COALESCE(updated_at, created_at, CURRENT_TIMESTAMP) FROM products;
+
+CREATE FUNCTION record_product_price() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO product_prices (product_id, price, sale_price, starts_at, ends_at)
+    VALUES (NEW.id, NEW.price, NEW.sale_price, NEW.sale_starts_at, NEW.sale_ends_at);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_products_price_insert
+    AFTER INSERT ON products
+    FOR EACH ROW EXECUTE FUNCTION record_product_price();
+
+CREATE TRIGGER trg_products_price_update
+    AFTER UPDATE OF price, sale_price, sale_starts_at, sale_ends_at ON products
+    FOR EACH ROW
+    WHEN ((OLD.price, OLD.sale_price, OLD.sale_starts_at, OLD.sale_ends_at)
+          IS DISTINCT FROM (NEW.price, NEW.sale_price, NEW.sale_starts_at, NEW.sale_ends_at))
+    EXECUTE FUNCTION record_product_price();
+
+-- The price a product sells at right now: the sale price while its window is
+-- open, the regular price otherwise. Sale windows are stored as UTC wall-clock
+-- times, so they are compared with the current time in UTC whatever the
+-- session time zone.
+CREATE FUNCTION product_current_price(price NUMERIC, sale_price NUMERIC, starts_at TIMESTAMP, ends_at TIMESTAMP)
+RETURNS NUMERIC AS $$
+    SELECT CASE
+        WHEN sale_price IS NOT NULL
+             AND (starts_at IS NULL OR starts_at <= (now() AT TIME ZONE 'UTC'))
+             AND (ends_at IS NULL OR ends_at > (now() AT TIME ZONE 'UTC'))
+        THEN sale_price
+        ELSE price
+    END
+$$ LANGUAGE sql STABLE;
+
+CREATE INDEX idx_product_prices_product_id ON product_prices(product_id, changed_at);
@@ -0,0 +1,11 @@
+-- rate is the number of units of currency per unit of the base currency set
+-- in the application config.
+CREATE TABLE exchange_rates (
+    currency CHAR(3) PRIMARY KEY,
+    rate NUMERIC(18, 8) NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (currency ~ '^[A-Z]{3}$'),
+    CHECK (rate > 0)
+);
+
+ALTER TABLE orders ADD COLUMN currency CHAR(3);
@@ -0,0 +1,15 @@
+ALTER TABLE order_items DROP COLUMN IF EXISTS tax_rate;
+ALTER TABLE order_items DROP COLUMN IF EXISTS tax;
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_region;
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_country;
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_included;
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_total;
+
+ALTER TABLE products DROP COLUMN IF EXISTS tax_class_id;
+ALTER TABLE categories DROP COLUMN IF EXISTS tax_class_id;
+
+DROP TABLE IF EXISTS tax_rates;
+
+DROP INDEX IF EXISTS idx_tax_classes_default;
+
+DROP TABLE IF EXISTS tax_classes;
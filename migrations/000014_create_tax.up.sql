@@ -0,0 +1,38 @@
+-- At most one tax class is the default; it applies to products whose own
+-- class and category class are both unset.
+CREATE TABLE tax_classes (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_tax_classes_default ON tax_classes (is_default) WHERE is_default;
+
+-- An empty region is the country-wide rate, used when a region has no rate
+-- of its own. rate is a percentage.
+CREATE TABLE tax_rates (
+    id SERIAL PRIMARY KEY,
+    tax_class_id INT NOT NULL REFERENCES tax_classes(id) ON DELETE CASCADE,
+    country CHAR(2) NOT NULL,
+    region VARCHAR(100) NOT NULL DEFAULT '',
+    rate NUMERIC(7, 4) NOT NULL,
+    name VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tax_class_id, country, region),
+    CHECK (country ~ '^[A-Z]{2}$'),
+    CHECK (rate >= 0 AND rate <= 100)
+);
+
+ALTER TABLE categories ADD COLUMN tax_class_id INT REFERENCES tax_classes(id) ON DELETE SET NULL;
+ALTER TABLE products ADD COLUMN tax_class_id INT REFERENCES tax_classes(id) ON DELETE SET NULL;
+
+ALTER TABLE orders ADD COLUMN tax_total NUMERIC(12, 2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN tax_included BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE orders ADD COLUMN tax_country CHAR(2);
+ALTER TABLE orders ADD COLUMN tax_region VARCHAR(100);
+ALTER TABLE order_items ADD COLUMN tax NUMERIC(12, 2) NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN tax_rate NUMERIC(7, 4) NOT NULL DEFAULT 0;
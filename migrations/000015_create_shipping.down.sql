@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_shipping_methods_zone;
+
+DROP TABLE IF EXISTS shipping_methods;
+DROP TABLE IF EXISTS shipping_zones;
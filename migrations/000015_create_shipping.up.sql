@@ -0,0 +1,37 @@
+-- A zone covers the listed countries, narrowed to postal codes starting with
+-- one of postal_prefixes when any are given. Quotes use the first matching
+-- zone by priority.
+CREATE TABLE shipping_zones (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    countries CHAR(2)[] NOT NULL,
+    postal_prefixes TEXT[] NOT NULL DEFAULT '{}',
+    priority INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (cardinality(countries) > 0)
+);
+
+-- weight_tiers holds [{"max_weight_grams": ..., "price": ...}] in ascending
+-- order for weight-based methods. Free methods need a cart subtotal of at
+-- least threshold.
+CREATE TABLE shipping_methods (
+    id SERIAL PRIMARY KEY,
+    zone_id INT NOT NULL REFERENCES shipping_zones(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    method_type VARCHAR(20) NOT NULL,
+    price NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    threshold NUMERIC(12, 2),
+    weight_tiers JSONB NOT NULL DEFAULT '[]',
+    pickup_address TEXT,
+    estimated_days INT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (method_type IN ('flat', 'weight', 'free', 'pickup')),
+    CHECK (price >= 0),
+    CHECK (threshold IS NULL OR threshold > 0),
+    CHECK (estimated_days IS NULL OR estimated_days >= 0)
+);
+
+CREATE INDEX idx_shipping_methods_zone ON shipping_methods (zone_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_wishlist_items_product;
+
+DROP TABLE IF EXISTS wishlist_items;
+DROP TABLE IF EXISTS wishlists;
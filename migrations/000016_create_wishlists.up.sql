@@ -0,0 +1,19 @@
+-- One wishlist per customer. share_token is set while the customer shares
+-- the wishlist publicly and cleared when they stop.
+CREATE TABLE wishlists (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL UNIQUE,
+    share_token VARCHAR(64) UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (user_id > 0)
+);
+
+CREATE TABLE wishlist_items (
+    wishlist_id INT NOT NULL REFERENCES wishlists(id) ON DELETE CASCADE,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (wishlist_id, product_id)
+);
+
+CREATE INDEX idx_wishlist_items_product ON wishlist_items (product_id);
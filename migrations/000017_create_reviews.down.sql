@@ -0,0 +1,7 @@
+ALTER TABLE products DROP COLUMN IF EXISTS rating_count;
+ALTER TABLE products DROP COLUMN IF EXISTS rating_average;
+
+DROP INDEX IF EXISTS idx_reviews_status;
+DROP INDEX IF EXISTS idx_reviews_product_status;
+
+DROP TABLE IF EXISTS reviews;
@@ -0,0 +1,28 @@
+-- Reviews start pending and count towards a product's rating once approved.
+-- A customer reviews a product at most once.
+CREATE TABLE reviews (
+    id SERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id INT NOT NULL,
+    skin_type_id INT REFERENCES skin_types(id) ON DELETE SET NULL,
+    rating SMALLINT NOT NULL,
+    title VARCHAR(255),
+    body TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    moderation_note TEXT,
+    moderated_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (product_id, user_id),
+    CHECK (user_id > 0),
+    CHECK (rating BETWEEN 1 AND 5),
+    CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+CREATE INDEX idx_reviews_product_status ON reviews (product_id, status);
+CREATE INDEX idx_reviews_status ON reviews (status, created_at);
+
+-- Aggregates of approved reviews, kept up to date on moderation so product
+-- listings can filter and sort by rating.
+ALTER TABLE products ADD COLUMN rating_average NUMERIC(3, 2) NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN rating_count INT NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS product_answer_votes;
+
+DROP INDEX IF EXISTS idx_product_answers_status;
+DROP INDEX IF EXISTS idx_product_answers_question;
+DROP TABLE IF EXISTS product_answers;
+
+DROP INDEX IF EXISTS idx_product_questions_status;
+DROP INDEX IF EXISTS idx_product_questions_product_status;
+DROP TABLE IF EXISTS product_questions;
@@ -0,0 +1,50 @@
+-- Customer questions about a product and their answers. Both go through
+-- moderation like reviews; staff answers are published immediately.
+CREATE TABLE product_questions (
+    id SERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    user_id INT NOT NULL,
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    moderation_note TEXT,
+    moderated_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (user_id > 0),
+    CHECK (body <> ''),
+    CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+CREATE INDEX idx_product_questions_product_status ON product_questions (product_id, status, created_at);
+CREATE INDEX idx_product_questions_status ON product_questions (status, created_at);
+
+-- upvotes mirrors the row count in product_answer_votes, one vote per
+-- customer.
+CREATE TABLE product_answers (
+    id SERIAL PRIMARY KEY,
+    question_id INT NOT NULL REFERENCES product_questions(id) ON DELETE CASCADE,
+    user_id INT NOT NULL,
+    is_staff BOOLEAN NOT NULL DEFAULT FALSE,
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    upvotes INT NOT NULL DEFAULT 0,
+    moderation_note TEXT,
+    moderated_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (user_id > 0),
+    CHECK (body <> ''),
+    CHECK (status IN ('pending', 'approved', 'rejected')),
+    CHECK (upvotes >= 0)
+);
+
+CREATE INDEX idx_product_answers_question ON product_answers (question_id, status);
+CREATE INDEX idx_product_answers_status ON product_answers (status, created_at);
+
+CREATE TABLE product_answer_votes (
+    answer_id INT NOT NULL REFERENCES product_answers(id) ON DELETE CASCADE,
+    user_id INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (answer_id, user_id),
+    CHECK (user_id > 0)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_product_ingredients_ingredient_id;
+
+DROP TABLE IF EXISTS product_ingredients;
+DROP TABLE IF EXISTS ingredients;
@@ -0,0 +1,25 @@
+-- Ingredients by INCI name. comedogenic_rating runs from 0 (won't clog
+-- pores) to 5 and is NULL when unknown.
+CREATE TABLE ingredients (
+    id SERIAL PRIMARY KEY,
+    inci_name VARCHAR(255) NOT NULL UNIQUE,
+    common_names TEXT[] NOT NULL DEFAULT '{}',
+    function TEXT,
+    comedogenic_rating SMALLINT,
+    is_allergen BOOLEAN NOT NULL DEFAULT FALSE,
+    CHECK (inci_name <> ''),
+    CHECK (comedogenic_rating BETWEEN 0 AND 5)
+);
+
+-- position orders a product's ingredient list as printed on the label,
+-- starting at 1.
+CREATE TABLE product_ingredients (
+    product_id INT REFERENCES products(id) ON DELETE CASCADE,
+    ingredient_id INT REFERENCES ingredients(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    PRIMARY KEY (product_id, ingredient_id),
+    UNIQUE (product_id, position),
+    CHECK (position > 0)
+);
+
+CREATE INDEX idx_product_ingredients_ingredient_id ON product_ingredients(ingredient_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_ingredient_conflicts_conflicting;
+DROP TABLE IF EXISTS ingredient_conflicts;
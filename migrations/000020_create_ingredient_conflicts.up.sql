@@ -0,0 +1,16 @@
+-- Pairs of ingredients that should not be used in the same routine step.
+-- Each pair is stored once with the lower ingredient id first.
+CREATE TABLE ingredient_conflicts (
+    id SERIAL PRIMARY KEY,
+    ingredient_id INT NOT NULL REFERENCES ingredients(id) ON DELETE CASCADE,
+    conflicting_ingredient_id INT NOT NULL REFERENCES ingredients(id) ON DELETE CASCADE,
+    severity VARCHAR(10) NOT NULL,
+    explanation TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (ingredient_id, conflicting_ingredient_id),
+    CHECK (ingredient_id < conflicting_ingredient_id),
+    CHECK (severity IN ('low', 'medium', 'high'))
+);
+
+CREATE INDEX idx_ingredient_conflicts_conflicting ON ingredient_conflicts(conflicting_ingredient_id);
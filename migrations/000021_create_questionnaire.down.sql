@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS questionnaire_options;
+DROP TABLE IF EXISTS questionnaire_questions;
+
+DROP INDEX IF EXISTS idx_products_concerns;
+ALTER TABLE products DROP COLUMN IF EXISTS concerns;
@@ -0,0 +1,30 @@
+-- Skin concerns a product addresses.
+ALTER TABLE products ADD COLUMN concerns TEXT[] NOT NULL DEFAULT '{}'
+    CHECK (concerns <@ ARRAY['acne', 'pigmentation', 'sensitivity', 'aging', 'dryness', 'oiliness']::TEXT[]);
+
+CREATE INDEX idx_products_concerns ON products USING GIN (concerns);
+
+-- Skin questionnaire. Each option adds weight to skin types and concerns;
+-- the weights of the chosen options make up the customer's skin profile.
+CREATE TABLE questionnaire_questions (
+    id SERIAL PRIMARY KEY,
+    text TEXT NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    multiple_choice BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (text <> '')
+);
+
+-- skin_type_weights holds [{"skin_type_id": 1, "weight": 1.5}] and
+-- concern_weights [{"concern": "acne", "weight": 1}].
+CREATE TABLE questionnaire_options (
+    id SERIAL PRIMARY KEY,
+    question_id INT NOT NULL REFERENCES questionnaire_questions(id) ON DELETE CASCADE,
+    text TEXT NOT NULL,
+    position INT NOT NULL,
+    skin_type_weights JSONB NOT NULL DEFAULT '[]',
+    concern_weights JSONB NOT NULL DEFAULT '[]',
+    UNIQUE (question_id, position),
+    CHECK (text <> '')
+);